@@ -4,108 +4,582 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"unicode"
 
 	"github.com/Rican7/define/internal/action"
+	"github.com/Rican7/define/internal/anki"
+	"github.com/Rican7/define/internal/benchmark"
+	"github.com/Rican7/define/internal/browser"
+	"github.com/Rican7/define/internal/cache"
+	"github.com/Rican7/define/internal/clipboard"
 	"github.com/Rican7/define/internal/config"
+	"github.com/Rican7/define/internal/history"
 	defineio "github.com/Rican7/define/internal/io"
 	"github.com/Rican7/define/internal/io/printer"
+	"github.com/Rican7/define/internal/keyring"
+	"github.com/Rican7/define/internal/logger"
+	"github.com/Rican7/define/internal/postprocess"
+	"github.com/Rican7/define/internal/starred"
+	"github.com/Rican7/define/internal/transport"
+	"github.com/Rican7/define/internal/update"
 	"github.com/Rican7/define/internal/version"
+	"github.com/Rican7/define/internal/xdg"
 	"github.com/Rican7/define/registry"
 	"github.com/Rican7/define/source"
+	"github.com/Rican7/define/source/embedded"
+	"github.com/Rican7/define/source/external"
 	flag "github.com/ogier/pflag"
 
+	"github.com/Rican7/define/source/datamuse"
+	_ "github.com/Rican7/define/source/freedict"
+	_ "github.com/Rican7/define/source/freelang"
 	_ "github.com/Rican7/define/source/glosbe"
 	"github.com/Rican7/define/source/oxford"
+	"github.com/Rican7/define/source/translate"
+	_ "github.com/Rican7/define/source/wdlexeme"
 	_ "github.com/Rican7/define/source/webster"
+	"github.com/Rican7/define/source/wiktionary"
+	_ "github.com/Rican7/define/source/wordcentral"
 )
 
 const (
 	// Configuration defaults
-	defaultConfigFileLocation = "~/.define.conf.json"
-	defaultIndentationSize    = 2
-	defaultPreferredSource    = oxford.JSONKey
+	legacyConfigFileLocation       = "~/.define.conf.json"
+	systemConfigFileLocation       = "/etc/define/config.json"
+	defaultCredentialsFileLocation = "~/.define.credentials.json"
+	defaultIndentationSize         = 2
+	defaultPreferredSource         = oxford.JSONKey
+	defaultTimeout                 = 10 * time.Second
+	defaultCacheTTL                = "0s"
+	defaultCacheMaxSizeMB          = 50
+
+	// legacyConfigHintFileName is the name of the file (in the data directory)
+	// marking that the legacy config file location hint has been shown
+	legacyConfigHintFileName = "legacy-config-hinted"
+
+	// maxSuggestions is the maximum number of suggested words to offer
+	maxSuggestions = 5
+
+	// defaultHistoryLimit is the default number of history records to print
+	defaultHistoryLimit = 10
+
+	// redactedPlaceholder is printed in place of the values of secrets
+	redactedPlaceholder = "(redacted)"
+
+	// notFoundExitCode is the exit code when words aren't found by any source
+	notFoundExitCode = 3
+
+	// networkFailureExitCode is the exit code when the lookups fail to finish,
+	// such as when the overall timeout is exceeded
+	networkFailureExitCode = 4
+
+	// interruptedExitCode is the exit code when the lookups are cancelled by
+	// an interrupt (Ctrl-C), by the convention of 128 plus the signal number
+	interruptedExitCode = 130
+
+	// interruptGracePeriod is how long an interrupt waits for the cancelled
+	// lookups to be reported, before it exits the app itself (such as when it
+	// interrupts a prompt, rather than a lookup)
+	interruptGracePeriod = time.Second
+
+	// maxWatchedLength is the maximum length (in characters) of the clipboard
+	// text to define when watching the clipboard
+	maxWatchedLength = 40
+
+	// maxWatchedWords is the maximum number of words of the clipboard text to
+	// define when watching the clipboard
+	maxWatchedWords = 3
+
+	// translationSourceLanguage is the language (by ISO 639-1 code) that
+	// defined words are translated from
+	translationSourceLanguage = "en"
+
+	// relatedWordsLimit is the maximum number of related words to print
+	relatedWordsLimit = 10
+
+	// relatedWordsTimeout is the time limit of finding related words (within
+	// the overall timeout), so that an unresponsive source can't hold up the
+	// definitions
+	relatedWordsTimeout = 5 * time.Second
 )
 
 var (
 	stdErrWriter = defineio.NewPanicWriter(os.Stderr, defaultIndentationSize)
 	stdOutWriter = defineio.NewPanicWriter(os.Stdout, defaultIndentationSize)
 
-	flags *flag.FlagSet
-	act   *action.Action
-	conf  config.Configuration
-	src   source.Source
+	flags    *flag.FlagSet
+	act      *action.Action
+	ankiOpts *anki.Options
+	conf     config.Configuration
+	src      source.Source
+
+	// arguments are the command line arguments, without any subcommand
+	arguments []string
+
+	// providerFlags are the flags of the registered source providers
+	providerFlags *flag.FlagSet
+
+	// providerConfs are the configurations of the registered source
+	// providers, by their JSON keys
+	providerConfs map[string]registry.Configuration
+
+	// fallbackSrc is the source of last resort, if any, for words that the
+	// selected source fails to define
+	fallbackSrc source.Source
+
+	// thesaurusSrc is the source, if any, of the synonyms and antonyms added
+	// to those of the printed results
+	thesaurusSrc source.Source
+
+	// abbreviationSrc is the source, if any, that abbreviations and acronyms
+	// are looked up with first, for their expansions
+	abbreviationSrc source.Source
+
+	// resultCache is the on-disk cache of source results, if caching is
+	// enabled
+	resultCache *cache.Disk
+
+	// runCtx bounds all of the run's lookups by the overall timeout
+	runCtx    context.Context    = context.Background()
+	cancelRun context.CancelFunc = func() {}
+
+	// interruptCtx is the parent of runCtx that's cancelled when the run is
+	// interrupted, so that cancelled lookups can be told apart from those
+	// that timed out
+	interruptCtx context.Context = context.Background()
+
+	// attempts records each source lookup of the run, guarded by attemptsMutex
+	attempts      []lookupAttempt
+	attemptsMutex sync.Mutex
+
+	// outputMutex guards the writers against the interleaved output of
+	// concurrent lookups
+	outputMutex sync.Mutex
 )
 
 func init() {
 	var err error
+	var subcommand *action.Subcommand
 
-	flags = flag.NewFlagSet(version.AppName, flag.ContinueOnError)
-	flags.SetOutput(stdErrWriter)
-	flags.Usage = func() {
+	// Subcommands (such as "define sources") have their own flags, while the
+	// top-level flags remain as aliases
+	subcommand, arguments = action.ParseSubcommand(os.Args[1:])
+	flags, act, ankiOpts = newFlagSet(subcommand)
+
+	// Configure our registered providers, with their flags kept apart so that
+	// they can be added to the flag set of a reload
+	providerFlags = flag.NewFlagSet(version.AppName, flag.ContinueOnError)
+	providerConfs = registry.ConfigureProviders(providerFlags)
+
+	if len(providerConfs) < 1 {
+		handleError(fmt.Errorf("no registered source providers"))
+	}
+
+	addProviderFlags(flags)
+
+	conf, err = loadConfig(flags)
+
+	// Re-initialize our writers once we have our indentation size configuration
+	initWriters()
+
+	// Finalize our configurations
+	registry.Finalize(providerConfList()...)
+
+	// Validating, initializing, or modifying the config file don't depend on
+	// a successfully loaded configuration (and report any of its problems)
+	switch act.Type() {
+	case action.ValidateConfig, action.InitConfig, action.MigrateConfig, action.ConfigSet, action.SetSecret:
+		return
+	}
+
+	// Report every problem of the configuration together, rather than just the
+	// first one that failed loading it
+	problems := configProblems(false)
+
+	if nil != err {
+		checkConfig(problems)
+		handleError(err)
+	}
+
+	if conf.LegacyConfigFile() {
+		hintLegacyConfigFile(defaultConfigFileLocations()[0])
+	}
+
+	// Customize the TLS connections of the transport shared by the sources
+	if tlsOpts := (transport.TLSOptions{CACertFile: conf.CACertFile, Insecure: conf.Insecure}); !tlsOpts.IsDefault() {
+		sharedTransport, err := transport.New(tlsOpts)
+
+		handleError(err)
+
+		if conf.Insecure {
+			printError(fmt.Errorf("warning: TLS certificate verification is disabled; connections to sources aren't secure"))
+		}
+
+		http.DefaultTransport = sharedTransport
+	}
+
+	// Log a summary of each HTTP request that the sources send
+	if conf.Debug() {
+		http.DefaultTransport = logger.NewTransport(http.DefaultTransport, logger.Default())
+	}
+
+	handleError(registerExecSources(conf.ExecSources))
+
+	checkConfig(append(problems, resolveSourceOptions()...))
+
+	err = selectSources()
+
+	initResultCache()
+
+	// Watching the clipboard handles its own interrupts, as they end it
+	if action.WatchClipboard != act.Type() {
+		runCtx = cancelOnInterrupt()
+	}
+
+	if 0 < conf.Timeout {
+		runCtx, cancelRun = context.WithTimeout(runCtx, time.Duration(conf.Timeout))
+	}
+
+	// Printing JSON results doesn't need a source
+	if action.DefineJSON == act.Type() {
+		err = nil
+	}
+
+	// Make sure our flags are parsed before entering main
+	handleError(err, flags.Parse(arguments))
+}
+
+// newFlagSet returns a new flag set for the given subcommand (or the top-level
+// command, if nil), with the flags of the actions (and Anki export options)
+func newFlagSet(subcommand *action.Subcommand) (*flag.FlagSet, *action.Action, *anki.Options) {
+	var flagSet *flag.FlagSet
+	var flagAct *action.Action
+	var flagAnkiOpts *anki.Options
+
+	if nil != subcommand {
+		flagSet = flag.NewFlagSet(version.AppName+" "+subcommand.Name, flag.ContinueOnError)
+	} else {
+		flagSet = flag.NewFlagSet(version.AppName, flag.ContinueOnError)
+	}
+
+	flagSet.SetOutput(stdErrWriter)
+
+	// Allow flags after the words (a "--" terminates the flags, so that words
+	// starting with a dash can be defined)
+	flagSet.SetInterspersed(true)
+	flagSet.Usage = func() {
 		printUsage(stdErrWriter)
 		quit(2)
 	}
 
-	act = action.Setup(flags)
+	if nil != subcommand {
+		flagAct = action.SetupSubcommand(flagSet, subcommand)
+	} else {
+		flagAct = action.Setup(flagSet)
+		flagAnkiOpts = anki.SetupOptions(flagSet)
+	}
 
-	// Configure our registered providers
-	providerConfs := registry.ConfigureProviders(flags)
-	var providerConfsList []registry.Configuration
+	return flagSet, flagAct, flagAnkiOpts
+}
 
-	if len(providerConfs) < 1 {
-		handleError(fmt.Errorf("no registered source providers"))
-	}
+// addProviderFlags adds the flags of the source providers to the given flag
+// set (the providers can only be configured once, so each flag set shares them)
+func addProviderFlags(flagSet *flag.FlagSet) {
+	providerFlags.VisitAll(func(providerFlag *flag.Flag) {
+		flagSet.VarP(providerFlag.Value, providerFlag.Name, providerFlag.Shorthand, providerFlag.Usage)
+	})
+}
 
-	for _, providerConf := range providerConfs {
-		providerConfsList = append(providerConfsList, providerConf)
+// defaultConfigFileLocations returns the locations that the config file is
+// searched for in, the config directory before the legacy location
+func defaultConfigFileLocations() []string {
+	return []string{
+		filepath.Join(xdg.ConfigDir(), "config.json"),
+		legacyConfigFileLocation,
 	}
+}
 
-	conf, err = config.NewFromRuntime(flags, providerConfs, defaultConfigFileLocation, config.Configuration{
-		IndentationSize: defaultIndentationSize,
-		PreferredSource: defaultPreferredSource,
+// loadConfig loads the configuration from the given flags (parsed from the
+// command line arguments), the config files, and the environment. The config
+// file is merged over any system-wide config file.
+func loadConfig(flagSet *flag.FlagSet) (config.Configuration, error) {
+	return config.NewFromRuntime(flagSet, arguments, providerConfs, defaultConfigFileLocations(), systemConfigFileLocation, config.Configuration{
+		IndentationSize:     defaultIndentationSize,
+		PreferredSource:     defaultPreferredSource,
+		HeadwordCase:        string(printer.HeadwordCaseSource),
+		Normalization:       string(source.NormalizationSmart),
+		MinSynonyms:         printer.DefaultMinSynonyms,
+		Timeout:             config.Duration(defaultTimeout),
+		MaxExamplesPerSense: printer.DefaultMaxExamplesPerSense,
+		OutputFormat:        config.OutputFormatText,
+		ThemeName:           printer.DefaultThemeName,
+		HistoryFile:         filepath.Join(xdg.DataDir(), "history.jsonl"),
+		StarredFile:         filepath.Join(xdg.DataDir(), "starred.json"),
+		CredentialsFile:     defaultCredentialsFileLocation,
+		CacheDir:            xdg.CacheDir(),
+		CacheTTL:            defaultCacheTTL,
+		CacheMaxSizeMB:      defaultCacheMaxSizeMB,
 	})
+}
+
+// initWriters initializes the writers with the configured indentation size
+func initWriters() {
+	var stdout io.Writer = os.Stdout
+
+	if conf.NoTrailingNewline() {
+		stdout = defineio.NewTrailingNewlineTrimmer(stdout)
+	}
 
-	// Re-initialize our writers once we have our indentation size configuration
 	stdErrWriter = defineio.NewPanicWriter(os.Stderr, conf.IndentationSize)
-	stdOutWriter = defineio.NewPanicWriter(os.Stdout, conf.IndentationSize)
+	stdOutWriter = defineio.NewPanicWriter(stdout, conf.IndentationSize)
 	flags.SetOutput(stdErrWriter)
+}
 
-	// Finalize our configurations
-	registry.Finalize(providerConfsList...)
+// providerConfList returns the configurations of the registered providers, in
+// the order that they were registered
+func providerConfList() []registry.Configuration {
+	return registry.ProviderConfigurations()
+}
 
-	handleError(err)
+// registerExecSources registers the sources provided by the given external
+// commands
+func registerExecSources(execSources []config.ExecSource) error {
+	for _, execSource := range execSources {
+		provider, providerConf, err := external.NewProvider(execSource.Name, execSource.Command, execSource.Args, execSource.Stdin)
+
+		if nil == err {
+			err = registry.RegisterConfigured(provider, providerConf)
+		}
+
+		if nil != err {
+			return err
+		}
+
+		providerConfs[providerConf.JSONKey()] = providerConf
+
+		logger.Debugf("define: registered the external command source %q", execSource.Name)
+	}
+
+	return nil
+}
+
+// resolveSourceOptions resolves the configured preferred source, which can be
+// given loosely (such as "oxford"), returning the problems of the configured
+// source options
+func resolveSourceOptions() []config.Problem {
+	var problems []config.Problem
+
+	if "" != conf.PreferredSource {
+		preferredSource, err := resolveSourceKey(conf.PreferredSource, providerConfList())
+
+		if nil != err {
+			problems = append(problems, config.Problem{Message: fmt.Sprintf("PreferredSource: %s", err)})
+		}
+
+		conf.PreferredSource = preferredSource
+	}
+
+	if _, exists := providerConfs[conf.Source]; "" != conf.Source && !exists {
+		problems = append(problems, config.Problem{Message: fmt.Sprintf("Source: provider/source %q does not exist", conf.Source)})
+	}
+
+	if "" != conf.ThesaurusSource {
+		thesaurusSource, err := resolveSourceKey(conf.ThesaurusSource, providerConfList())
+
+		if nil != err {
+			problems = append(problems, config.Problem{Message: fmt.Sprintf("ThesaurusSource: %s", err)})
+		}
+
+		conf.ThesaurusSource = thesaurusSource
+	}
+
+	return problems
+}
+
+// selectSources provides the configured source (or else the preferred source,
+// falling back to the others), the fallback source of last resort, the
+// thesaurus source, and the abbreviation source
+func selectSources() error {
+	var err error
+
+	src, fallbackSrc, thesaurusSrc, abbreviationSrc = nil, nil, nil, nil
+
+	// Abbreviations are still looked up with the selected source, if the
+	// abbreviation source can't be provided
+	if providerConf, exists := providerConfs[wiktionary.JSONKey]; exists && conf.ExpandAbbreviations {
+		var abbreviationErr error
+
+		if abbreviationSrc, abbreviationErr = registry.Provide(providerConf); nil != abbreviationErr {
+			printError(fmt.Errorf("warning: abbreviations can't be expanded: %s", abbreviationErr))
+		}
+	}
+
+	// The thesaurus is optional, so results are printed without it if its
+	// source can't be provided
+	if providerConf, exists := providerConfs[conf.ThesaurusSource]; exists {
+		var thesaurusErr error
+
+		if thesaurusSrc, thesaurusErr = registry.Provide(providerConf); nil != thesaurusErr {
+			printError(fmt.Errorf("warning: the thesaurus source can't be used: %s", thesaurusErr))
+		}
+	}
 
 	if "" != conf.Source {
-		if providerConf, exists := providerConfs[conf.Source]; exists {
-			src, err = registry.Provide(providerConf)
-		} else {
-			handleError(fmt.Errorf("provider/source %q does not exist", conf.Source))
+		providerConf, exists := providerConfs[conf.Source]
+
+		if !exists {
+			return fmt.Errorf("provider/source %q does not exist", conf.Source)
 		}
+
+		src, err = registry.Provide(providerConf)
 	} else {
+		providerConfsList := providerConfList()
+
+		sort.Slice(providerConfsList, func(i, j int) bool {
+			return isFallbackBefore(providerConfsList[i], providerConfsList[j])
+		})
+
 		src, err = registry.ProvidePreferred(conf.PreferredSource, providerConfsList)
+
+		// Fall back to the embedded dictionary as a source of last resort
+		if !conf.NoEmbedded && embedded.Available() {
+			fallbackSrc = embedded.New()
+
+			if nil == src {
+				logger.Debugf("define: no source could be provided (%s); using the embedded dictionary", err)
+
+				src, err = fallbackSrc, nil
+			}
+		}
 	}
 
-	// Make sure our flags are parsed before entering main
-	handleError(err, flags.Parse(os.Args[1:]))
+	if nil != src {
+		logger.Debugf("define: selected source %q", src.Name())
+	}
+
+	return err
+}
+
+// initResultCache initializes the on-disk cache of source results, if caching
+// is enabled
+func initResultCache() {
+	resultCache = nil
+
+	if expiry := conf.CacheExpiry(); 0 < expiry {
+		resultCache = &cache.Disk{Dir: conf.CacheDir, TTL: expiry, MaxSize: int64(conf.CacheMaxSizeMB) << 20}
+	}
+}
+
+// reloadConfig reloads the configuration from the same command line arguments,
+// and selects the configured source again. The previous configuration is kept
+// if the reloaded one has problems.
+func reloadConfig() error {
+	if conf.ConfigStdin() {
+		return errors.New("the config file was read from stdin, so it can't be reloaded")
+	}
+
+	reloadFlags, _, _ := newFlagSet(act.Subcommand())
+	addProviderFlags(reloadFlags)
+
+	reloaded, err := loadConfig(reloadFlags)
+
+	if nil != err {
+		return err
+	}
+
+	previousConf, previousSrc, previousFallbackSrc, previousThesaurusSrc := conf, src, fallbackSrc, thesaurusSrc
+	conf = reloaded
+
+	// Only the newly added external command sources need to be registered
+	var execSources []config.ExecSource
+
+	for _, execSource := range conf.ExecSources {
+		if !hasExecSource(previousConf.ExecSources, execSource.Name) {
+			execSources = append(execSources, execSource)
+		}
+	}
+
+	err = registerExecSources(execSources)
+
+	if nil == err {
+		err = problemsError(append(configProblems(false), resolveSourceOptions()...))
+	}
+
+	if nil == err {
+		err = selectSources()
+	}
+
+	if nil != err {
+		conf, src, fallbackSrc, thesaurusSrc = previousConf, previousSrc, previousFallbackSrc, previousThesaurusSrc
+
+		return err
+	}
+
+	initWriters()
+	initResultCache()
+
+	return nil
+}
+
+// hasExecSource returns whether the given external command sources include
+// one of the given name
+func hasExecSource(execSources []config.ExecSource, name string) bool {
+	for _, execSource := range execSources {
+		if name == execSource.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// problemsError returns an error of the given problems of the configuration
+// that aren't warnings, if any
+func problemsError(problems []config.Problem) error {
+	var errs []string
+
+	for _, problem := range problems {
+		if !problem.Warning {
+			errs = append(errs, problem.String())
+		}
+	}
+
+	if len(errs) < 1 {
+		return nil
+	}
+
+	return fmt.Errorf("found %d problem(s) in the configuration: %s", len(errs), strings.Join(errs, "; "))
 }
 
 func handleError(err ...error) {
 	for _, e := range err {
 		if nil != e {
-			msg := e.Error()
+			printError(e)
 
-			if len(msg) > 1 {
-				// Format the message
-				msg = strings.ToTitle(msg[:1]) + msg[1:]
-
-				stdErrWriter.IndentWrites(func(writer *defineio.PanicWriter) {
-					writer.WritePaddedStringLine(msg, 1)
-				})
+			switch e.(type) {
+			case *overallTimeoutError:
+				printAttempts()
+				quit(networkFailureExitCode)
+			case *cancelledError:
+				quit(interruptedExitCode)
 			}
 
 			quit(1)
@@ -113,7 +587,46 @@ func handleError(err ...error) {
 	}
 }
 
+// hintLegacyConfigFile prints a one-time hint suggesting that the loaded config
+// file be moved from its legacy location to the given preferred location
+func hintLegacyConfigFile(preferredLocation string) {
+	markerFile := filepath.Join(xdg.DataDir(), legacyConfigHintFileName)
+
+	if _, err := os.Stat(markerFile); !os.IsNotExist(err) {
+		return
+	}
+
+	// Only hint if we can remember that we have, to avoid repeating ourselves
+	if err := os.MkdirAll(filepath.Dir(markerFile), 0755); nil != err {
+		return
+	}
+
+	if err := ioutil.WriteFile(markerFile, nil, 0644); nil != err {
+		return
+	}
+
+	printError(fmt.Errorf(
+		"hint: the config file %q is at a legacy location; consider moving it to %q",
+		conf.FileLocation(),
+		preferredLocation,
+	))
+}
+
+func printError(err error) {
+	msg := err.Error()
+
+	if len(msg) > 1 {
+		// Format the message
+		msg = strings.ToTitle(msg[:1]) + msg[1:]
+
+		stdErrWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+			writer.WritePaddedStringLine(msg, 1)
+		})
+	}
+}
+
 func quit(code int) {
+	cancelRun()
 	os.Exit(code)
 }
 
@@ -125,74 +638,2612 @@ func printConfig() {
 	stdOutWriter.WriteStringLine(string(encoded))
 }
 
-func printSources() {
-	var sourceStrings []string
+// writeConfigFiles writes the locations of the loaded config files, in the
+// order that they were merged
+func writeConfigFiles(writer *defineio.PanicWriter) {
+	configFiles := conf.ConfigFiles()
 
-	for conf, source := range registry.Providers() {
-		sourceStrings = append(sourceStrings, fmt.Sprintf("%q (%s)", source.Name(), conf.JSONKey()))
-	}
+	// Note the config file given by the environment, which is easy to forget
+	describe := func(location string) string {
+		if conf.ConfigFileFromEnv() && location == conf.FileLocation() {
+			return fmt.Sprintf("%q (from the DEFINE_APP_CONFIG_FILE environment variable)", location)
+		}
 
-	sort.Strings(sourceStrings)
+		return fmt.Sprintf("%q", location)
+	}
 
-	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
-		writer.WritePaddedStringLine("Available sources:", 1)
+	switch len(configFiles) {
+	case 0:
+		writer.WritePaddedStringLine("No config file loaded", 1)
+	case 1:
+		writer.WritePaddedStringLine("Config file: "+describe(configFiles[0]), 1)
+	default:
+		writer.WriteNewLine()
+		writer.WriteStringLine("Config files, in the order they were merged (each overriding the previous):")
 
-		for i, source := range sourceStrings {
-			writer.WriteStringLine(fmt.Sprintf("%d. %s", i+1, source))
-		}
+		writer.IndentWrites(func(writer *defineio.PanicWriter) {
+			for i, location := range configFiles {
+				writer.WriteStringLine(fmt.Sprintf("%d. %s", i+1, describe(location)))
+			}
+		})
 
 		writer.WriteNewLine()
-	})
+	}
 }
 
-func printVersion() {
-	stdOutWriter.WriteStringLine(version.Printable())
+// configProblems returns the problems of the loaded config files and of the
+// configuration's values, where the comments and trailing commas of JSON
+// config files are only problems if validating strictly
+func configProblems(strict bool) []config.Problem {
+	var problems []config.Problem
+
+	configFiles := conf.ConfigFiles()
+	validateFile := conf.ValidateFile
+
+	if strict {
+		validateFile = conf.ValidateFileStrict
+	}
+
+	for _, location := range configFiles {
+		fileProblems, err := validateFile(location)
+
+		if nil != err {
+			fileProblems = []config.Problem{{Message: err.Error()}}
+		}
+
+		for _, problem := range fileProblems {
+			// Only name the file of each problem when there are several
+			if 1 < len(configFiles) {
+				problem.File = location
+			}
+
+			problems = append(problems, problem)
+		}
+	}
+
+	return append(problems, conf.Validate()...)
 }
 
-func printUsage(writer *defineio.PanicWriter) {
-	writer.IndentWrites(func(w *defineio.PanicWriter) {
-		flags.SetOutput(w)
+// checkConfig reports the given problems of the configuration together,
+// quitting if any of them aren't warnings, or else printing the warnings
+func checkConfig(problems []config.Problem) {
+	var errs []string
 
-		w.WritePaddedStringLine(fmt.Sprintf("Usage: %s [<options>...] <word>", version.AppName), 1)
+	for _, problem := range problems {
+		if !problem.Warning {
+			errs = append(errs, problem.String())
+		}
+	}
 
-		w.WriteStringLine("Options:")
-		flags.PrintDefaults()
-		w.WriteNewLine()
+	if len(errs) < 1 {
+		for _, problem := range problems {
+			printError(errors.New(problem.String()))
+		}
+
+		return
+	}
+
+	stdErrWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine(fmt.Sprintf("Found %d problem(s) in the configuration:", len(problems)), 1)
+
+		writer.IndentWrites(func(writer *defineio.PanicWriter) {
+			for _, problem := range problems {
+				writer.WriteStringLine("- " + problem.String())
+			}
+		})
+
+		writer.WriteNewLine()
 	})
+
+	quit(1)
 }
 
-func defineWord(word string) {
-	result, err := src.Define(word)
+func validateConfig() {
+	var problems []string
 
-	handleError(err, source.ValidateResult(result))
+	for _, problem := range configProblems(act.Strict()) {
+		problems = append(problems, problem.String())
+	}
 
-	resultPrinter := printer.NewResultPrinter(stdOutWriter)
+	// Make sure that the explicitly selected source can be provided with its
+	// required configuration values (a preferred source may fall back)
+	for _, providerConf := range conf.ProviderConfigs() {
+		if conf.Source == providerConf.JSONKey() {
+			if _, err := registry.Provide(providerConf); nil != err {
+				problems = append(problems, err.Error())
+			}
+		}
+	}
 
-	resultPrinter.PrintResult(result)
-	resultPrinter.PrintSourceName(src)
-}
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writeConfigFiles(writer)
 
-func main() {
-	// Get the word from our first non-flag argument
-	word := flags.Arg(0)
+		if len(problems) < 1 {
+			writer.WriteStringLine("The configuration is valid")
+			writer.WriteNewLine()
 
-	// Decide what to perform
-	switch act.Type() {
-	case action.PrintConfig:
-		printConfig()
-	case action.ListSources:
-		printSources()
-	case action.PrintVersion:
-		printVersion()
-	case action.DefineWord:
+			return
+		}
+
+		writer.WriteStringLine(fmt.Sprintf("Found %d problem(s):", len(problems)))
+
+		writer.IndentWrites(func(writer *defineio.PanicWriter) {
+			for _, problem := range problems {
+				writer.WriteStringLine("- " + problem)
+			}
+		})
+
+		writer.WriteNewLine()
+	})
+
+	if len(problems) > 0 {
+		quit(1)
+	}
+}
+
+func initConfig() {
+	handleError(conf.WriteExample(act.Force()))
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine(fmt.Sprintf("Wrote an example config file to %q", conf.TargetFileLocation()), 1)
+	})
+}
+
+func migrateConfig() {
+	renamed, err := conf.MigrateFile()
+
+	handleError(err)
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		if len(renamed) < 1 {
+			writer.WritePaddedStringLine(fmt.Sprintf("The config file %q has no deprecated keys", conf.TargetFileLocation()), 1)
+			return
+		}
+
+		keys := make([]string, 0, len(renamed))
+
+		for key := range renamed {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		writer.WritePaddedStringLine(fmt.Sprintf("Renamed the deprecated keys of the config file %q:", conf.TargetFileLocation()), 1)
+
+		writer.IndentWrites(func(writer *defineio.PanicWriter) {
+			for _, key := range keys {
+				writer.WriteStringLine(fmt.Sprintf("- %q to %q", key, renamed[key]))
+			}
+		})
+
+		writer.WriteNewLine()
+	})
+}
+
+func setConfigValue(assignment string) {
+	parts := strings.SplitN(assignment, "=", 2)
+
+	if len(parts) < 2 {
+		handleError(fmt.Errorf("invalid assignment %q; must be in the form of \"key=value\"", assignment))
+	}
+
+	handleError(conf.SetFileValue(parts[0], parts[1]))
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine(fmt.Sprintf("Set %q in the config file %q", parts[0], conf.TargetFileLocation()), 1)
+	})
+}
+
+func printConfigValue(keyPath string) {
+	value, err := conf.Value(keyPath)
+
+	handleError(err)
+
+	if str, ok := value.(string); ok {
+		if config.IsSecretKey(keyPath) && "" != str && keyring.Placeholder != str && config.CredentialsPlaceholder != str && !act.ShowSecrets() {
+			str = redactedPlaceholder
+		}
+
+		stdOutWriter.WriteStringLine(str)
+		return
+	}
+
+	encoded, err := json.Marshal(value)
+
+	handleError(err)
+
+	stdOutWriter.WriteStringLine(string(encoded))
+}
+
+func printSources() {
+	type sourceInfo struct {
+		name string
+		conf registry.Configuration
+	}
+
+	confsByName := make(map[string][]registry.Configuration)
+
+	providers := registry.Providers()
+
+	for _, conf := range registry.ProviderConfigurations() {
+		confsByName[providers[conf].Name()] = append(confsByName[providers[conf].Name()], conf)
+	}
+
+	// Group the sources by whether they require keys, in order of their names
+	var keyless, keyed []sourceInfo
+
+	for _, name := range registry.ProviderNames() {
+		confs := confsByName[name]
+		delete(confsByName, name)
+
+		sort.Slice(confs, func(i, j int) bool {
+			return confs[i].JSONKey() < confs[j].JSONKey()
+		})
+
+		for _, conf := range confs {
+			if 0 < len(registry.ProviderMetadata(conf).RequiredKeys) {
+				keyed = append(keyed, sourceInfo{name, conf})
+			} else {
+				keyless = append(keyless, sourceInfo{name, conf})
+			}
+		}
+	}
+
+	header := []string{"", "Source", "Key", "Configured", "Requires", "Capabilities"}
+	number := 0
+
+	// The descriptions are listed beneath the tables, by the sources' numbers
+	var details [][]string
+
+	sourceRows := func(sources []sourceInfo) [][]string {
+		rows := [][]string{header}
+
+		for _, info := range sources {
+			metadata := registry.ProviderMetadata(info.conf)
+			configured := "yes"
+			requires := "-"
+
+			number++
+			numberLabel := fmt.Sprintf("%d.", number)
+
+			if conf.PreferredSource == info.conf.JSONKey() {
+				numberLabel = "*" + numberLabel
+			}
+
+			if !registry.HasRequiredKeys(info.conf) {
+				configured = "no"
+			}
+
+			if 0 < len(metadata.RequiredKeys) {
+				var flagNames []string
+
+				for _, requiredKey := range metadata.RequiredKeys {
+					flagNames = append(flagNames, "--"+requiredKey.FlagName)
+				}
+
+				requires = strings.Join(flagNames, ", ")
+			}
+
+			rows = append(rows, []string{
+				numberLabel,
+				fmt.Sprintf("%q", info.name),
+				info.conf.JSONKey(),
+				configured,
+				requires,
+				strings.Join(metadata.Capabilities, ", "),
+			})
+
+			if detail := sourceDetail(metadata, "no" == configured); 0 < len(detail) {
+				details = append(details, append([]string{fmt.Sprintf("%d. %q", number, info.name)}, detail...))
+			}
+		}
+
+		return rows
+	}
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		if 0 < len(keyless) {
+			writer.WritePaddedStringLine("Available sources (no key required):", 1)
+
+			writer.WriteColumns(sourceRows(keyless))
+		}
+
+		if 0 < len(keyed) {
+			writer.WritePaddedStringLine("Available sources (key required):", 1)
+
+			writer.WriteColumns(sourceRows(keyed))
+		}
+
+		writer.WritePaddedStringLine("* The preferred source", 1)
+
+		for _, detail := range details {
+			writer.WriteStringLine(detail[0])
+
+			writer.IndentWrites(func(writer *defineio.PanicWriter) {
+				for _, line := range detail[1:] {
+					writer.WriteStringLine(line)
+				}
+			})
+
+			writer.WriteNewLine()
+		}
+	})
+}
+
+// sourceDetail returns the lines describing a source by its metadata: its
+// description and homepage, and where to obtain its required keys, if they
+// aren't configured yet
+func sourceDetail(metadata registry.Metadata, unconfigured bool) []string {
+	var lines []string
+
+	if "" != metadata.Description {
+		lines = append(lines, metadata.Description)
+	}
+
+	if "" != metadata.Homepage {
+		lines = append(lines, metadata.Homepage)
+	}
+
+	if unconfigured && "" != metadata.SignupURL && 0 < len(metadata.RequiredKeys) {
+		keys := make([]string, len(metadata.RequiredKeys))
+
+		for i, requiredKey := range metadata.RequiredKeys {
+			keys[i] = requiredKey.Name
+		}
+
+		lines = append(lines, fmt.Sprintf("Requires %s, from %s", strings.Join(keys, " and "), metadata.SignupURL))
+	}
+
+	return lines
+}
+
+func printEnvVars() {
+	rows := [][]string{{"Variable", "Key", "Value", "Status"}}
+
+	for _, info := range conf.EnvVars() {
+		value := info.Value
+
+		if config.EnvVarUnset == info.Status {
+			value = "-"
+		} else if config.IsSecretKey(info.KeyPath) && "" != value && !act.ShowSecrets() {
+			value = redactedPlaceholder
+		}
+
+		name := info.Name
+
+		if "" != info.Alias {
+			name = fmt.Sprintf("%s (as %s)", info.Name, info.Alias)
+		}
+
+		rows = append(rows, []string{name, info.KeyPath, value, string(info.Status)})
+	}
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine("Environment variables:", 1)
+
+		writer.WriteColumns(rows)
+
+		writer.WritePaddedStringLine("Values that are \"overridden\" are set by a flag or the config file instead", 1)
+	})
+}
+
+func printVersion() {
+	stdOutWriter.WriteStringLine(version.Printable())
+}
+
+func printVersionJSON() {
+	encoded, err := json.MarshalIndent(version.GetInfo(), "", "    ")
+
+	handleError(err)
+
+	stdOutWriter.WriteStringLine(string(encoded))
+}
+
+func checkUpdate() {
+	printUpdateStatus(latestRelease())
+}
+
+// printUpdateStatus prints whether the given latest release is newer than the
+// running version
+func printUpdateStatus(release *update.Release, isNewer bool) {
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		if isNewer {
+			writer.WritePaddedStringLine(fmt.Sprintf("A newer version is available: %s (current: %s)", release.TagName, version.Name()), 1)
+		} else {
+			writer.WritePaddedStringLine(fmt.Sprintf("Already up to date (%s)", version.Name()), 1)
+		}
+	})
+}
+
+func selfUpdate() {
+	release, isNewer := latestRelease()
+
+	if !isNewer {
+		printUpdateStatus(release, isNewer)
+		return
+	}
+
+	handleError(release.Install(http.DefaultClient))
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine(fmt.Sprintf("Updated to %s", release.TagName), 1)
+	})
+}
+
+func latestRelease() (*update.Release, bool) {
+	release, err := update.Latest(http.DefaultClient)
+
+	handleError(err)
+
+	isNewer, err := release.IsNewer()
+
+	handleError(err)
+
+	return release, isNewer
+}
+
+// promptSecret interactively prompts for the value of the named key
+func promptSecret(name string) string {
+	stdErrWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.Printf("Enter the value for %q: ", name)
+	})
+
+	value, err := bufio.NewReader(os.Stdin).ReadString('\n')
+
+	handleError(err)
+
+	if value = strings.TrimSpace(value); "" == value {
+		handleError(fmt.Errorf("no value entered for key %q", name))
+	}
+
+	return value
+}
+
+func setKey(name string) {
+	if nil == flags.Lookup(name) {
+		handleError(fmt.Errorf("unknown key %q; keys are named after their command line flags", name))
+	}
+
+	handleError(keyring.Set(name, promptSecret(name)))
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine(fmt.Sprintf("Stored %q in the system keyring", name), 1)
+	})
+}
+
+func setSecret(keyPath string) {
+	// Make sure that the key exists, and can hold a reference, before storing
+	// its secret
+	value, err := conf.Value(keyPath)
+
+	handleError(err)
+
+	if _, ok := value.(string); !ok {
+		handleError(fmt.Errorf("key %q isn't a string, so it can't reference a secret in the keyring", keyPath))
+	}
+
+	handleError(keyring.Set(keyPath, promptSecret(keyPath)))
+	handleError(conf.SetFileValue(keyPath, keyring.Reference(keyPath)))
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine(fmt.Sprintf(
+			"Stored %q in the system keyring, referenced from the config file %q",
+			keyPath,
+			conf.TargetFileLocation(),
+		), 1)
+	})
+}
+
+func printHistory(limitArg string) {
+	limit := defaultHistoryLimit
+
+	if "" != limitArg {
+		var err error
+
+		if limit, err = strconv.Atoi(limitArg); nil != err || limit < 1 {
+			handleError(fmt.Errorf("invalid history limit %q", limitArg))
+		}
+	}
+
+	records, err := history.Read(conf.HistoryFile, limit)
+
+	handleError(err)
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WriteNewLine()
+
+		for _, record := range records {
+			writer.WriteStringLine(fmt.Sprintf(
+				"%s  %s  (%s)",
+				record.Time.Local().Format("2006-01-02 15:04"),
+				record.Word,
+				record.Source,
+			))
+		}
+
+		writer.WriteNewLine()
+	})
+}
+
+func clearHistory() {
+	handleError(history.Clear(conf.HistoryFile))
+}
+
+func printCacheInfo() {
+	disk := cache.Disk{Dir: conf.CacheDir, TTL: conf.CacheExpiry()}
+
+	stats, err := disk.Stats()
+
+	handleError(err)
+
+	ttl := "disabled"
+
+	if 0 < disk.TTL {
+		ttl = disk.TTL.String()
+	}
+
+	maxSize := "no limit"
+
+	if 0 < conf.CacheMaxSizeMB {
+		maxSize = fmt.Sprintf("%d MB", conf.CacheMaxSizeMB)
+	}
+
+	rows := [][]string{
+		{"Location:", conf.CacheDir},
+		{"TTL:", ttl},
+		{"Max size:", maxSize},
+		{"Results:", fmt.Sprintf("%d (%d expired)", stats.Count, stats.Expired)},
+		{"Size:", fmt.Sprintf("%.1f MB", float64(stats.Size)/(1<<20))},
+	}
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine("Cache:", 1)
+
+		writer.WriteColumns(rows)
+	})
+}
+
+func clearCache() {
+	removed, err := (cache.Disk{Dir: conf.CacheDir}).Clear()
+
+	handleError(err)
+
+	stdOutWriter.WriteStringLine(fmt.Sprintf("Removed %d cached results", removed))
+}
+
+func recordHistory(result source.Result, src source.Source) {
+	record := history.Record{Word: result.Headword(), Source: src.Name(), Time: time.Now()}
+
+	if err := history.Append(conf.HistoryFile, record); nil != err {
+		printError(fmt.Errorf("failed to record lookup history with error: %s", err))
+	}
+}
+
+func starWord(word string) {
+	list, err := starred.Load(conf.StarredFile)
+
+	handleError(err)
+
+	starredWord := starred.Word{Word: word, Starred: time.Now()}
+
+	result, err := lookup(src, word)
+
+	if nil == err {
+		err = source.ValidateResult(result)
+	}
+
+	if nil == err {
+		starredWord.Word = result.Headword()
+		starredWord.Definition = firstDefinition(result)
+		starredWord.Source = src.Name()
+	} else {
+		starredWord.Note = fmt.Sprintf("Failed to define with error: %s", err)
+	}
+
+	list.Star(starredWord)
+
+	handleError(list.Save())
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine(fmt.Sprintf("Starred %q", starredWord.Word), 1)
+	})
+}
+
+func unstarWord(word string) {
+	list, err := starred.Load(conf.StarredFile)
+
+	handleError(err)
+
+	if !list.Unstar(word) {
+		handleError(fmt.Errorf("word %q isn't starred", word))
+	}
+
+	handleError(list.Save())
+}
+
+func printStarred() {
+	list, err := starred.Load(conf.StarredFile)
+
+	handleError(err)
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine("Starred words:", 1)
+
+		for _, starredWord := range list.Words {
+			writer.WriteStringLine(starredWord.Word)
+
+			writer.IndentWrites(func(writer *defineio.PanicWriter) {
+				if "" != starredWord.Definition {
+					writer.WriteStringLine(starredWord.Definition)
+				}
+
+				if "" != starredWord.Note {
+					writer.WriteStringLine(fmt.Sprintf("[%s]", starredWord.Note))
+				}
+			})
+		}
+
+		writer.WriteNewLine()
+	})
+}
+
+// firstDefinition returns the first definition found in a result
+func firstDefinition(result source.Result) string {
+	for _, entry := range result.Entries() {
+		for _, sense := range entry.Senses() {
+			for _, definition := range sense.Definitions() {
+				return definition
+			}
+		}
+	}
+
+	return ""
+}
+
+func matchRegex(pattern string) {
+	matcher, ok := src.(source.Matcher)
+
+	if !ok {
+		handleError(fmt.Errorf("source %q doesn't support regular expression matching (only local dictionary sources do)", src.Name()))
+	}
+
+	results, err := matcher.Match(pattern)
+
+	handleError(err)
+
+	if len(results) < 1 {
+		handleError(fmt.Errorf("no words match the regular expression %q", pattern))
+	}
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine(fmt.Sprintf("Words matching %q:", pattern), 1)
+
+		for _, result := range results {
+			writer.WriteStringLine(result.Headword())
+
+			if definition := firstDefinition(result); "" != definition {
+				writer.IndentWrites(func(writer *defineio.PanicWriter) {
+					writer.WriteStringLine(definition)
+				})
+			}
+		}
+
+		writer.WriteNewLine()
+	})
+}
+
+// wordFrequency defines how frequently a word is used, according to a source
+type wordFrequency struct {
+	word       string
+	perMillion float64
+	src        source.FrequencySource
+}
+
+// frequencySources returns the sources that know how frequently words are
+// used, in their order of priority, followed by the embedded frequency list
+// (as a fallback for offline use)
+func frequencySources() []source.FrequencySource {
+	var sources []source.FrequencySource
+
+	for _, info := range prioritizedSources() {
+		if providedSource, err := registry.Provide(info.conf); nil == err {
+			if frequencySource, ok := providedSource.(source.FrequencySource); ok {
+				sources = append(sources, frequencySource)
+			}
+		}
+	}
+
+	if !conf.NoEmbedded && embedded.Available() {
+		if frequencySource, ok := embedded.New().(source.FrequencySource); ok {
+			sources = append(sources, frequencySource)
+		}
+	}
+
+	return sources
+}
+
+// lookupFrequency returns how frequently the given word is used, according to
+// the first of the given sources that knows
+func lookupFrequency(sources []source.FrequencySource, word string) (wordFrequency, error) {
+	var err error
+
+	for _, frequencySource := range sources {
+		var perMillion float64
+
+		if perMillion, err = frequencySource.Frequency(word); nil == err {
+			return wordFrequency{word, perMillion, frequencySource}, nil
+		}
+
+		logger.Debugf("define: source %q failed to find the frequency of %q: %s", frequencySource.Name(), word, err)
+	}
+
+	if _, ok := err.(*source.EmptyResultError); ok || nil == err {
+		err = fmt.Errorf("the frequency of %q is unknown", word)
+	}
+
+	return wordFrequency{}, err
+}
+
+// printFrequencies prints how frequently each of the given words are used,
+// ranked from the most to the least frequent if there are several
+func printFrequencies(words []string) {
+	sources := frequencySources()
+
+	if len(sources) < 1 {
+		handleError(fmt.Errorf("no configured source knows how frequently words are used; add the %q source (such as with --source=%s)", datamuse.Name, datamuse.JSONKey))
+	}
+
+	if 1 == len(words) {
+		frequency, err := lookupFrequency(sources, words[0])
+
+		handleError(err)
+
+		stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+			writer.WritePaddedStringLine(fmt.Sprintf("%s: %.2f per million words (%s)", frequency.word, frequency.perMillion, source.BandOf(frequency.perMillion)), 1)
+			writer.WriteStringLine(fmt.Sprintf("Frequency provided by: %q", frequency.src.Name()))
+			writer.WriteNewLine()
+		})
+
+		return
+	}
+
+	var frequencies []wordFrequency
+
+	for _, word := range words {
+		frequency, err := lookupFrequency(sources, word)
+
+		if nil != err {
+			printError(err)
+			continue
+		}
+
+		frequencies = append(frequencies, frequency)
+	}
+
+	sort.SliceStable(frequencies, func(i, j int) bool {
+		return frequencies[i].perMillion > frequencies[j].perMillion
+	})
+
+	rows := [][]string{{"Rank", "Word", "Per million", "Band", "Source"}}
+
+	for i, frequency := range frequencies {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d.", i+1),
+			frequency.word,
+			fmt.Sprintf("%.2f", frequency.perMillion),
+			string(source.BandOf(frequency.perMillion)),
+			frequency.src.Name(),
+		})
+	}
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine("Word frequencies:", 1)
+
+		writer.WriteColumns(rows)
+		writer.WriteNewLine()
+	})
+
+	if len(frequencies) < len(words) {
+		quit(1)
+	}
+}
+
+// printPronunciations prints the phonetic transcriptions of each of the given
+// words, one per line with their region labels (and prefixed by their word, if
+// there are several), and exits with the notFoundExitCode if any have none
+func printPronunciations(words []string) {
+	var sources []source.Source
+
+	for _, info := range prioritizedSources() {
+		if providedSource, err := registry.Provide(info.conf); nil == err {
+			sources = append(sources, providedSource)
+		}
+	}
+
+	if len(sources) < 1 {
+		handleError(fmt.Errorf("no sources are available"))
+	}
+
+	notFound := false
+
+	for _, word := range words {
+		pronunciations, err := lookupPronunciations(sources, word)
+
+		handleError(err)
+
+		if len(pronunciations) < 1 {
+			printError(fmt.Errorf("no source has a pronunciation of %q", word))
+			notFound = true
+			continue
+		}
+
+		for _, pronunciation := range pronunciations {
+			fields := []string{pronunciation.Transcription}
+
+			if "" != pronunciation.Region {
+				fields = append(fields, pronunciation.Region)
+			}
+
+			if 1 < len(words) {
+				fields = append([]string{word}, fields...)
+			}
+
+			stdOutWriter.WriteStringLine(strings.Join(fields, "\t"))
+		}
+	}
+
+	if notFound {
+		quit(notFoundExitCode)
+	}
+}
+
+// lookupPronunciations returns the pronunciations of the given word from the
+// first of the given sources, in turn, that has any. An error is returned if
+// none of the sources were able to define the word, for reasons other than not
+// finding it.
+func lookupPronunciations(sources []source.Source, word string) ([]source.RegionalPronunciation, error) {
+	var lastErr error
+
+	determined := false
+
+	for _, src := range sources {
+		result, err := lookup(src, word)
+
+		if nil == err {
+			err = source.ValidateResult(result)
+		}
+
+		if _, ok := err.(*source.EmptyResultError); ok {
+			determined = true
+			continue
+		}
+
+		if isRunEnded(err) {
+			return nil, err
+		}
+
+		if nil != err {
+			lastErr = fmt.Errorf("source %q: %s", src.Name(), err)
+			continue
+		}
+
+		determined = true
+
+		if pronunciations := source.ResultPronunciations(result); 0 < len(pronunciations) {
+			return pronunciations, nil
+		}
+
+		logger.Debugf("define: source %q has no pronunciation of %q", src.Name(), word)
+	}
+
+	if !determined {
+		return nil, lastErr
+	}
+
+	return nil, nil
+}
+
+// rhymeColumns is the number of columns that rhymes are printed in
+const rhymeColumns = 4
+
+func findRhymes(word string) {
+	var rhymer source.Rhymer
+
+	for _, info := range prioritizedSources() {
+		if providedSource, err := registry.Provide(info.conf); nil == err {
+			if sourceRhymer, ok := providedSource.(source.Rhymer); ok {
+				rhymer = sourceRhymer
+				break
+			}
+		}
+	}
+
+	if nil == rhymer {
+		handleError(fmt.Errorf("no configured source can find rhymes; add the %q source (such as with --source=%s)", datamuse.Name, datamuse.JSONKey))
+	}
+
+	rhymes, err := source.RhymesContext(runCtx, rhymer, word, act.Near(), conf.Limit())
+
+	if nil != err && nil != interruptCtx.Err() {
+		err = &cancelledError{}
+	} else if context.DeadlineExceeded == err {
+		err = &overallTimeoutError{time.Duration(conf.Timeout)}
+	}
+
+	handleError(err)
+
+	if act.JSON() {
+		encoded, err := json.MarshalIndent(rhymes, "", "    ")
+
+		handleError(err)
+
+		stdOutWriter.WriteStringLine(string(encoded))
+		return
+	}
+
+	if len(rhymes) < 1 {
+		handleError(fmt.Errorf("no words rhyme with %q", word))
+	}
+
+	var rows [][]string
+
+	for i, rhyme := range rhymes {
+		if 0 == i%rhymeColumns {
+			rows = append(rows, nil)
+		}
+
+		rows[len(rows)-1] = append(rows[len(rows)-1], rhyme.Word)
+	}
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		if act.Near() {
+			writer.WritePaddedStringLine(fmt.Sprintf("Words that nearly rhyme with %q:", word), 1)
+		} else {
+			writer.WritePaddedStringLine(fmt.Sprintf("Words that rhyme with %q:", word), 1)
+		}
+
+		writer.WriteColumns(rows)
+		writer.WriteNewLine()
+	})
+}
+
+func exportAnki(path string) {
+	var cards []anki.Card
+
+	words, err := readWords()
+
+	handleError(err)
+
+	if len(words) < 1 {
+		handleError(fmt.Errorf("no words given to export"))
+	}
+
+	for _, word := range words {
+		result, err := lookup(src, word)
+
+		if nil == err {
+			err = source.ValidateResult(result)
+		}
+
+		if nil != err {
+			printError(err)
+			continue
+		}
+
+		cards = append(cards, anki.NewCard(result, *ankiOpts))
+	}
+
+	handleError(anki.Write(path, cards, ankiOpts.Append))
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine(fmt.Sprintf("Exported %d of %d words to %q", len(cards), len(words), path), 1)
+	})
+}
+
+// readWords returns the words passed as arguments, followed by any words read
+// from the words file
+func readWords() ([]string, error) {
+	words := flags.Args()
+
+	warnFlagLikeWords(words)
+
+	if "" == conf.WordsFile() {
+		return words, nil
+	}
+
+	// The config file takes priority over any words on stdin
+	if "-" == conf.WordsFile() && conf.ConfigStdin() {
+		printError(errors.New("warning: not reading words from stdin, as the config file was read from it"))
+
+		return words, nil
+	}
+
+	file := os.Stdin
+
+	if "-" != conf.WordsFile() {
+		var err error
+
+		if file, err = os.Open(conf.WordsFile()); nil != err {
+			return nil, err
+		}
+
+		defer file.Close()
+	}
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		if word := strings.TrimSpace(scanner.Text()); "" != word {
+			words = append(words, word)
+		}
+	}
+
+	return words, scanner.Err()
+}
+
+// warnFlagLikeWords warns about any of the given words that match the name of
+// a flag, as they were passed after the "--" terminator and are therefore
+// being treated as words rather than flags
+func warnFlagLikeWords(words []string) {
+	for _, word := range words {
+		if !strings.HasPrefix(word, "-") || nil == lookupFlag(word) {
+			continue
+		}
+
+		printError(fmt.Errorf("warning: %q is being treated as a word, not a flag, as it follows the \"--\" terminator", word))
+	}
+}
+
+// lookupFlag returns the flag named by the given flag-like argument (such as
+// "--porcelain", "-q", or "--source=x"), or nil if there isn't one
+func lookupFlag(arg string) *flag.Flag {
+	name := strings.SplitN(strings.TrimLeft(arg, "-"), "=", 2)[0]
+
+	if strings.HasPrefix(arg, "--") {
+		return flags.Lookup(name)
+	}
+
+	var found *flag.Flag
+
+	flags.VisitAll(func(f *flag.Flag) {
+		if 1 == len(name) && name == f.Shorthand {
+			found = f
+		}
+	})
+
+	return found
+}
+
+func printUsage(writer *defineio.PanicWriter) {
+	writer.IndentWrites(func(w *defineio.PanicWriter) {
+		flags.SetOutput(w)
+
+		if subcommand := act.Subcommand(); nil != subcommand {
+			w.WritePaddedStringLine(fmt.Sprintf("Usage: %s %s %s", version.AppName, subcommand.Name, subcommand.Usage), 1)
+			w.WriteStringLine(subcommand.Description)
+			w.WriteNewLine()
+		} else {
+			w.WritePaddedStringLine(fmt.Sprintf("Usage: %s [<options>...] [--] <word>...", version.AppName), 1)
+			w.WriteStringLine(fmt.Sprintf("   or: %s <command> [<arguments>...]", version.AppName))
+			w.WriteNewLine()
+
+			w.WriteStringLine("Commands:")
+
+			var rows [][]string
+
+			for _, subcommand := range action.Subcommands {
+				rows = append(rows, []string{subcommand.Name, subcommand.Description})
+			}
+
+			w.IndentWrites(func(w *defineio.PanicWriter) {
+				w.WriteColumns(rows)
+			})
+
+			w.WriteNewLine()
+		}
+
+		w.WriteStringLine("Options:")
+		flags.PrintDefaults()
+		w.WriteNewLine()
+	})
+}
+
+func promptSuggestions(suggestions []string) string {
+	var options []string
+
+	for i, suggestion := range suggestions {
+		options = append(options, fmt.Sprintf("%d) %s", i+1, suggestion))
+	}
+
+	// Prompt on stderr, so that stdout stays clean when piped
+	stdErrWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.Printf("Did you mean: %s — select [1-%d/n]: ", strings.Join(options, " "), len(suggestions))
+	})
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+
+	if nil != err {
+		return ""
+	}
+
+	selected, err := strconv.Atoi(strings.TrimSpace(answer))
+
+	if nil != err || selected < 1 || selected > len(suggestions) {
+		return ""
+	}
+
+	return suggestions[selected-1]
+}
+
+func handleSuggestions(err *source.EmptyResultError) {
+	suggestions := err.Suggestions
+
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+
+	if !conf.NoPrompt && defineio.IsTerminal(os.Stdin) {
+		if selected := promptSuggestions(suggestions); "" != selected {
+			defineWord(selected)
+			return
+		}
+	}
+
+	printError(err)
+
+	stdErrWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine(fmt.Sprintf("Did you mean: %s?", strings.Join(suggestions, ", ")), 1)
+	})
+
+	quit(1)
+}
+
+// sourceInfo defines the name and configuration of a source's provider
+type sourceInfo struct {
+	name string
+	conf registry.Configuration
+}
+
+// resolveSourceKey resolves a loosely given source, such as "oxford", to the key
+// of the single source that matches it. The keys and names of the sources are
+// matched case-insensitively: exactly, and then by prefix, and then by
+// substring, with the first kind of match that finds any sources being used.
+func resolveSourceKey(given string, confs []registry.Configuration) (string, error) {
+	providers := registry.Providers()
+	lowerGiven := strings.ToLower(given)
+
+	matchers := []func(string) bool{
+		func(candidate string) bool { return lowerGiven == candidate },
+		func(candidate string) bool { return strings.HasPrefix(candidate, lowerGiven) },
+		func(candidate string) bool { return strings.Contains(candidate, lowerGiven) },
+	}
+
+	for _, matches := range matchers {
+		var keys []string
+
+		for _, providerConf := range confs {
+			key := providerConf.JSONKey()
+
+			if matches(strings.ToLower(key)) || matches(strings.ToLower(providers[providerConf].Name())) {
+				keys = append(keys, key)
+			}
+		}
+
+		switch len(keys) {
+		case 0:
+			continue
+		case 1:
+			return keys[0], nil
+		}
+
+		sort.Strings(keys)
+
+		return "", fmt.Errorf("source %q is ambiguous, matching: %s", given, strings.Join(keys, ", "))
+	}
+
+	return "", fmt.Errorf("no source matches %q (see --list-sources for the available sources)", given)
+}
+
+// isFallbackBefore returns whether a source should be fallen back to before
+// another, so that the fallbacks are in a stable order: the sources that don't
+// require keys first, and then the others, each in the order of their keys
+func isFallbackBefore(a registry.Configuration, b registry.Configuration) bool {
+	aKeyless := 0 == len(registry.ProviderMetadata(a).RequiredKeys)
+	bKeyless := 0 == len(registry.ProviderMetadata(b).RequiredKeys)
+
+	if aKeyless != bKeyless {
+		return aKeyless
+	}
+
+	return a.JSONKey() < b.JSONKey()
+}
+
+// prioritizedSources returns the sources' providers in the order they would be
+// attempted: the preferred source first, falling back to the others in the
+// order of isFallbackBefore. Only the explicitly selected source is returned,
+// if one is set.
+func prioritizedSources() []sourceInfo {
+	var sources []sourceInfo
+
+	providers := registry.Providers()
+
+	for _, providerConf := range registry.ProviderConfigurations() {
+		if "" == conf.Source || conf.Source == providerConf.JSONKey() {
+			sources = append(sources, sourceInfo{providers[providerConf].Name(), providerConf})
+		}
+	}
+
+	sort.Slice(sources, func(i, j int) bool {
+		iPreferred := conf.PreferredSource == sources[i].conf.JSONKey()
+		jPreferred := conf.PreferredSource == sources[j].conf.JSONKey()
+
+		if iPreferred != jPreferred {
+			return iPreferred
+		}
+
+		return isFallbackBefore(sources[i].conf, sources[j].conf)
+	})
+
+	return sources
+}
+
+// dryRun prints the sources that would be attempted, in order, and the
+// requests that the selected source would send to define the given words,
+// without sending them
+func dryRun(words []string) {
+	sources := prioritizedSources()
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writeConfigFiles(writer)
+
+		writer.WriteStringLine("Sources, in the order they would be attempted:")
+		writer.WriteNewLine()
+
+		writer.IndentWrites(func(writer *defineio.PanicWriter) {
+			for i, info := range sources {
+				line := fmt.Sprintf("%d. %q (%s)", i+1, info.name, info.conf.JSONKey())
+
+				if _, err := registry.Provide(info.conf); nil != err {
+					line += fmt.Sprintf(" - unavailable: %s", err)
+				} else if nil != src && src.Name() == info.name {
+					line = "*" + line
+				}
+
+				writer.WriteStringLine(line)
+			}
+		})
+
+		writer.WritePaddedStringLine("* The selected source", 1)
+
+		if nil == src {
+			writer.WriteStringLine("No source is available")
+			writer.WriteNewLine()
+
+			return
+		}
+
+		requestSource, ok := src.(source.RequestSource)
+
+		if !ok {
+			writer.WriteStringLine(fmt.Sprintf("Source %q doesn't send any requests", src.Name()))
+			writer.WriteNewLine()
+
+			return
+		}
+
+		for _, word := range words {
+			request, err := requestSource.Request(normalizeWord(word))
+
+			handleError(err)
+
+			writer.WriteStringLine(fmt.Sprintf("Request for %q:", word))
+
+			writer.IndentWrites(func(writer *defineio.PanicWriter) {
+				for _, line := range redactedRequestLines(request) {
+					writer.WriteStringLine(line)
+				}
+			})
+
+			writer.WriteNewLine()
+		}
+	})
+}
+
+// redactedRequestLines returns the printable lines of an HTTP request's method,
+// URL, and headers, with the values of any secrets redacted
+func redactedRequestLines(request *http.Request) []string {
+	requestURL := *request.URL
+	queryParams := requestURL.Query()
+
+	for name := range queryParams {
+		if config.IsSecretKey(name) {
+			queryParams.Set(name, redactedPlaceholder)
+		}
+	}
+
+	requestURL.RawQuery = strings.Replace(queryParams.Encode(), url.QueryEscape(redactedPlaceholder), redactedPlaceholder, -1)
+
+	lines := []string{request.Method + " " + requestURL.String()}
+
+	var headerNames []string
+
+	for name := range request.Header {
+		headerNames = append(headerNames, name)
+	}
+
+	sort.Strings(headerNames)
+
+	for _, name := range headerNames {
+		value := strings.Join(request.Header[name], ", ")
+
+		if config.IsSecretKey(name) {
+			value = redactedPlaceholder
+		}
+
+		lines = append(lines, fmt.Sprintf("%s: %s", name, value))
+	}
+
+	return lines
+}
+
+// nonWordError represents an error caused by looking up input that isn't a
+// word, such as a number or a symbol, which sources can't define
+type nonWordError struct {
+	word string
+}
+
+func (e *nonWordError) Error() string {
+	return fmt.Sprintf("no definitions for non-word input %q (use --allow-symbols to look it up anyway)", e.word)
+}
+
+// checkWord returns an error if the given word doesn't contain any letters
+// (such as a number or a symbol), so that doomed lookups are never sent,
+// unless symbols are allowed
+func checkWord(word string) error {
+	if conf.AllowSymbols() || "" == word {
+		return nil
+	}
+
+	for _, char := range word {
+		if unicode.IsLetter(char) {
+			return nil
+		}
+	}
+
+	return &nonWordError{word: word}
+}
+
+// overallTimeoutError represents an error caused by exceeding the overall
+// timeout of the run's lookups
+type overallTimeoutError struct {
+	timeout time.Duration
+}
+
+func (e *overallTimeoutError) Error() string {
+	return fmt.Sprintf("lookup timed out after %s (the overall timeout, which --timeout increases)", e.timeout)
+}
+
+// cancelledError represents an error caused by the run being interrupted
+// (such as by Ctrl-C) during a lookup
+type cancelledError struct{}
+
+func (e *cancelledError) Error() string {
+	return "lookup cancelled"
+}
+
+// isRunEnded returns whether an error ends the run's lookups entirely, as the
+// rest of them would only fail the same way
+func isRunEnded(err error) bool {
+	switch err.(type) {
+	case *overallTimeoutError, *cancelledError:
+		return true
+	default:
+		return false
+	}
+}
+
+// cancelOnInterrupt returns a context that's cancelled when the app is first
+// interrupted (such as by Ctrl-C), which also becomes the interruptCtx, aborting
+// the requests of the lookups under it. If the cancelled lookups aren't
+// reported within the interruptGracePeriod, the app
+// exits on its own, while a second interrupt exits it immediately.
+func cancelOnInterrupt() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	interruptCtx = ctx
+
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+
+	go func() {
+		<-interrupts
+
+		// Restore the default handling, for a second interrupt
+		signal.Stop(interrupts)
+		cancel()
+
+		time.Sleep(interruptGracePeriod)
+
+		printError(&cancelledError{})
+		quit(interruptedExitCode)
+	}()
+
+	return ctx
+}
+
+// lookupAttempt is a record of a source's lookup of a word, and how it ended
+type lookupAttempt struct {
+	src     source.Source
+	word    string
+	elapsed time.Duration
+	err     error
+}
+
+// lookup defines a word with the given source, bound by both the per-source
+// timeout and the overall timeout (whichever is reached first), using and
+// updating the result cache, if enabled
+func lookup(src source.Source, word string) (source.Result, error) {
+	if nil == resultCache {
+		return lookupContext(runCtx, src, word)
+	}
+
+	key := cacheKey(src, word)
+
+	if cached, ok := resultCache.Get(key); ok {
+		if result, err := source.UnmarshalResultJSON(cached); nil == err {
+			logger.Debugf("define: using the cached result of %q from %q", word, src.Name())
+
+			return result, nil
+		}
+	}
+
+	result, err := lookupContext(runCtx, src, word)
+
+	// Only valid results are cached, so that failures are retried
+	if nil == err && nil == source.ValidateResult(result) {
+		if encoded, encodeErr := source.MarshalResultJSON(result); nil == encodeErr {
+			if cacheErr := resultCache.Set(key, encoded); nil != cacheErr {
+				logger.Debugf("define: failed to cache the result of %q: %s", word, cacheErr)
+			}
+		}
+	}
+
+	return result, err
+}
+
+// cacheKey returns the key of the cached result of a lookup of a word (as it's
+// normalized) with the given source, distinguished by the options that the
+// source's results depend on (such as its language)
+func cacheKey(src source.Source, word string) string {
+	opts := cache.KeyOptions{Source: src.Name()}
+
+	if optionedSrc, ok := src.(source.OptionedSource); ok {
+		options := optionedSrc.Options()
+
+		opts.Language = options.Language
+		opts.Region = options.Region
+		opts.Mode = options.Mode
+	}
+
+	return cache.Key(normalizeWord(word), opts)
+}
+
+// lookupContext is like lookup, but is also cancelled when the given context
+// (derived from the run's context) is done
+func lookupContext(ctx context.Context, src source.Source, word string) (source.Result, error) {
+	word = normalizeWord(word)
+
+	if 0 < conf.PerSourceTimeout {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(conf.PerSourceTimeout))
+		defer cancel()
+	}
+
+	started := time.Now()
+
+	result, err := source.DefineContext(ctx, src, word)
+
+	if nil != err && nil != interruptCtx.Err() {
+		err = &cancelledError{}
+	} else if context.DeadlineExceeded == err {
+		if nil != runCtx.Err() {
+			err = &overallTimeoutError{time.Duration(conf.Timeout)}
+		} else {
+			err = fmt.Errorf("lookup with source %q timed out after %s (the per-source timeout, which --timeout-per-source increases)", src.Name(), time.Duration(conf.PerSourceTimeout))
+		}
+	}
+
+	attemptsMutex.Lock()
+	attempts = append(attempts, lookupAttempt{src, word, time.Since(started), err})
+	attemptsMutex.Unlock()
+
+	return result, err
+}
+
+// normalizeWord normalizes the capitalization of a word before it's looked up,
+// by the configured normalization
+func normalizeWord(word string) string {
+	// An invalid normalization is reported as a problem with the config
+	normalization, _ := source.ParseNormalization(conf.Normalization)
+
+	return normalization.Apply(word)
+}
+
+// printAttempts prints each of the source lookups of the run, and how far each
+// got before it ended
+func printAttempts() {
+	attemptsMutex.Lock()
+	defer attemptsMutex.Unlock()
+
+	if len(attempts) < 1 {
+		return
+	}
+
+	rows := [][]string{{"Source", "Word", "Elapsed", "Outcome"}}
+
+	for _, attempt := range attempts {
+		outcome := "defined"
+
+		switch attempt.err.(type) {
+		case nil:
+		case *overallTimeoutError:
+			outcome = "timed out"
+		case *cancelledError:
+			outcome = "cancelled"
+		case *source.EmptyResultError:
+			outcome = "not found"
+		default:
+			outcome = "failed: " + attempt.err.Error()
+		}
+
+		rows = append(rows, []string{
+			fmt.Sprintf("%q", attempt.src.Name()),
+			attempt.word,
+			attempt.elapsed.Round(time.Millisecond).String(),
+			outcome,
+		})
+	}
+
+	stdErrWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine("Attempted lookups:", 1)
+
+		writer.WriteColumns(rows)
+	})
+}
+
+func defineWord(word string) {
+	result, resultSrc, err := lookupWithFallback(word)
+
+	if emptyErr, ok := err.(*source.EmptyResultError); ok && 0 < len(emptyErr.Suggestions) {
+		handleSuggestions(emptyErr)
+		return
+	}
+
+	handleError(err)
+
+	printResult(result, resultSrc)
+	printTranslation(result)
+	printRelated(result)
+
+	if act.Open() {
+		handleError(openWebPage(resultSrc, word))
+	}
+}
+
+// openWebPage opens the web page for the given word in the default web
+// browser, as found by webPageURL
+func openWebPage(src source.Source, word string) error {
+	pageURL, err := webPageURL(src, word)
+
+	if nil != err {
+		return err
+	}
+
+	return browser.Open(pageURL)
+}
+
+// webPageURL returns the URL of the given source's web page for the given word,
+// falling back to the first of the other sources, in their order of priority,
+// that has one if the given source doesn't
+func webPageURL(src source.Source, word string) (string, error) {
+	if webSource, ok := src.(source.WebSource); ok && "" != webSource.WebURL(word) {
+		return webSource.WebURL(word), nil
+	}
+
+	for _, info := range prioritizedSources() {
+		providedSource, err := registry.Provide(info.conf)
+
+		if nil != err {
+			continue
+		}
+
+		if webSource, ok := providedSource.(source.WebSource); ok && "" != webSource.WebURL(word) {
+			logger.Debugf("define: source %q has no web page for %q; using %q", src.Name(), word, providedSource.Name())
+
+			return webSource.WebURL(word), nil
+		}
+	}
+
+	return "", fmt.Errorf("no source has a web page for %q", word)
+}
+
+// printWebPageURLs prints the URL of the web page for each of the given words,
+// as found by webPageURL, without defining them
+func printWebPageURLs(words []string) {
+	for _, word := range words {
+		pageURL, err := webPageURL(src, word)
+
+		handleError(err)
+
+		stdOutWriter.WriteStringLine(pageURL)
+	}
+}
+
+// lookupWithFallback looks up a word with the selected source, falling back to
+// the source of last resort if the selected source fails to define it, and
+// returns the valid result and the source that defined it
+func lookupWithFallback(word string) (source.Result, source.Source, error) {
+	if err := checkWord(word); nil != err {
+		return nil, src, err
+	}
+
+	if nil != abbreviationSrc && abbreviationSrc.Name() != src.Name() && source.IsAbbreviation(word) {
+		result, err := lookupVariants(abbreviationSrc, word)
+
+		if nil == err {
+			return result, abbreviationSrc, nil
+		}
+
+		logger.Debugf("define: source %q failed to expand %q (%s); using %q", abbreviationSrc.Name(), word, err, src.Name())
+	}
+
+	result, err := lookupVariants(src, word)
+
+	// Only retry when the word wasn't found, so that real errors aren't masked
+	if _, ok := err.(*source.EmptyResultError); ok && conf.RetryEmpty {
+		retryResult, retrySrc, retryErr := retryEmpty(word)
+
+		if nil != retryResult {
+			return retryResult, retrySrc, nil
+		}
+
+		if nil != retryErr {
+			return nil, src, retryErr
+		}
+	}
+
+	// Once the run has timed out or been cancelled, no fallback can finish
+	if nil == err || nil == fallbackSrc || src == fallbackSrc || isRunEnded(err) {
+		return result, src, err
+	}
+
+	fallbackResult, fallbackErr := lookup(fallbackSrc, word)
+
+	if nil == fallbackErr {
+		fallbackErr = source.ValidateResult(fallbackResult)
+	}
+
+	if nil != fallbackErr {
+		// Report the selected source's error, rather than the fallback's
+		return result, src, err
+	}
+
+	logger.Debugf("define: source %q failed to define %q (%s); using %q", src.Name(), word, err, fallbackSrc.Name())
+
+	return fallbackResult, fallbackSrc, nil
+}
+
+// lookupVariants looks up a word with the given source, like lookup, and then
+// each of its abbreviation variants (such as "etc" for "etc.") while it isn't
+// found, returning the first valid result
+func lookupVariants(lookupSrc source.Source, word string) (source.Result, error) {
+	result, err := lookup(lookupSrc, word)
+
+	if nil == err {
+		err = source.ValidateResult(result)
+	}
+
+	for _, variant := range source.AbbreviationVariants(normalizeWord(word)) {
+		if _, ok := err.(*source.EmptyResultError); !ok {
+			break
+		}
+
+		variantResult, variantErr := lookup(lookupSrc, variant)
+
+		if nil == variantErr {
+			variantErr = source.ValidateResult(variantResult)
+		}
+
+		if nil == variantErr {
+			logger.Debugf("define: source %q didn't find %q; defined %q instead", lookupSrc.Name(), word, variant)
+
+			return variantResult, nil
+		}
+	}
+
+	return result, err
+}
+
+// retryEmpty looks up a word that the selected source didn't find with each of
+// the other usable sources, in their order of priority, and returns the first
+// valid result and the source that defined it, or nil if none did. If the run
+// times out or is cancelled first, the retries stop, returning its error.
+func retryEmpty(word string) (source.Result, source.Source, error) {
+	confs := registry.ProviderConfigurations()
+
+	sort.Slice(confs, func(i, j int) bool {
+		return isFallbackBefore(confs[i], confs[j])
+	})
+
+	for _, providerConf := range confs {
+		retrySrc, err := registry.Provide(providerConf)
+
+		if nil != err || retrySrc.Name() == src.Name() {
+			continue
+		}
+
+		result, err := lookup(retrySrc, word)
+
+		if isRunEnded(err) {
+			return nil, nil, err
+		}
+
+		if nil == err {
+			err = source.ValidateResult(result)
+		}
+
+		if nil != err {
+			logger.Debugf("define: retrying %q with source %q failed: %s", word, retrySrc.Name(), err)
+			continue
+		}
+
+		printError(fmt.Errorf("note: source %q didn't find %q; defined by %q instead", src.Name(), word, retrySrc.Name()))
+
+		return result, retrySrc, nil
+	}
+
+	return nil, nil, nil
+}
+
+// defineWords defines each of the given words in turn, showing the progress
+// of the lookups. Words that fail to be defined are reported, without
+// stopping the rest.
+func defineWords(words []string) {
+	progress := defineio.NewProgress(os.Stderr, !conf.Quiet())
+	failed := 0
+
+	for i, word := range words {
+		progress.Update(i+1, len(words), word)
+
+		result, resultSrc, err := lookupWithFallback(word)
+
+		progress.Clear()
+
+		if isRunEnded(err) {
+			// The rest of the words would only fail the same way
+			handleError(err)
+		}
+
+		if nil != err {
+			printError(err)
+			failed++
+			continue
+		}
+
+		printResult(result, resultSrc)
+		printTranslation(result)
+		printRelated(result)
+
+		if act.Open() {
+			if err = openWebPage(resultSrc, word); nil != err {
+				printError(err)
+				failed++
+			}
+		}
+	}
+
+	if 0 < failed {
+		quit(1)
+	}
+}
+
+// defineWithAllSources defines a word with every available source at once,
+// printing each source's result as soon as it arrives (or, if ordered, in the
+// sources' order of priority once they've all finished). If only the first
+// match is wanted, the lookups stop as soon as a source defines the word (or,
+// if ordered, as soon as the highest priority source that will define it has),
+// cancelling the rest. It returns whether any of the sources defined the word.
+func defineWithAllSources(word string) bool {
+	var sources []source.Source
+
+	for _, info := range prioritizedSources() {
+		if providedSource, err := registry.Provide(info.conf); nil == err {
+			sources = append(sources, providedSource)
+		}
+	}
+
+	if len(sources) < 1 {
+		handleError(fmt.Errorf("no sources are available"))
+	}
+
+	if err := checkWord(word); nil != err {
+		printError(err)
+		return false
+	}
+
+	ctx, cancel := context.WithCancel(runCtx)
+	defer cancel()
+
+	results := make([]source.Result, len(sources))
+	errs := make([]error, len(sources))
+	finished := make([]bool, len(sources))
+	skipped := make([]bool, len(sources))
+
+	// Buffered, so the lookups can finish even if we're no longer receiving
+	done := make(chan int, len(sources))
+
+	for i, src := range sources {
+		go func(i int, src source.Source) {
+			results[i], errs[i] = lookupContext(ctx, src, word)
+
+			if nil == errs[i] {
+				errs[i] = source.ValidateResult(results[i])
+			}
+
+			done <- i
+		}(i, src)
+	}
+
+	// match is the index of the first matching source, once it's known
+	match := -1
+
+	for range sources {
+		i := <-done
+		finished[i] = true
+
+		// Lookups that finish after the first match are ignored
+		if match >= 0 {
+			skipped[i] = true
+			continue
+		}
+
+		if !conf.Ordered() {
+			printSourceResult(sources[i], results[i], errs[i])
+
+			if conf.FirstMatch() && nil == errs[i] {
+				match = i
+			}
+		} else if conf.FirstMatch() {
+			// The first match is the first successful source, in order,
+			// once all of the sources before it have failed
+			for j := range sources {
+				if !finished[j] {
+					break
+				}
+
+				if nil == errs[j] {
+					match = j
+					break
+				}
+			}
+		}
+
+		if match >= 0 {
+			cancel()
+		}
+	}
+
+	defined := false
+	var skippedNames []string
+
+	for i, src := range sources {
+		// When ordered, the sources after the first match are ignored
+		if skipped[i] || (conf.Ordered() && match >= 0 && i > match) {
+			skippedNames = append(skippedNames, fmt.Sprintf("%q", src.Name()))
+			continue
+		}
+
+		if conf.Ordered() {
+			printSourceResult(src, results[i], errs[i])
+		}
+
+		defined = defined || nil == errs[i]
+	}
+
+	if 0 < len(skippedNames) {
+		printError(fmt.Errorf("sources skipped after the first match: %s", strings.Join(skippedNames, ", ")))
+	}
+
+	return defined
+}
+
+// watchedResult is a result of defining a word while watching the clipboard,
+// and the source that defined it
+type watchedResult struct {
+	result source.Result
+	src    source.Source
+}
+
+// watchClipboard defines each new word (or short phrase) copied to the
+// system's clipboard, until interrupted. Words that were already defined are
+// printed again without being looked up again.
+func watchClipboard() {
+	read, err := clipboard.SystemReader()
+
+	handleError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	go func() {
+		<-interrupts
+		cancel()
+	}()
+
+	// Reload the configuration when hung up, between the definitions of words
+	var reloadMutex sync.Mutex
+
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	defer signal.Stop(hangups)
+
+	go func() {
+		for range hangups {
+			reloadMutex.Lock()
+
+			if err := reloadConfig(); nil != err {
+				printError(fmt.Errorf("the config wasn't reloaded: %s", err))
+			} else {
+				stdErrWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+					writer.WritePaddedStringLine("Config reloaded.", 1)
+				})
+			}
+
+			reloadMutex.Unlock()
+		}
+	}()
+
+	var results cache.Memory
+
+	stdErrWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine("Watching the clipboard for words to define (press Ctrl-C to stop)...", 1)
+	})
+
+	err = clipboard.Watch(ctx, read, clipboard.DefaultInterval, clipboard.DefaultDebounce, func(text string) {
+		reloadMutex.Lock()
+		defer reloadMutex.Unlock()
+
+		word, ok := watchedWord(text)
+
+		if !ok {
+			logger.Debugf("define: ignoring the clipboard text %q", text)
+			return
+		}
+
+		key := cacheKey(src, word)
+
+		if cached, ok := results.Get(key); ok {
+			logger.Debugf("define: using the cached result of %q", word)
+
+			printResult(cached.(watchedResult).result, cached.(watchedResult).src)
+			return
+		}
+
+		result, resultSrc, err := lookupWatchedWord(ctx, word)
+
+		if nil != ctx.Err() {
+			return
+		}
+
+		if nil != err {
+			printError(err)
+			return
+		}
+
+		results.Set(key, watchedResult{result, resultSrc})
+
+		printResult(result, resultSrc)
+	})
+
+	handleError(err)
+}
+
+// watchedWord returns the word (or short phrase) to define from the given
+// clipboard text, without its surrounding whitespace and punctuation, and
+// whether it should be defined at all (multi-line and long texts aren't)
+func watchedWord(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+
+	if strings.ContainsAny(text, "\r\n") {
+		return "", false
+	}
+
+	word := strings.TrimFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	if "" == word || maxWatchedLength < len([]rune(word)) || maxWatchedWords < len(strings.Fields(word)) {
+		return "", false
+	}
+
+	return word, true
+}
+
+// lookupWatchedWord looks up a word copied to the clipboard, applying the
+// overall timeout to each word rather than to the whole watch
+func lookupWatchedWord(ctx context.Context, word string) (source.Result, source.Source, error) {
+	runCtx, cancelRun = ctx, func() {}
+
+	if 0 < conf.Timeout {
+		runCtx, cancelRun = context.WithTimeout(ctx, time.Duration(conf.Timeout))
+	}
+
+	defer cancelRun()
+
+	return lookupWithFallback(word)
+}
+
+// readTextWords reads each of the whitespace-separated words of the given
+// text, without any of their surrounding punctuation
+func readTextWords(reader io.Reader) ([]string, error) {
+	var words []string
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Split(bufio.ScanWords)
+
+	for scanner.Scan() {
+		word := strings.TrimFunc(scanner.Text(), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		})
+
+		if "" != word {
+			words = append(words, word)
+		}
+	}
+
+	return words, scanner.Err()
+}
+
+// spellCheck checks whether each of the given words is found by any of the
+// sources, printing the words that aren't (and optionally their suggested
+// alternatives), and exits with the notFoundExitCode if any aren't found
+func spellCheck(words []string) {
+	var sources []source.Source
+
+	for _, info := range prioritizedSources() {
+		if providedSource, err := registry.Provide(info.conf); nil == err {
+			sources = append(sources, providedSource)
+		}
+	}
+
+	if len(sources) < 1 {
+		handleError(fmt.Errorf("no sources are available"))
+	}
+
+	misspelled := false
+
+	for _, word := range words {
+		found, suggestions, err := spellCheckWord(sources, word)
+
+		handleError(err)
+
+		if found {
+			continue
+		}
+
+		misspelled = true
+
+		if act.Suggest() && 0 < len(suggestions) {
+			if len(suggestions) > maxSuggestions {
+				suggestions = suggestions[:maxSuggestions]
+			}
+
+			stdOutWriter.WriteStringLine(fmt.Sprintf("%s: %s", word, strings.Join(suggestions, ", ")))
+		} else {
+			stdOutWriter.WriteStringLine(word)
+		}
+	}
+
+	if misspelled {
+		quit(notFoundExitCode)
+	}
+}
+
+// spellCheckWord returns whether the given word is found by any of the given
+// sources, in turn, and any suggested alternatives if it isn't. An error is
+// returned if none of the sources were able to determine whether it exists.
+func spellCheckWord(sources []source.Source, word string) (bool, []string, error) {
+	var suggestions []string
+	var lastErr error
+
+	determined := false
+
+	for _, src := range sources {
+		result, err := lookup(src, word)
+
+		if nil == err {
+			err = source.ValidateResult(result)
+		}
+
+		if nil == err {
+			return true, nil, nil
+		}
+
+		if emptyErr, ok := err.(*source.EmptyResultError); ok {
+			determined = true
+
+			if len(suggestions) < 1 {
+				suggestions = emptyErr.Suggestions
+			}
+
+			continue
+		}
+
+		if isRunEnded(err) {
+			return false, nil, err
+		}
+
+		lastErr = fmt.Errorf("source %q: %s", src.Name(), err)
+	}
+
+	if !determined {
+		return false, nil, lastErr
+	}
+
+	return false, suggestions, nil
+}
+
+// benchmarkSources compares each of the configured sources by defining the
+// given words (or a sample list of words) with each of them
+func benchmarkSources(words []string) {
+	var sources []source.Source
+
+	for _, info := range prioritizedSources() {
+		if providedSource, err := registry.Provide(info.conf); nil == err {
+			sources = append(sources, providedSource)
+		}
+	}
+
+	if len(sources) < 1 {
+		handleError(fmt.Errorf("no sources are available"))
+	}
+
+	if len(words) < 1 {
+		words = benchmark.DefaultWords
+	}
+
+	stdErrWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine(fmt.Sprintf("Benchmarking %d source(s) with %d word(s)...", len(sources), len(words)), 1)
+	})
+
+	results := benchmark.Run(sources, words, lookup, benchmark.Options{
+		Concurrency: benchmark.DefaultConcurrency,
+		Interval:    benchmark.DefaultInterval,
+	})
+
+	rows := [][]string{{"Source", "Success", "Avg. latency", "Avg. senses", "Avg. synonyms"}}
+
+	for _, result := range results {
+		rows = append(rows, []string{
+			fmt.Sprintf("%q", result.Source),
+			fmt.Sprintf("%d/%d (%.0f%%)", result.Successes, result.Lookups, 100*result.SuccessRate()),
+			result.AverageLatency().Round(time.Millisecond).String(),
+			fmt.Sprintf("%.1f", result.AverageSenses()),
+			fmt.Sprintf("%.1f", result.AverageSynonyms()),
+		})
+	}
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WritePaddedStringLine("Source benchmark:", 1)
+
+		writer.WriteColumns(rows)
+		writer.WriteNewLine()
+	})
+}
+
+// printSourceResult prints the result of a source's lookup, or its error, so
+// that it doesn't interleave with the output of any other concurrent lookups
+func printSourceResult(src source.Source, result source.Result, err error) {
+	outputMutex.Lock()
+	defer outputMutex.Unlock()
+
+	if nil != err {
+		printError(fmt.Errorf("source %q: %s", src.Name(), err))
+		return
+	}
+
+	printResult(result, src)
+}
+
+// printResult records and prints a successfully defined result of the given
+// source, according to the configured output
+func printResult(result source.Result, src source.Source) {
+	if conf.HistoryEnabled {
+		recordHistory(result, src)
+	}
+
+	renderResult(withThesaurus(result, src), src)
+}
+
+// withThesaurus returns a result of the given source with the synonyms and
+// antonyms of the thesaurus source added, if there is one. The result is
+// returned as is if the thesaurus source fails.
+func withThesaurus(result source.Result, src source.Source) source.Result {
+	if nil == thesaurusSrc || (nil != src && src.Name() == thesaurusSrc.Name()) {
+		return result
+	}
+
+	thesaurus, err := lookup(thesaurusSrc, result.Headword())
+
+	if _, ok := err.(*source.EmptyResultError); ok {
+		logger.Debugf("define: the thesaurus source %q doesn't have %q", thesaurusSrc.Name(), result.Headword())
+
+		return result
+	}
+
+	if nil != err {
+		printError(fmt.Errorf("warning: couldn't look up %q with the thesaurus source %q: %s", result.Headword(), thesaurusSrc.Name(), err))
+
+		return result
+	}
+
+	return source.MergeThesaurus(result, thesaurus)
+}
+
+// renderResult prints a result of the given source (or of an unknown source,
+// if nil), according to the configured output
+func renderResult(result source.Result, src source.Source) {
+	result = source.LimitSenses(result, conf.LimitPerPOS, conf.MaxSenses)
+
+	if "" != conf.PostProcess {
+		logger.Debugf("define: post-processing the result with %q", conf.PostProcess)
+
+		postProcessResult(result)
+		return
+	}
+
+	if conf.Porcelain() {
+		logger.Debugf("define: printing the result with the porcelain printer")
+
+		printer.NewPorcelainPrinter(stdOutWriter).PrintResult(result)
+		return
+	}
+
+	if config.OutputFormatJSON == conf.OutputFormat {
+		logger.Debugf("define: printing the result as JSON")
+
+		handleError(printer.NewJSONPrinter(stdOutWriter).PrintResult(result))
+		return
+	}
+
+	logger.Debugf("define: printing the result with the result printer")
+
+	headwordCase, err := printer.ParseHeadwordCase(conf.HeadwordCase)
+
+	handleError(err)
+
+	resultPrinter := printer.NewResultPrinter(stdOutWriter)
+	resultPrinter.SetHeadwordCase(headwordCase)
+	resultPrinter.SetMinSynonyms(conf.MinSynonyms)
+	resultPrinter.SetShowExamples(conf.ShowExamples.Or(true))
+	resultPrinter.SetMaxExamplesPerSense(conf.MaxExamplesPerSense)
+	resultPrinter.SetShowThesaurus(!conf.HideThesaurus.Or(false))
+	resultPrinter.SetShowInflections(conf.ShowForms)
+	resultPrinter.SetColor(conf.Color.Or(defineio.IsTerminal(os.Stdout) && "" == os.Getenv("NO_COLOR")))
+
+	if bullet, isSet := conf.SenseBullet(); isSet {
+		resultPrinter.SetBullet(bullet)
+	}
+
+	if theme, err := conf.EffectiveTheme(); nil == err {
+		resultPrinter.SetTheme(theme)
+	}
+
+	resultPrinter.PrintResult(result)
+
+	if nil != src {
+		resultPrinter.PrintSourceName(src)
+	}
+}
+
+// defineJSON prints the results of the JSON document(s) at the given location
+// ("-" for stdin), such as previously printed with the JSON output format,
+// without querying any source
+func defineJSON(location string) {
+	if "-" == location && conf.ConfigStdin() {
+		handleError(errors.New("can't read the results from stdin, as the config file was read from it"))
+	}
+
+	var file io.Reader = os.Stdin
+
+	if "-" != location {
+		opened, err := os.Open(location)
+
+		handleError(err)
+
+		defer opened.Close()
+
+		file = opened
+	}
+
+	// Several results (such as of several words) are a stream of documents
+	decoder := json.NewDecoder(file)
+	count := 0
+
+	for {
+		var document json.RawMessage
+
+		err := decoder.Decode(&document)
+
+		if io.EOF == err {
+			break
+		}
+
+		handleError(err)
+
+		result, err := source.UnmarshalResultJSON(document)
+
+		handleError(err)
+
+		renderResult(result, nil)
+		count++
+	}
+
+	if 0 == count {
+		handleError(fmt.Errorf("no results found in %q", location))
+	}
+}
+
+// isTextOutput returns whether results are printed as human-readable text,
+// rather than in a format for scripts (or through a post-process command)
+func isTextOutput() bool {
+	return "" == conf.PostProcess && !conf.Porcelain() && config.OutputFormatJSON != conf.OutputFormat
+}
+
+// printTranslation prints the translation of a result's headword into the
+// configured language, if any. Translation failures are reported without
+// failing, as the translation is only supplementary to the definition.
+func printTranslation(result source.Result) {
+	if "" == conf.Translate || !isTextOutput() {
+		return
+	}
+
+	translator := translate.New(http.Client{Timeout: time.Duration(conf.PerSourceTimeout)})
+	translation, err := source.TranslateContext(runCtx, translator, result.Headword(), translationSourceLanguage, conf.Translate)
+
+	if nil != err && nil != interruptCtx.Err() {
+		handleError(&cancelledError{})
+	}
+
+	if context.DeadlineExceeded == err {
+		err = &overallTimeoutError{time.Duration(conf.Timeout)}
+	}
+
+	if nil != err {
+		printError(fmt.Errorf("warning: couldn't translate %q into %q: %s", result.Headword(), conf.Translate, err))
+		return
+	}
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WriteStringLine(fmt.Sprintf("Translation (%s):", conf.Translate))
+
+		writer.IndentWrites(func(writer *defineio.PanicWriter) {
+			writer.WriteStringLine(translation)
+		})
+
+		writer.WriteNewLine()
+		writer.WriteStringLine(fmt.Sprintf("Translation provided by: %q", translator.Name()))
+		writer.WriteNewLine()
+	})
+}
+
+// printRelated prints the words commonly used with a result's headword, if
+// enabled. Failures are silently skipped (other than being logged), as the
+// related words are only supplementary to the definition.
+func printRelated(result source.Result) {
+	if !conf.Related || !isTextOutput() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(runCtx, relatedWordsTimeout)
+	defer cancel()
+
+	associator := datamuse.New(http.Client{}).(source.Associator)
+	related, err := source.RelatedContext(ctx, associator, result.Headword(), relatedWordsLimit)
+
+	if nil != err && nil != interruptCtx.Err() {
+		handleError(&cancelledError{})
+	}
+
+	if nil != err {
+		logger.Debugf("define: couldn't find the words related to %q: %s", result.Headword(), err)
+		return
+	}
+
+	if len(related) < 1 {
+		return
+	}
+
+	stdOutWriter.IndentWrites(func(writer *defineio.PanicWriter) {
+		writer.WriteStringLine("Related:")
+
+		writer.IndentWrites(func(writer *defineio.PanicWriter) {
+			writer.WriteStringLine(strings.Join(related, ", "))
+		})
+
+		writer.WriteNewLine()
+		writer.WriteStringLine(fmt.Sprintf("Related words provided by: %q", associator.Name()))
+		writer.WriteNewLine()
+	})
+}
+
+func postProcessResult(result source.Result) {
+	encoded, err := source.MarshalResultJSON(result)
+
+	handleError(err)
+
+	handleError(postprocess.Run(conf.PostProcess, encoded, stdOutWriter, stdErrWriter))
+}
+
+func main() {
+	// Get the word from our first non-flag argument
+	word := flags.Arg(0)
+
+	// Decide what to perform
+	switch act.Type() {
+	case action.PrintConfig:
+		printConfig()
+	case action.ValidateConfig:
+		validateConfig()
+	case action.InitConfig:
+		initConfig()
+	case action.MigrateConfig:
+		migrateConfig()
+	case action.ConfigSet:
+		setConfigValue(act.Value())
+	case action.ConfigGet:
+		printConfigValue(act.Value())
+	case action.ListSources:
+		printSources()
+	case action.ListEnv:
+		printEnvVars()
+	case action.PrintVersion:
+		printVersion()
+	case action.PrintVersionJSON:
+		printVersionJSON()
+	case action.SetKey:
+		setKey(act.Value())
+	case action.SetSecret:
+		setSecret(act.Value())
+	case action.PrintHistory:
+		printHistory(word)
+	case action.ClearHistory:
+		clearHistory()
+	case action.PrintCacheInfo:
+		printCacheInfo()
+	case action.ClearCache:
+		clearCache()
+	case action.StarWord:
+		starWord(act.Value())
+	case action.UnstarWord:
+		unstarWord(act.Value())
+	case action.ListStarred:
+		printStarred()
+	case action.ExportAnki:
+		exportAnki(act.Value())
+	case action.MatchRegex:
+		matchRegex(act.Value())
+	case action.DefineJSON:
+		defineJSON(act.Value())
+	case action.SelfUpdate:
+		selfUpdate()
+	case action.CheckUpdate:
+		checkUpdate()
+	case action.PrintUsage:
+		printUsage(stdErrWriter)
+		quit(2)
+	case action.WordFrequency:
+		words, err := readWords()
+
+		handleError(err)
+
+		if len(words) < 1 {
+			printUsage(stdOutWriter)
+			quit(1)
+		}
+
+		printFrequencies(words)
+	case action.WatchClipboard:
+		watchClipboard()
+	case action.PrintWebURL:
+		words, err := readWords()
+
+		handleError(err)
+
+		if len(words) < 1 {
+			printUsage(stdOutWriter)
+			quit(1)
+		}
+
+		printWebPageURLs(words)
+	case action.PrintPronunciation:
+		words, err := readWords()
+
+		handleError(err)
+
+		if len(words) < 1 {
+			printUsage(stdOutWriter)
+			quit(1)
+		}
+
+		printPronunciations(words)
+	case action.FindRhymes:
+		findRhymes(act.Value())
+	case action.SpellCheck:
+		words, err := readWords()
+
+		handleError(err)
+
+		// Read the words of any piped text, like a spell checker would
+		if len(words) < 1 && !conf.ConfigStdin() && !defineio.IsTerminal(os.Stdin) {
+			words, err = readTextWords(os.Stdin)
+
+			handleError(err)
+		}
+
+		if len(words) < 1 {
+			printUsage(stdOutWriter)
+			quit(1)
+		}
+
+		spellCheck(words)
+	case action.BenchmarkSources:
+		words, err := readWords()
+
+		handleError(err)
+
+		benchmarkSources(words)
+	case action.DryRun:
+		words, err := readWords()
+
+		handleError(err)
+
+		if len(words) < 1 {
+			printUsage(stdOutWriter)
+			quit(1)
+		}
+
+		dryRun(words)
+	case action.DefineWord:
 		fallthrough
 	default:
-		if "" == word {
+		words, err := readWords()
+
+		handleError(err)
+
+		switch {
+		case len(words) < 1:
 			// Show our usage
 			printUsage(stdOutWriter)
 			quit(1)
-		} else {
-			defineWord(word)
+		case conf.AllSources():
+			failed := false
+
+			for _, word := range words {
+				failed = !defineWithAllSources(word) || failed
+			}
+
+			if failed {
+				quit(1)
+			}
+		case 1 == len(words):
+			defineWord(words[0])
+		default:
+			defineWords(words)
 		}
 	}
 }
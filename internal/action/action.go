@@ -12,8 +12,38 @@ import (
 const (
 	DefineWord Type = iota
 	PrintConfig
+	ValidateConfig
+	InitConfig
+	ConfigSet
+	ConfigGet
 	ListSources
 	PrintVersion
+	PrintVersionJSON
+	SetKey
+	PrintHistory
+	ClearHistory
+	StarWord
+	UnstarWord
+	ListStarred
+	ExportAnki
+	MatchRegex
+	SelfUpdate
+	CheckUpdate
+	DryRun
+	PrintUsage
+	ListEnv
+	BenchmarkSources
+	SpellCheck
+	FindRhymes
+	WordFrequency
+	PrintPronunciation
+	PrintWebURL
+	WatchClipboard
+	SetSecret
+	DefineJSON
+	MigrateConfig
+	PrintCacheInfo
+	ClearCache
 )
 
 // Type defines the type of action intended for the app to perform.
@@ -21,11 +51,46 @@ type Type uint
 
 // Action defines an intended action for the app to perform.
 type Action struct {
-	flagSet *flag.FlagSet
-	flag    struct {
+	flagSet    *flag.FlagSet
+	subcommand *Subcommand
+	flag       struct {
 		printConfig  bool
+		validate     bool
+		strict       bool
+		initConfig   bool
+		migrate      bool
+		force        bool
+		configSet    string
+		configGet    string
+		showSecrets  bool
 		listSources  bool
 		printVersion bool
+		versionJSON  bool
+		json         bool
+		setKey       string
+		setSecret    string
+		history      bool
+		historyClear bool
+		star         string
+		unstar       string
+		starred      bool
+		anki         string
+		regex        string
+		selfUpdate   bool
+		checkUpdate  bool
+		dryRun       bool
+		listEnv      bool
+		benchmark    bool
+		spell        bool
+		suggest      bool
+		rhymes       string
+		near         bool
+		frequency    bool
+		ipa          bool
+		open         bool
+		printURL     bool
+		watch        bool
+		defineJSON   string
 	}
 }
 
@@ -38,8 +103,42 @@ func Setup(flags *flag.FlagSet) *Action {
 
 	// Define our flags
 	flags.BoolVar(&act.flag.printConfig, "print-config", false, "To print the current configuration")
+	flags.BoolVar(&act.flag.initConfig, "init-config", false, "To write a commented example config file to the config file location")
+	flags.BoolVar(&act.flag.migrate, "migrate-config", false, "To rename the deprecated keys of the config file to their current names")
+	flags.BoolVar(&act.flag.force, "force", false, "To overwrite an existing file (such as with --init-config)")
+	flags.StringVar(&act.flag.configSet, "config-set", "", "To set a key (such as \"OxfordDictionary.AppKey=value\") in the config file")
+	flags.StringVar(&act.flag.configGet, "config-get", "", "To print the current value of a key (such as \"OxfordDictionary.AppKey\")")
+	flags.BoolVar(&act.flag.showSecrets, "show-secrets", false, "To show the values of secrets (such as with --config-get or --list-env)")
+	flags.BoolVar(&act.flag.validate, "validate-config", false, "To validate the config file and the configuration of its sources")
+	flags.BoolVar(&act.flag.strict, "strict", false, "To also report the comments and trailing commas of a JSON config file as problems (with --validate-config)")
 	flags.BoolVar(&act.flag.listSources, "list-sources", false, "To print the available sources")
+	flags.BoolVar(&act.flag.listEnv, "list-env", false, "To print the environment variables that the app reads, and their current values")
 	flags.BoolVar(&act.flag.printVersion, "version", false, "To print the app's version info")
+	flags.BoolVar(&act.flag.versionJSON, "version-json", false, "To print the app's version info as JSON")
+	flags.BoolVar(&act.flag.json, "json", false, "To print the output as JSON (with --version or --rhymes)")
+	flags.BoolVar(&act.flag.selfUpdate, "self-update", false, "To update the app to the latest released version")
+	flags.BoolVar(&act.flag.checkUpdate, "check-update", false, "To check whether a newer released version of the app exists")
+	flags.BoolVar(&act.flag.history, "history", false, "To print the most recent lookups (optionally pass the number to print)")
+	flags.BoolVar(&act.flag.historyClear, "history-clear", false, "To clear the lookup history")
+	flags.StringVar(&act.flag.star, "star", "", "To save the given word, and its definition, to the starred words list")
+	flags.StringVar(&act.flag.unstar, "unstar", "", "To remove the given word from the starred words list")
+	flags.BoolVar(&act.flag.starred, "starred", false, "To print the starred words list")
+	flags.StringVar(&act.flag.anki, "anki", "", "To export the given words as flashcards to the given Anki-importable file")
+	flags.StringVar(&act.flag.regex, "regex", "", "To list the words of a local dictionary source matching the given regular expression")
+	flags.BoolVar(&act.flag.spell, "spell", false, "To only check whether the given words are found by any source, printing those that aren't")
+	flags.BoolVar(&act.flag.suggest, "suggest", false, "To print the suggested alternatives of each word that isn't found (with --spell)")
+	flags.StringVar(&act.flag.rhymes, "rhymes", "", "To print the words that rhyme with the given word")
+	flags.BoolVar(&act.flag.near, "near", false, "To print the words that nearly rhyme instead (with --rhymes)")
+	flags.BoolVar(&act.flag.frequency, "frequency", false, "To print how frequently the given words are used, ranking them if there are several")
+	flags.BoolVar(&act.flag.open, "open", false, "To also open the source's web page for each defined word in the default web browser")
+	flags.BoolVar(&act.flag.printURL, "print-url", false, "To only print the URL of the source's web page for each of the given words")
+	flags.BoolVar(&act.flag.watch, "watch-clipboard", false, "To define each new word (or short phrase) copied to the clipboard, until interrupted")
+	flags.BoolVar(&act.flag.ipa, "ipa", false, "To only print the phonetic transcriptions (IPA) of the given words, one per line with their region labels")
+	flags.BoolVar(&act.flag.benchmark, "benchmark-sources", false, "To compare the latency, success rate, and result richness of each configured source, by defining the given words (or a sample list)")
+	flags.StringVar(&act.flag.defineJSON, "define-json", "", "To print the results of the given file of JSON results (\"-\" for stdin), such as printed with \"--output-format=json\", without querying any source")
+	flags.BoolVar(&act.flag.dryRun, "dry-run", false, "To print the sources and requests that would be used to define the given words, without sending them")
+	flags.StringVar(&act.flag.setKey, "set-key", "", "To interactively store the value of the given API key flag in the system keyring")
+	flags.StringVar(&act.flag.setSecret, "config-set-secret", "", "To interactively store the value of a key (such as \"OxfordDictionary.AppKey\") in the system keyring, referencing it from the config file")
 
 	// Pass our flagset, so we can be diligent about parse checking later
 	act.flagSet = flags
@@ -58,14 +157,160 @@ func (a *Action) validateState() {
 func (a *Action) Type() Type {
 	a.validateState()
 
+	if nil != a.subcommand {
+		return a.subcommandType()
+	}
+
 	switch {
 	case a.flag.printConfig:
 		return PrintConfig
+	case a.flag.validate:
+		return ValidateConfig
+	case a.flag.initConfig:
+		return InitConfig
+	case a.flag.migrate:
+		return MigrateConfig
+	case "" != a.flag.configSet:
+		return ConfigSet
+	case "" != a.flag.configGet:
+		return ConfigGet
+	case "" != a.flag.setSecret:
+		return SetSecret
 	case a.flag.listSources:
 		return ListSources
+	case a.flag.listEnv:
+		return ListEnv
+	case a.flag.benchmark:
+		return BenchmarkSources
+	case a.flag.spell:
+		return SpellCheck
+	case "" != a.flag.rhymes:
+		return FindRhymes
+	case a.flag.frequency:
+		return WordFrequency
+	case a.flag.ipa:
+		return PrintPronunciation
+	case a.flag.printURL:
+		return PrintWebURL
+	case a.flag.watch:
+		return WatchClipboard
+	case a.flag.versionJSON, a.flag.printVersion && a.flag.json:
+		return PrintVersionJSON
 	case a.flag.printVersion:
 		return PrintVersion
+	case "" != a.flag.setKey:
+		return SetKey
+	case a.flag.historyClear:
+		return ClearHistory
+	case a.flag.history:
+		return PrintHistory
+	case "" != a.flag.star:
+		return StarWord
+	case "" != a.flag.unstar:
+		return UnstarWord
+	case a.flag.starred:
+		return ListStarred
+	case "" != a.flag.anki:
+		return ExportAnki
+	case "" != a.flag.regex:
+		return MatchRegex
+	case "" != a.flag.defineJSON:
+		return DefineJSON
+	case a.flag.selfUpdate:
+		return SelfUpdate
+	case a.flag.checkUpdate:
+		return CheckUpdate
+	case a.flag.dryRun:
+		return DryRun
 	default:
 		return DefineWord
 	}
 }
+
+// Value returns the value passed to the action's flag, for the action types
+// that take one (SetKey, StarWord, UnstarWord, ExportAnki, MatchRegex,
+// ConfigSet, ConfigGet, SetSecret, and DefineJSON).
+func (a *Action) Value() string {
+	a.validateState()
+
+	if nil != a.subcommand {
+		return a.subcommandValue()
+	}
+
+	switch a.Type() {
+	case SetKey:
+		return a.flag.setKey
+	case StarWord:
+		return a.flag.star
+	case UnstarWord:
+		return a.flag.unstar
+	case ExportAnki:
+		return a.flag.anki
+	case MatchRegex:
+		return a.flag.regex
+	case FindRhymes:
+		return a.flag.rhymes
+	case ConfigSet:
+		return a.flag.configSet
+	case ConfigGet:
+		return a.flag.configGet
+	case SetSecret:
+		return a.flag.setSecret
+	case DefineJSON:
+		return a.flag.defineJSON
+	default:
+		return ""
+	}
+}
+
+// Force returns whether the action should overwrite any existing files.
+func (a *Action) Force() bool {
+	a.validateState()
+
+	return a.flag.force
+}
+
+// Strict returns whether the action should validate the config file strictly.
+func (a *Action) Strict() bool {
+	a.validateState()
+
+	return a.flag.strict
+}
+
+// ShowSecrets returns whether the action should show the values of secrets.
+func (a *Action) ShowSecrets() bool {
+	a.validateState()
+
+	return a.flag.showSecrets
+}
+
+// Suggest returns whether the action should print the suggested alternatives
+// of words.
+func (a *Action) Suggest() bool {
+	a.validateState()
+
+	return a.flag.suggest
+}
+
+// Near returns whether the action should find near matches, such as near
+// rhymes.
+func (a *Action) Near() bool {
+	a.validateState()
+
+	return a.flag.near
+}
+
+// JSON returns whether the action should print its output as JSON.
+func (a *Action) JSON() bool {
+	a.validateState()
+
+	return a.flag.json
+}
+
+// Open returns whether the action should open the web pages of the defined
+// words.
+func (a *Action) Open() bool {
+	a.validateState()
+
+	return a.flag.open
+}
@@ -0,0 +1,35 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package action
+
+import (
+	"testing"
+
+	flag "github.com/ogier/pflag"
+)
+
+func TestVersionType(t *testing.T) {
+	testData := []struct {
+		arguments []string
+		wantType  Type
+	}{
+		{[]string{"--version"}, PrintVersion},
+		{[]string{"--version", "--json"}, PrintVersionJSON},
+		{[]string{"--json", "--version"}, PrintVersionJSON},
+		{[]string{"--version-json"}, PrintVersionJSON},
+		{[]string{"--json", "word"}, DefineWord},
+	}
+
+	for _, data := range testData {
+		flags := flag.NewFlagSet("test", flag.ContinueOnError)
+		act := Setup(flags)
+
+		if err := flags.Parse(data.arguments); nil != err {
+			t.Fatalf("parsing %q failed: %s", data.arguments, err)
+		}
+
+		if got := act.Type(); data.wantType != got {
+			t.Errorf("Type() for %q returned %d, want %d", data.arguments, got, data.wantType)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package action
+
+import (
+	"strings"
+
+	flag "github.com/ogier/pflag"
+)
+
+// List of the verbs of the config subcommand.
+const (
+	configVerbPrint    = "print"
+	configVerbValidate = "validate"
+	configVerbInit     = "init"
+	configVerbMigrate  = "migrate"
+	configVerbGet      = "get"
+	configVerbSet      = "set"
+	configVerbSecret   = "set-secret"
+)
+
+// List of the verbs of the cache subcommand.
+const (
+	cacheVerbInfo  = "info"
+	cacheVerbClear = "clear"
+)
+
+// Subcommand defines a subcommand of the app, as an alternative to the
+// flag-style actions.
+type Subcommand struct {
+	Name        string
+	Usage       string
+	Description string
+}
+
+// Subcommands is the list of the app's subcommands.
+var Subcommands = []Subcommand{
+	{
+		Name:        "lookup",
+		Usage:       "[<options>...] [--] <word>...",
+		Description: "Define the given words",
+	},
+	{
+		Name:        "sources",
+		Usage:       "[<options>...]",
+		Description: "Print the available sources",
+	},
+	{
+		Name:        "config",
+		Usage:       "(print | validate | init | migrate | get <key> | set <key>=<value> | set-secret <key>) [<options>...]",
+		Description: "Print, validate, initialize, migrate, or modify the configuration",
+	},
+	{
+		Name:        "cache",
+		Usage:       "(info | clear)",
+		Description: "Print the location and size of, or clear, the cache of source results",
+	},
+	{
+		Name:        "version",
+		Usage:       "[--json]",
+		Description: "Print the app's version info",
+	},
+}
+
+// ParseSubcommand returns the subcommand named by the first of the given
+// arguments and the rest of the arguments, or nil and all of the arguments if
+// the first doesn't name a subcommand.
+func ParseSubcommand(arguments []string) (*Subcommand, []string) {
+	if len(arguments) < 1 {
+		return nil, arguments
+	}
+
+	for i := range Subcommands {
+		if Subcommands[i].Name == arguments[0] {
+			return &Subcommands[i], arguments[1:]
+		}
+	}
+
+	return nil, arguments
+}
+
+// SetupSubcommand sets up a lazy-valued action for a subcommand, based on a
+// given flag set. Only the flags relevant to the subcommand are defined.
+//
+// NOTE: The passed flag set will have to be parsed before the action can be
+// used, just as with Setup.
+func SetupSubcommand(flags *flag.FlagSet, subcommand *Subcommand) *Action {
+	act := Action{flagSet: flags, subcommand: subcommand}
+
+	switch subcommand.Name {
+	case "lookup":
+		flags.BoolVar(&act.flag.dryRun, "dry-run", false, "To print the sources and requests that would be used to define the given words, without sending them")
+		flags.BoolVar(&act.flag.open, "open", false, "To also open the source's web page for each defined word in the default web browser")
+		flags.StringVar(&act.flag.defineJSON, "define-json", "", "To print the results of the given file of JSON results (\"-\" for stdin), without querying any source")
+	case "config":
+		flags.BoolVar(&act.flag.force, "force", false, "To overwrite an existing config file (with init)")
+		flags.BoolVar(&act.flag.strict, "strict", false, "To also report the comments and trailing commas of a JSON config file as problems (with validate)")
+		flags.BoolVar(&act.flag.showSecrets, "show-secrets", false, "To show the values of secrets (with get)")
+	case "version":
+		flags.BoolVar(&act.flag.versionJSON, "json", false, "To print the version info as JSON")
+	}
+
+	return &act
+}
+
+// Subcommand returns the subcommand of the action, or nil if the action wasn't
+// set up for a subcommand.
+func (a *Action) Subcommand() *Subcommand {
+	return a.subcommand
+}
+
+// subcommandType returns the action type to perform for the subcommand.
+func (a *Action) subcommandType() Type {
+	switch a.subcommand.Name {
+	case "lookup":
+		if a.flag.dryRun {
+			return DryRun
+		}
+
+		if "" != a.flag.defineJSON {
+			return DefineJSON
+		}
+
+		return DefineWord
+	case "sources":
+		return ListSources
+	case "config":
+		switch a.flagSet.Arg(0) {
+		case configVerbPrint:
+			return PrintConfig
+		case configVerbValidate:
+			return ValidateConfig
+		case configVerbInit:
+			return InitConfig
+		case configVerbMigrate:
+			return MigrateConfig
+		case configVerbGet:
+			if "" != a.flagSet.Arg(1) {
+				return ConfigGet
+			}
+		case configVerbSet:
+			if "" != a.flagSet.Arg(1) {
+				return ConfigSet
+			}
+		case configVerbSecret:
+			if "" != a.flagSet.Arg(1) {
+				return SetSecret
+			}
+		}
+
+		return PrintUsage
+	case "cache":
+		switch a.flagSet.Arg(0) {
+		case cacheVerbInfo:
+			return PrintCacheInfo
+		case cacheVerbClear:
+			return ClearCache
+		}
+
+		return PrintUsage
+	case "version":
+		if a.flag.versionJSON {
+			return PrintVersionJSON
+		}
+
+		return PrintVersion
+	default:
+		return PrintUsage
+	}
+}
+
+// subcommandValue returns the value passed to the subcommand's arguments, for
+// the action types that take one.
+func (a *Action) subcommandValue() string {
+	switch a.subcommandType() {
+	case ConfigGet, SetSecret:
+		return a.flagSet.Arg(1)
+	case ConfigSet:
+		// Allow the value to be passed as a separate argument
+		return strings.Join(a.flagSet.Args()[1:], "=")
+	case DefineJSON:
+		return a.flag.defineJSON
+	default:
+		return ""
+	}
+}
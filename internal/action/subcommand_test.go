@@ -0,0 +1,74 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package action
+
+import (
+	"testing"
+
+	flag "github.com/ogier/pflag"
+)
+
+func TestParseSubcommand(t *testing.T) {
+	subcommand, rest := ParseSubcommand([]string{"config", "get", "Source"})
+
+	if nil == subcommand || "config" != subcommand.Name {
+		t.Fatalf("ParseSubcommand returned %v, want the config subcommand", subcommand)
+	}
+
+	if 2 != len(rest) || "get" != rest[0] {
+		t.Errorf("ParseSubcommand returned the remaining arguments %q", rest)
+	}
+
+	for _, arguments := range [][]string{{}, {"word"}, {"--", "config"}, {"--version"}} {
+		if subcommand, rest := ParseSubcommand(arguments); nil != subcommand || len(arguments) != len(rest) {
+			t.Errorf("ParseSubcommand(%q) returned %v, %q, want no subcommand", arguments, subcommand, rest)
+		}
+	}
+}
+
+func TestSubcommandType(t *testing.T) {
+	testData := []struct {
+		arguments []string
+		wantType  Type
+		wantValue string
+	}{
+		{[]string{"lookup", "word"}, DefineWord, ""},
+		{[]string{"lookup", "--dry-run", "word"}, DryRun, ""},
+		{[]string{"lookup", "--define-json=results.json"}, DefineJSON, "results.json"},
+		{[]string{"sources"}, ListSources, ""},
+		{[]string{"version"}, PrintVersion, ""},
+		{[]string{"version", "--json"}, PrintVersionJSON, ""},
+		{[]string{"config", "print"}, PrintConfig, ""},
+		{[]string{"config", "validate"}, ValidateConfig, ""},
+		{[]string{"config", "init", "--force"}, InitConfig, ""},
+		{[]string{"config", "migrate"}, MigrateConfig, ""},
+		{[]string{"config", "get", "Source"}, ConfigGet, "Source"},
+		{[]string{"config", "set", "Source=x"}, ConfigSet, "Source=x"},
+		{[]string{"config", "set", "Source", "x"}, ConfigSet, "Source=x"},
+		{[]string{"config", "set-secret", "OxfordDictionary.AppKey"}, SetSecret, "OxfordDictionary.AppKey"},
+		{[]string{"config", "set-secret"}, PrintUsage, ""},
+		{[]string{"config", "get"}, PrintUsage, ""},
+		{[]string{"config"}, PrintUsage, ""},
+		{[]string{"cache", "info"}, PrintCacheInfo, ""},
+		{[]string{"cache", "clear"}, ClearCache, ""},
+		{[]string{"cache"}, PrintUsage, ""},
+	}
+
+	for _, data := range testData {
+		subcommand, arguments := ParseSubcommand(data.arguments)
+		flags := flag.NewFlagSet("test", flag.ContinueOnError)
+		act := SetupSubcommand(flags, subcommand)
+
+		if err := flags.Parse(arguments); nil != err {
+			t.Fatalf("parsing %q failed: %s", data.arguments, err)
+		}
+
+		if got := act.Type(); data.wantType != got {
+			t.Errorf("Type() for %q returned %d, want %d", data.arguments, got, data.wantType)
+		}
+
+		if got := act.Value(); data.wantValue != got {
+			t.Errorf("Value() for %q returned %q, want %q", data.arguments, got, data.wantValue)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package anki provides the exporting of results as flashcards that can be
+// imported into Anki.
+package anki
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"os"
+	"strings"
+
+	"github.com/Rican7/define/source"
+	flag "github.com/ogier/pflag"
+)
+
+const (
+	fieldSeparator = "\t"
+	lineBreak      = "<br>"
+)
+
+// fieldCleaner replaces characters that would break the tab-separated format
+var fieldCleaner = strings.NewReplacer("\t", " ", "\r\n", lineBreak, "\n", lineBreak, "\r", lineBreak)
+
+// Options defines the options for exporting flashcards.
+type Options struct {
+	IncludePronunciation bool
+	IncludeSynonyms      bool
+	Append               bool
+}
+
+// Card defines a flashcard, with its front and back as HTML.
+type Card struct {
+	Front string
+	Back  string
+}
+
+// SetupOptions sets up lazy-valued export options based on a given flag set.
+func SetupOptions(flags *flag.FlagSet) *Options {
+	var opts Options
+
+	// Define our flags
+	flags.BoolVar(&opts.IncludePronunciation, "anki-pronunciation", false, "To include the pronunciation on the back of Anki flashcards")
+	flags.BoolVar(&opts.IncludeSynonyms, "anki-synonyms", false, "To include synonyms on the back of Anki flashcards")
+	flags.BoolVar(&opts.Append, "anki-append", false, "To append to an existing Anki file, skipping words already present")
+
+	return &opts
+}
+
+// NewCard creates a new Card from a result, with the word on the front and the
+// formatted definitions and examples on the back.
+func NewCard(result source.Result, opts Options) Card {
+	var back []string
+	var synonyms []string
+
+	for _, entry := range result.Entries() {
+		if opts.IncludePronunciation && "" != entry.Pronunciation() {
+			back = append(back, fmt.Sprintf("/%s/", html.EscapeString(entry.Pronunciation())))
+		}
+
+		if wordEntry, ok := entry.(source.WordEntry); ok && "" != wordEntry.Category() {
+			back = append(back, fmt.Sprintf("<i>(%s)</i>", html.EscapeString(wordEntry.Category())))
+		}
+
+		for i, sense := range entry.Senses() {
+			if len(sense.Definitions()) < 1 {
+				continue
+			}
+
+			definitions := html.EscapeString(strings.Join(sense.Definitions(), "; "))
+
+			back = append(back, fmt.Sprintf("%d. %s", i+1, definitions))
+
+			for _, example := range sense.Examples() {
+				back = append(back, fmt.Sprintf("<i>&quot;%s&quot;</i>", html.EscapeString(example)))
+			}
+		}
+
+		if thesaurusEntry, ok := entry.(source.ThesaurusEntry); ok {
+			synonyms = append(synonyms, thesaurusEntry.Synonyms()...)
+		}
+	}
+
+	if opts.IncludeSynonyms && len(synonyms) > 0 {
+		back = append(back, "Synonyms: "+html.EscapeString(strings.Join(synonyms, ", ")))
+	}
+
+	return Card{
+		Front: html.EscapeString(result.Headword()),
+		Back:  strings.Join(back, lineBreak),
+	}
+}
+
+// Write writes the cards to a tab-separated file at the given path, that can
+// be imported into Anki.
+//
+// If appending, the cards are added to the end of any existing file, skipping
+// any cards whose front is already present in the file. Otherwise, any
+// existing file is replaced.
+func Write(path string, cards []Card, appending bool) error {
+	fileFlags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	existing := make(map[string]bool)
+
+	if appending {
+		var err error
+
+		if existing, err = readFronts(path); nil != err {
+			return err
+		}
+
+		fileFlags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+
+	file, err := os.OpenFile(path, fileFlags, 0644)
+
+	if nil != err {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+
+	for _, card := range cards {
+		key := frontKey(card.Front)
+
+		if existing[key] {
+			continue
+		}
+
+		existing[key] = true
+
+		fmt.Fprint(writer, fieldCleaner.Replace(card.Front), fieldSeparator, fieldCleaner.Replace(card.Back), "\n")
+	}
+
+	if err = writer.Flush(); nil != err {
+		file.Close()
+
+		return err
+	}
+
+	return file.Close()
+}
+
+// readFronts reads the fronts of the cards in an existing file
+func readFronts(path string) (map[string]bool, error) {
+	fronts := make(map[string]bool)
+
+	file, err := os.Open(path)
+
+	if os.IsNotExist(err) {
+		return fronts, nil
+	} else if nil != err {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		front := strings.SplitN(scanner.Text(), fieldSeparator, 2)[0]
+
+		fronts[frontKey(front)] = true
+	}
+
+	return fronts, scanner.Err()
+}
+
+// frontKey returns the key used to compare the fronts of cards
+func frontKey(front string) string {
+	return strings.ToLower(strings.TrimSpace(html.UnescapeString(front)))
+}
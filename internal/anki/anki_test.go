@@ -0,0 +1,100 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package anki
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestNewCardEscapesHTML(t *testing.T) {
+	result := source.NewResult("<b>&co", "en",
+		source.NewEntry("<b>&co", "n & v",
+			source.NewSense("a \"quoted\" <tag> & more", "it's").WithExamples("1 < 2 & 3 > 2"),
+		).WithPronunciation("<ko>").WithSynonyms("a&b", "<c>"),
+	)
+
+	card := NewCard(result, Options{IncludePronunciation: true, IncludeSynonyms: true})
+
+	if want := "&lt;b&gt;&amp;co"; want != card.Front {
+		t.Errorf("NewCard returned the front %q, want %q", card.Front, want)
+	}
+
+	want := "/&lt;ko&gt;/" + lineBreak +
+		"<i>(n &amp; v)</i>" + lineBreak +
+		"1. a &#34;quoted&#34; &lt;tag&gt; &amp; more; it&#39;s" + lineBreak +
+		"<i>&quot;1 &lt; 2 &amp; 3 &gt; 2&quot;</i>" + lineBreak +
+		"Synonyms: a&amp;b, &lt;c&gt;"
+
+	if want != card.Back {
+		t.Errorf("NewCard returned the back %q, want %q", card.Back, want)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-anki")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "cards.txt")
+	cards := []Card{
+		{Front: "run", Back: "to move\tswiftly\non foot"},
+		{Front: "Run", Back: "a duplicate"},
+	}
+
+	if err := Write(path, cards, false); nil != err {
+		t.Fatalf("Write returned error %q", err)
+	}
+
+	if contents, _ := ioutil.ReadFile(path); "run\tto move swiftly<br>on foot\n" != string(contents) {
+		t.Errorf("Write wrote %q", contents)
+	}
+}
+
+func TestWriteAppendingSkipsExistingWords(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-anki")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "cards.txt")
+
+	if err := Write(path, []Card{{Front: "fish &amp; chips", Back: "a meal"}, {Front: "run", Back: "to move"}}, false); nil != err {
+		t.Fatalf("Write returned error %q", err)
+	}
+
+	cards := []Card{
+		{Front: "Run", Back: "to move again"},
+		{Front: " fish &amp; chips ", Back: "a meal again"},
+		{Front: "walk", Back: "to move slowly"},
+	}
+
+	if err := Write(path, cards, true); nil != err {
+		t.Fatalf("Write returned error %q", err)
+	}
+
+	want := "fish &amp; chips\ta meal\nrun\tto move\nwalk\tto move slowly\n"
+
+	if contents, _ := ioutil.ReadFile(path); want != string(contents) {
+		t.Errorf("Write wrote %q, want %q", contents, want)
+	}
+
+	if err := Write(path, []Card{{Front: "walk", Back: "to move slowly"}}, false); nil != err {
+		t.Fatalf("Write returned error %q", err)
+	}
+
+	if contents, _ := ioutil.ReadFile(path); "walk\tto move slowly\n" != string(contents) {
+		t.Errorf("Write without appending wrote %q", contents)
+	}
+}
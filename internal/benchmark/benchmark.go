@@ -0,0 +1,176 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package benchmark provides a comparison of dictionary sources, by measuring
+// their lookups of a list of words
+package benchmark
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Rican7/define/source"
+)
+
+// Defaults of the benchmark options
+const (
+	DefaultConcurrency = 4
+	DefaultInterval    = 500 * time.Millisecond
+)
+
+// DefaultWords is the sample list of words looked up when none are given
+var DefaultWords = []string{"house", "run", "bright", "quickly", "serendipity"}
+
+// LookupFunc defines a function that looks up a word with a source
+type LookupFunc func(src source.Source, word string) (source.Result, error)
+
+// Options defines the options of a benchmark
+type Options struct {
+	// Concurrency is the maximum number of sources benchmarked at once
+	Concurrency int
+
+	// Interval is the minimum time between the start of each of a source's
+	// lookups, so as to respect the source's rate limits
+	Interval time.Duration
+}
+
+// Result defines the measurements of a source's lookups
+type Result struct {
+	Source    string
+	Lookups   int
+	Successes int
+
+	// Latency is the total time spent by all of the lookups
+	Latency time.Duration
+
+	// Senses and Synonyms are the total numbers returned by the successful
+	// lookups
+	Senses   int
+	Synonyms int
+}
+
+// AverageLatency returns the average time spent by each lookup
+func (r Result) AverageLatency() time.Duration {
+	if r.Lookups < 1 {
+		return 0
+	}
+
+	return r.Latency / time.Duration(r.Lookups)
+}
+
+// SuccessRate returns the fraction (from 0 to 1) of successful lookups
+func (r Result) SuccessRate() float64 {
+	if r.Lookups < 1 {
+		return 0
+	}
+
+	return float64(r.Successes) / float64(r.Lookups)
+}
+
+// AverageSenses returns the average number of senses of each successful lookup
+func (r Result) AverageSenses() float64 {
+	if r.Successes < 1 {
+		return 0
+	}
+
+	return float64(r.Senses) / float64(r.Successes)
+}
+
+// AverageSynonyms returns the average number of synonyms of each successful
+// lookup
+func (r Result) AverageSynonyms() float64 {
+	if r.Successes < 1 {
+		return 0
+	}
+
+	return float64(r.Synonyms) / float64(r.Successes)
+}
+
+// Run benchmarks the given sources by looking up each of the given words with
+// each source, and returns the results in the same order as the sources.
+//
+// Sources are benchmarked concurrently (up to the configured concurrency),
+// while each source's lookups are performed one at a time.
+func Run(sources []source.Source, words []string, lookup LookupFunc, opts Options) []Result {
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+
+	results := make([]Result, len(sources))
+	slots := make(chan struct{}, opts.Concurrency)
+
+	var wg sync.WaitGroup
+
+	for i, src := range sources {
+		wg.Add(1)
+
+		go func(i int, src source.Source) {
+			defer wg.Done()
+
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			results[i] = benchmarkSource(src, words, lookup, opts.Interval)
+		}(i, src)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// benchmarkSource looks up each of the given words with a source, waiting at
+// least the given interval between the start of each lookup
+func benchmarkSource(src source.Source, words []string, lookup LookupFunc, interval time.Duration) Result {
+	result := Result{Source: src.Name()}
+
+	for i, word := range words {
+		start := time.Now()
+
+		defined, err := lookup(src, word)
+
+		elapsed := time.Since(start)
+
+		result.Lookups++
+		result.Latency += elapsed
+
+		if nil == err {
+			err = source.ValidateResult(defined)
+		}
+
+		if nil == err {
+			result.Successes++
+			result.Senses += countSenses(defined)
+			result.Synonyms += countSynonyms(defined)
+		}
+
+		if i < len(words)-1 && elapsed < interval {
+			time.Sleep(interval - elapsed)
+		}
+	}
+
+	return result
+}
+
+// countSenses returns the number of senses of all of a result's entries
+func countSenses(result source.Result) int {
+	var count int
+
+	for _, entry := range result.Entries() {
+		count += len(entry.Senses())
+	}
+
+	return count
+}
+
+// countSynonyms returns the number of synonyms of all of a result's entries
+func countSynonyms(result source.Result) int {
+	var count int
+
+	for _, entry := range result.Entries() {
+		if thesaurusEntry, ok := entry.(source.ThesaurusEntry); ok {
+			count += len(thesaurusEntry.Synonyms())
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,107 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package benchmark
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Rican7/define/source"
+)
+
+type testSource struct {
+	name string
+	err  error
+}
+
+func (s testSource) Name() string {
+	return s.name
+}
+
+func (s testSource) Define(word string) (source.Result, error) {
+	if nil != s.err {
+		return nil, s.err
+	}
+
+	return source.ResultValue{
+		Head: word,
+		EntryVals: []interface{}{
+			source.EntryValue{
+				DictionaryEntryValue: source.DictionaryEntryValue{
+					SenseVals: []source.SenseValue{{DefinitionVals: []string{"a"}}, {DefinitionVals: []string{"b"}}},
+				},
+				ThesaurusEntryValue: source.ThesaurusEntryValue{SynonymVals: []string{"c"}},
+			},
+		},
+	}, nil
+}
+
+func define(src source.Source, word string) (source.Result, error) {
+	return src.Define(word)
+}
+
+func TestRun(t *testing.T) {
+	sources := []source.Source{testSource{name: "good"}, testSource{name: "bad", err: errors.New("failed")}}
+
+	results := Run(sources, []string{"one", "two"}, define, Options{Concurrency: 2})
+
+	if 2 != len(results) {
+		t.Fatalf("Run returned %d results, want 2", len(results))
+	}
+
+	good, bad := results[0], results[1]
+
+	if "good" != good.Source || 2 != good.Lookups || 1 != good.SuccessRate() {
+		t.Errorf("Run returned %+v for the good source", good)
+	}
+
+	if 2 != good.AverageSenses() || 1 != good.AverageSynonyms() {
+		t.Errorf("Run returned %v senses and %v synonyms on average, want 2 and 1", good.AverageSenses(), good.AverageSynonyms())
+	}
+
+	if "bad" != bad.Source || 2 != bad.Lookups || 0 != bad.SuccessRate() || 0 != bad.AverageSenses() {
+		t.Errorf("Run returned %+v for the bad source", bad)
+	}
+}
+
+func TestRunBoundsConcurrency(t *testing.T) {
+	var active, maxActive int32
+
+	lookup := func(src source.Source, word string) (source.Result, error) {
+		current := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+
+		for {
+			seen := atomic.LoadInt32(&maxActive)
+
+			if current <= seen || atomic.CompareAndSwapInt32(&maxActive, seen, current) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+
+		return src.Define(word)
+	}
+
+	sources := []source.Source{testSource{name: "a"}, testSource{name: "b"}, testSource{name: "c"}, testSource{name: "d"}}
+
+	Run(sources, []string{"word"}, lookup, Options{Concurrency: 2})
+
+	if got := atomic.LoadInt32(&maxActive); got > 2 {
+		t.Errorf("Run looked up with %d sources at once, want at most 2", got)
+	}
+}
+
+func TestRunInterval(t *testing.T) {
+	interval := 20 * time.Millisecond
+	start := time.Now()
+
+	Run([]source.Source{testSource{name: "a"}}, []string{"one", "two", "three"}, define, Options{Interval: interval})
+
+	if elapsed := time.Since(start); elapsed < 2*interval {
+		t.Errorf("Run finished 3 lookups in %s, want at least %s", elapsed, 2*interval)
+	}
+}
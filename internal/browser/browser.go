@@ -0,0 +1,46 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package browser provides a mechanism for opening URLs in the system's default
+// web browser.
+package browser
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// OpenError represents an error caused by a failure to open a URL.
+type OpenError struct {
+	URL string
+	Err error
+}
+
+// Open opens the given URL in the system's default web browser, via the
+// platform's opener command (open, start, or xdg-open).
+func Open(url string) error {
+	if err := openCommand(url).Run(); nil != err {
+		return &OpenError{URL: url, Err: err}
+	}
+
+	return nil
+}
+
+// openCommand returns an exec.Cmd that opens the given URL with the platform's
+// opener command
+func openCommand(url string) *exec.Cmd {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		// The shell treats ampersands as command separators, so escape them
+		return exec.Command("cmd", "/C", "start", "", strings.Replace(url, "&", "^&", -1))
+	default:
+		return exec.Command("xdg-open", url)
+	}
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("opening %q in a web browser failed with error: %s", e.URL, e.Err)
+}
@@ -0,0 +1,187 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Disk is an on-disk cache of values by their keys (such as from Key), each
+// stored as a file in a directory, that expire after a time to live
+type Disk struct {
+	// Dir is the directory that the values are stored in, created as needed
+	Dir string
+
+	// TTL is how long a value is cached for
+	TTL time.Duration
+
+	// MaxSize is the maximum total size of the cached values, in bytes, beyond
+	// which the least recently cached values are removed (0 for no limit)
+	MaxSize int64
+}
+
+// Get returns the value cached for the given key, and whether it was cached
+// (and hasn't expired)
+func (d Disk) Get(key string) ([]byte, bool) {
+	location := filepath.Join(d.Dir, key)
+	info, err := os.Stat(location)
+
+	if nil != err {
+		return nil, false
+	}
+
+	if d.TTL < time.Since(info.ModTime()) {
+		os.Remove(location)
+
+		return nil, false
+	}
+
+	value, err := ioutil.ReadFile(location)
+
+	if nil != err {
+		return nil, false
+	}
+
+	return value, true
+}
+
+// Set caches the given value for the given key, replacing any cached value,
+// and then removes the least recently cached values beyond the maximum size
+func (d Disk) Set(key string, value []byte) error {
+	if err := os.MkdirAll(d.Dir, 0700); nil != err {
+		return err
+	}
+
+	// Write to a temporary file first, so that a concurrent Get never reads a
+	// partially written value
+	file, err := ioutil.TempFile(d.Dir, "."+key)
+
+	if nil != err {
+		return err
+	}
+
+	_, err = file.Write(value)
+
+	if closeErr := file.Close(); nil == err {
+		err = closeErr
+	}
+
+	if nil == err {
+		err = os.Rename(file.Name(), filepath.Join(d.Dir, key))
+	}
+
+	if nil != err {
+		os.Remove(file.Name())
+
+		return err
+	}
+
+	return d.prune()
+}
+
+// DiskStats defines the statistics of the values of an on-disk cache
+type DiskStats struct {
+	// Count is the number of cached values, including the expired ones
+	Count int
+
+	// Expired is the number of the cached values that have expired
+	Expired int
+
+	// Size is the total size of the cached values, in bytes
+	Size int64
+}
+
+// Stats returns the statistics of the cached values. A cache directory that
+// doesn't exist yet has no values.
+func (d Disk) Stats() (DiskStats, error) {
+	var stats DiskStats
+
+	infos, err := d.values()
+
+	for _, info := range infos {
+		stats.Count++
+		stats.Size += info.Size()
+
+		if d.TTL < time.Since(info.ModTime()) {
+			stats.Expired++
+		}
+	}
+
+	return stats, err
+}
+
+// Clear removes every cached value, returning the number removed
+func (d Disk) Clear() (int, error) {
+	infos, err := d.values()
+
+	if nil != err {
+		return 0, err
+	}
+
+	removed := 0
+
+	for _, info := range infos {
+		if err = os.Remove(filepath.Join(d.Dir, info.Name())); nil != err && !os.IsNotExist(err) {
+			return removed, err
+		}
+
+		removed++
+	}
+
+	return removed, nil
+}
+
+// values returns the file infos of the cached values, skipping the temporary
+// files of values still being written
+func (d Disk) values() ([]os.FileInfo, error) {
+	infos, err := ioutil.ReadDir(d.Dir)
+
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if nil != err {
+		return nil, err
+	}
+
+	var values []os.FileInfo
+
+	for _, info := range infos {
+		if !info.IsDir() && '.' != info.Name()[0] {
+			values = append(values, info)
+		}
+	}
+
+	return values, nil
+}
+
+// prune removes the expired values, and the least recently cached values
+// beyond the maximum size
+func (d Disk) prune() error {
+	infos, err := d.values()
+
+	if nil != err {
+		return err
+	}
+
+	// Newest first, so that the oldest values are the ones beyond the limit
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ModTime().After(infos[j].ModTime())
+	})
+
+	var size int64
+
+	for _, info := range infos {
+		size += info.Size()
+
+		if d.TTL < time.Since(info.ModTime()) || (0 < d.MaxSize && d.MaxSize < size) {
+			os.Remove(filepath.Join(d.Dir, info.Name()))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDisk(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-cache")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	disk := Disk{Dir: filepath.Join(dir, "cache"), TTL: time.Hour, MaxSize: 8}
+
+	if _, ok := disk.Get("word"); ok {
+		t.Errorf("Get returned a value from an empty cache")
+	}
+
+	if err := disk.Set("word", []byte("1234")); nil != err {
+		t.Fatalf("Set returned error %q", err)
+	}
+
+	if value, ok := disk.Get("word"); !ok || "1234" != string(value) {
+		t.Errorf("Get returned %q, %t, want %q, %t", value, ok, "1234", true)
+	}
+
+	// Make the first value the least recently cached
+	past := time.Now().Add(-time.Minute)
+	os.Chtimes(filepath.Join(disk.Dir, "word"), past, past)
+
+	if err := disk.Set("other", []byte("12345")); nil != err {
+		t.Fatalf("Set returned error %q", err)
+	}
+
+	if _, ok := disk.Get("word"); ok {
+		t.Errorf("Get returned a value that's beyond the maximum size")
+	}
+
+	if _, ok := disk.Get("other"); !ok {
+		t.Errorf("Get didn't return the most recently cached value")
+	}
+
+	disk.TTL = time.Nanosecond
+	time.Sleep(time.Millisecond)
+
+	if _, ok := disk.Get("other"); ok {
+		t.Errorf("Get returned an expired value")
+	}
+}
+
+func TestDiskStatsAndClear(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-cache")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	disk := Disk{Dir: filepath.Join(dir, "cache"), TTL: time.Hour}
+
+	if stats, err := disk.Stats(); nil != err || (DiskStats{}) != stats {
+		t.Errorf("Stats of a missing directory returned %+v, %v, want empty stats", stats, err)
+	}
+
+	for _, key := range []string{"word", "other"} {
+		if err := disk.Set(key, []byte("1234")); nil != err {
+			t.Fatalf("Set returned error %q", err)
+		}
+	}
+
+	// Expire the first value
+	past := time.Now().Add(-2 * time.Hour)
+	os.Chtimes(filepath.Join(disk.Dir, "word"), past, past)
+
+	if stats, err := disk.Stats(); nil != err || (DiskStats{Count: 2, Expired: 1, Size: 8}) != stats {
+		t.Errorf("Stats returned %+v, %v, want 2 values, 1 expired, of 8 bytes", stats, err)
+	}
+
+	if removed, err := disk.Clear(); nil != err || 2 != removed {
+		t.Errorf("Clear returned %d, %v, want 2, nil", removed, err)
+	}
+
+	if _, ok := disk.Get("other"); ok {
+		t.Errorf("Get returned a value after the cache was cleared")
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package cache provides mechanisms for caching the results of definition
+// lookups.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// keyFieldSeparator is the separator used between the fields of a key before
+// hashing. It's a character that can't reasonably appear in any of the fields.
+const keyFieldSeparator = "\x00"
+
+// KeyOptions defines the options that affect a lookup's result, and therefore
+// must be incorporated into its cache key
+type KeyOptions struct {
+	Source   string
+	Language string
+	Region   string
+	Mode     string
+}
+
+// Key returns the canonical cache key for a lookup of the given word with the
+// given options.
+//
+// The options are normalized (trimmed and lowercased), so that options differing
+// only by case or surrounding whitespace share the same key. The word is only
+// trimmed, as its case may distinguish it (such as "Polish" and "polish"), and
+// is expected to already be normalized the way that it's looked up.
+func Key(word string, opts KeyOptions) string {
+	fields := []string{
+		normalize(opts.Source),
+		normalize(opts.Language),
+		normalize(opts.Region),
+		normalize(opts.Mode),
+		strings.TrimSpace(word),
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(fields, keyFieldSeparator)))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// normalize normalizes a key field
+func normalize(field string) string {
+	return strings.ToLower(strings.TrimSpace(field))
+}
@@ -0,0 +1,56 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package cache
+
+import (
+	"testing"
+)
+
+func TestKeyCollapsesCaseAndWhitespace(t *testing.T) {
+	opts := KeyOptions{Source: "Oxford", Language: "en", Region: "us"}
+	expected := Key("word", opts)
+
+	for _, word := range []string{" word", "word\n", "\tword "} {
+		if actual := Key(word, opts); expected != actual {
+			t.Errorf("Key(%q) = %q, expected %q", word, actual, expected)
+		}
+	}
+
+	if actual := Key("word", KeyOptions{Source: "oxford", Language: "EN", Region: "US"}); expected != actual {
+		t.Errorf("Key with differently cased options = %q, expected %q", actual, expected)
+	}
+}
+
+func TestKeyDistinguishesOptions(t *testing.T) {
+	combinations := []struct {
+		word string
+		opts KeyOptions
+	}{
+		{"word", KeyOptions{}},
+		{"words", KeyOptions{}},
+		{"Word", KeyOptions{}},
+		{"WORD", KeyOptions{}},
+		{"word", KeyOptions{Source: "Oxford"}},
+		{"word", KeyOptions{Source: "MerriamWebster"}},
+		{"word", KeyOptions{Source: "Oxford", Language: "en"}},
+		{"word", KeyOptions{Source: "Oxford", Language: "es"}},
+		{"word", KeyOptions{Source: "Oxford", Language: "en", Region: "us"}},
+		{"word", KeyOptions{Source: "Oxford", Language: "en", Region: "gb"}},
+		{"word", KeyOptions{Source: "Oxford", Region: "en"}},
+		{"word", KeyOptions{Source: "Oxford", Language: "en", Mode: "rich"}},
+		{"word", KeyOptions{Source: "Oxford", Mode: "en"}},
+		{"en", KeyOptions{Source: "Oxford", Region: "word"}},
+	}
+
+	seen := make(map[string]int)
+
+	for i, combination := range combinations {
+		key := Key(combination.word, combination.opts)
+
+		if j, ok := seen[key]; ok {
+			t.Errorf("combinations %d and %d produced the same key %q", j, i, key)
+		}
+
+		seen[key] = i
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package cache
+
+import "sync"
+
+// Memory is an in-memory cache of values by their keys, safe for concurrent
+// use. The zero value is an empty cache ready to use.
+type Memory struct {
+	mutex  sync.RWMutex
+	values map[string]interface{}
+}
+
+// Get returns the value cached for the given key, and whether it was cached
+func (m *Memory) Get(key string) (interface{}, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	value, ok := m.values[key]
+
+	return value, ok
+}
+
+// Set caches the given value for the given key, replacing any cached value
+func (m *Memory) Set(key string, value interface{}) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if nil == m.values {
+		m.values = make(map[string]interface{})
+	}
+
+	m.values[key] = value
+}
@@ -0,0 +1,20 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package cache
+
+import "testing"
+
+func TestMemory(t *testing.T) {
+	var memory Memory
+
+	if _, ok := memory.Get("word"); ok {
+		t.Errorf("Get returned a value from an empty cache")
+	}
+
+	memory.Set("word", 1)
+	memory.Set("word", 2)
+
+	if value, ok := memory.Get("word"); !ok || 2 != value {
+		t.Errorf("Get returned %v, %t, want %v, %t", value, ok, 2, true)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package clipboard provides mechanisms for reading and watching the text of
+// the system's clipboard.
+package clipboard
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+const (
+	// DefaultInterval is the default interval between reads of the clipboard
+	DefaultInterval = 250 * time.Millisecond
+
+	// DefaultDebounce is the default duration that new clipboard text must
+	// stay unchanged before it's handled
+	DefaultDebounce = 500 * time.Millisecond
+)
+
+// ErrUnavailable is returned when no command to read the clipboard is
+// available
+var ErrUnavailable = errors.New("no clipboard command is available (install wl-clipboard, xclip, or xsel)")
+
+// Reader reads the current text of a clipboard
+type Reader func() (string, error)
+
+// SystemReader returns a Reader of the system's clipboard, via the platform's
+// clipboard command, or ErrUnavailable if there isn't one.
+func SystemReader() (Reader, error) {
+	var candidates [][]string
+
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = [][]string{{"pbpaste"}}
+	case "windows":
+		candidates = [][]string{{"powershell", "-NoProfile", "-Command", "Get-Clipboard"}}
+	default:
+		if "" != os.Getenv("WAYLAND_DISPLAY") {
+			candidates = append(candidates, []string{"wl-paste", "--no-newline"})
+		}
+
+		candidates = append(candidates,
+			[]string{"xclip", "-selection", "clipboard", "-out"},
+			[]string{"xsel", "--clipboard", "--output"},
+		)
+	}
+
+	for _, candidate := range candidates {
+		path, err := exec.LookPath(candidate[0])
+
+		if nil != err {
+			continue
+		}
+
+		args := candidate[1:]
+
+		return func() (string, error) {
+			output, err := exec.Command(path, args...).Output()
+
+			return string(output), err
+		}, nil
+	}
+
+	return nil, ErrUnavailable
+}
+
+// Watch reads the clipboard with the given Reader at each interval, until the
+// context is done, and calls handle with each new text once it's stayed
+// unchanged for the debounce duration (so that a selection still being made
+// isn't handled). The clipboard's text when watching starts isn't handled.
+//
+// Watch returns nil when the context is done, or the first error of reading
+// the clipboard.
+func Watch(ctx context.Context, read Reader, interval time.Duration, debounce time.Duration, handle func(text string)) error {
+	handled, err := read()
+
+	if nil != err {
+		return err
+	}
+
+	pending := handled
+	pendingSince := time.Now()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		text, err := read()
+
+		if nil != err {
+			return err
+		}
+
+		if text != pending {
+			pending, pendingSince = text, time.Now()
+		}
+
+		if pending != handled && debounce <= time.Since(pendingSince) {
+			handled = pending
+			handle(pending)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package clipboard
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// sequenceReader returns a Reader that returns each of the given texts for the
+// given number of reads in turn, and then cancels the watch
+func sequenceReader(cancel context.CancelFunc, reads int, texts ...string) Reader {
+	count := 0
+
+	return func() (string, error) {
+		index := count / reads
+		count++
+
+		if index >= len(texts) {
+			cancel()
+
+			return texts[len(texts)-1], nil
+		}
+
+		return texts[index], nil
+	}
+}
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	read := sequenceReader(cancel, 3, "initial", "first", "first", "second")
+
+	var handled []string
+
+	err := Watch(ctx, read, time.Millisecond, 0, func(text string) {
+		handled = append(handled, text)
+	})
+
+	if nil != err {
+		t.Fatalf("Watch returned an error: %s", err)
+	}
+
+	if want := []string{"first", "second"}; !reflect.DeepEqual(want, handled) {
+		t.Errorf("Watch handled %q, want %q", handled, want)
+	}
+}
+
+func TestWatchDebounces(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	read := sequenceReader(cancel, 1, "initial", "fir", "first")
+
+	var handled []string
+
+	// Each text is only read once, so none of them are stable long enough
+	err := Watch(ctx, read, time.Millisecond, time.Hour, func(text string) {
+		handled = append(handled, text)
+	})
+
+	if nil != err {
+		t.Fatalf("Watch returned an error: %s", err)
+	}
+
+	if 0 != len(handled) {
+		t.Errorf("Watch handled %q, want nothing", handled)
+	}
+}
+
+func TestWatchReadError(t *testing.T) {
+	readErr := errors.New("read failed")
+
+	err := Watch(context.Background(), func() (string, error) { return "", readErr }, time.Millisecond, 0, func(string) {})
+
+	if readErr != err {
+		t.Errorf("Watch returned %v, want %v", err, readErr)
+	}
+}
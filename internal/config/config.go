@@ -5,12 +5,21 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
-	"strconv"
-
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/Rican7/define/internal/io/printer"
+	"github.com/Rican7/define/internal/keyring"
+	"github.com/Rican7/define/internal/logger"
+	"github.com/Rican7/define/internal/paths"
 	"github.com/Rican7/define/registry"
 	"github.com/fatih/structs"
 	homedir "github.com/mitchellh/go-homedir"
@@ -19,16 +28,128 @@ import (
 	"github.com/imdario/mergo"
 )
 
+const (
+	// fileLocationKey is the (comment) key of the loaded config files'
+	// locations in the marshalled configuration
+	fileLocationKey = commentKeyPrefix + " config file"
+
+	// noFileLocationDescription describes the config file's location when no
+	// config file was loaded
+	noFileLocationDescription = "none; using defaults + env + flags"
+
+	// stdinFileLocation describes the location of a config file read from
+	// stdin
+	stdinFileLocation = "(stdin)"
+)
+
+// List of output formats
+const (
+	OutputFormatText      = "text"
+	OutputFormatPorcelain = "porcelain"
+	OutputFormatJSON      = "json"
+)
+
+// deprecatedFields maps the fields of the deprecated options, which are aliases
+// of other options (see resolveDeprecatedOptions), to the fields of the options
+// that replace them. They're still read, but aren't written to example config
+// files.
+var deprecatedFields = map[string]string{
+	"NoExamples": "ShowExamples",
+}
+
+// stdin is the reader that a config file is read from, with the config stdin
+// flag
+var stdin io.Reader = os.Stdin
+
 // Configuration defines the application's configuration structure
 type Configuration struct {
-	IndentationSize uint
-	PreferredSource string
-	Source          string
+	IndentationSize     uint
+	PreferredSource     string
+	Source              string
+	ThesaurusSource     string
+	NoPrompt            bool
+	NoEmbedded          bool
+	RetryEmpty          bool
+	ExpandAbbreviations bool
+	NoExamples          bool
+	ShowExamples        Toggle
+	MaxExamplesPerSense uint
+	HideThesaurus       Toggle
+	ShowForms           bool
+	OutputFormat        string
+	Color               Toggle
+	ThemeName           string
+	Theme               printer.Theme
+	HeadwordCase        string
+	Bullet              string
+	Normalization       string
+	Translate           string
+	Related             bool
+	MinSynonyms         uint
+	LimitPerPOS         uint
+	MaxSenses           uint
+	Timeout             Duration
+	PerSourceTimeout    Duration
+	CACertFile          string
+	Insecure            bool
+	PostProcess         string
+	HistoryEnabled      bool
+	HistoryFile         string
+	StarredFile         string
+	CredentialsFile     string
+	CacheDir            string
+	CacheTTL            string
+	CacheMaxSizeMB      uint
+	ExecSources         []ExecSource
+	Profiles            map[string]json.RawMessage
 
 	// Private fields that shouldn't be externally set or output
 	providerConfigs    map[string]registry.Configuration
 	configFileLocation string
+	configFiles        []string
+	keyringSecrets     map[string]string
+	credentials        map[string]string
+	credentialsFile    string
+	credentialsMode    os.FileMode
+	configStdin        bool
+	stdinContents      []byte
+	configFileFormat   string
+	fileFormat         fileFormat
+	legacyConfigFile   bool
+	configFileEnv      bool
+	profile            string
+	targetFileLocation string
+	defaults           *Configuration
+	provided           map[string]bool
 	noConfigFile       bool
+	porcelain          bool
+	noTrailingNewline  bool
+	wordsFile          string
+	quiet              bool
+	allSources         bool
+	ordered            bool
+	firstMatch         bool
+	allowSymbols       bool
+	debug              bool
+	limit              uint
+}
+
+// ExecSource defines the configuration of a source provided by an external
+// command, which is passed a word and prints its JSON result to stdout
+type ExecSource struct {
+	// Name is the name of the source, also used as its key (such as with the
+	// Source and PreferredSource options)
+	Name string
+
+	// Command is the command to run, by its path or its name in the PATH
+	Command string
+
+	// Args are the arguments passed to the command, before the word
+	Args []string
+
+	// Stdin is whether the word is written to the command's stdin, rather
+	// than passed as its last argument
+	Stdin bool
 }
 
 // initializeCommandLineConfig initializes the command line configuration.
@@ -37,50 +158,229 @@ func initializeCommandLineConfig(flags *flag.FlagSet) *Configuration {
 
 	// Define our flags
 	flags.StringVarP(&conf.configFileLocation, "config-file", "c", "", "The location of the config file to use")
+	flags.BoolVar(&conf.configStdin, "config-stdin", false, "To read the config file from stdin (rather than reading any words from stdin)")
+	flags.StringVar(&conf.configFileFormat, "config-file-format", "", "The format of the config file (\"json\", \"toml\", or \"yaml\"), if it can't be detected from its extension")
 	flags.BoolVar(&conf.noConfigFile, "no-config-file", false, "To not load any config file")
+	flags.StringVar(&conf.profile, "profile", "", "The name of the config file's profile to overlay on top of its other values")
+	flags.StringVar(&conf.wordsFile, "words-file", "", "The location of a file of words to use, one per line (\"-\" for stdin)")
+	flags.BoolVarP(&conf.quiet, "quiet", "q", false, "To not print any progress information")
+	flags.BoolVar(&conf.allSources, "all-sources", false, "To define the word with every available source, printing each result as it arrives")
+	flags.BoolVar(&conf.ordered, "ordered", false, "To print the results of all sources in their order of priority (such as with --all-sources)")
+	flags.BoolVar(&conf.firstMatch, "first-match", false, "To stop querying sources as soon as one defines the word (with --all-sources)")
+	flags.BoolVar(&conf.allowSymbols, "allow-symbols", false, "To look up words without any letters (such as numbers or symbols), rather than rejecting them")
+	flags.BoolVar(&conf.debug, "debug", false, "To log debugging information about the app's behavior to stderr")
+	flags.UintVar(&conf.limit, "limit", 0, "The maximum number of senses to show in total (0 for no limit; overrides MaxSenses)")
+	flags.UintVar(&conf.LimitPerPOS, "limit-per-pos", 0, "The maximum number of senses to show for each part of speech (0 for no limit)")
+	flags.BoolVar(&conf.porcelain, "porcelain", false, "To print results in a stable, tab-separated format for scripts (deprecated: use --output-format=porcelain)")
+	flags.BoolVar(&conf.noTrailingNewline, "no-trailing-newline", false, "To not end the output with a line break (such as for pipelines that compare output exactly)")
 	flags.UintVar(&conf.IndentationSize, "indent-size", 0, "The number of spaces to indent output by")
 	flags.StringVar(&conf.PreferredSource, "preferred-source", "", "The preferred source to use, if available and able to be provided")
 	flags.StringVarP(&conf.Source, "source", "s", "", "The source to use (will error if unavailable or unable to be provided)")
+	flags.StringVar(&conf.ThesaurusSource, "thesaurus-source", "", "A source to add the synonyms and antonyms of, to those of the defining source's results")
+	flags.StringVar(&conf.PostProcess, "post-process", "", "A command to pipe the JSON result through, printing the command's output instead")
+	flags.BoolVar(&conf.HistoryEnabled, "history-enabled", false, "To record each successfully defined word in the lookup history")
+	flags.StringVar(&conf.HistoryFile, "history-file", "", "The location of the lookup history file")
+	flags.StringVar(&conf.StarredFile, "starred-file", "", "The location of the starred words file")
+	flags.StringVar(&conf.CredentialsFile, "credentials-file", "", "The location of a file of source credentials, merged into the config file's source sections")
+	flags.StringVar(&conf.CacheDir, "cache-dir", "", "The directory to cache source results in (relative to the home directory, if not absolute)")
+	flags.StringVar(&conf.CacheTTL, "cache-ttl", "", "How long to cache source results for (such as \"24h\", or \"0s\" to not cache them)")
+	flags.UintVar(&conf.CacheMaxSizeMB, "cache-max-size-mb", 0, "The maximum size of the cache, in megabytes (0 for no limit)")
+	flags.Var(&conf.Timeout, "timeout", "The overall time limit of the lookups, including any fallbacks (such as \"30s\", or \"0s\" for none)")
+	flags.Var(&conf.PerSourceTimeout, "timeout-per-source", "The time limit of each individual source lookup (such as \"10s\")")
+	flags.StringVar(&conf.CACertFile, "ca-cert", "", "The location of a PEM encoded bundle of CA certificates to trust, such as for a TLS-intercepting proxy")
+	flags.BoolVar(&conf.Insecure, "insecure", false, "To skip verifying the TLS certificates of sources (discouraged; prefer --ca-cert)")
+	flags.StringVar(&conf.HeadwordCase, "headword-case", "", "The capitalization to display headwords in (\"source\", \"lower\", \"upper\", or \"title\")")
+	flags.StringVar(&conf.Bullet, "bullet", "", "The marker to print before each sense, instead of its number (such as \"•\" or \"-\", or empty for no marker)")
+	flags.StringVar(&conf.Normalization, "normalization", "", "How to normalize the capitalization of words before looking them up (\"none\", \"lower\", or \"smart\")")
+	flags.StringVar(&conf.Translate, "translate", "", "The language code (ISO 639-1) to also translate defined words into (such as \"fr\")")
+	flags.BoolVar(&conf.Related, "related", false, "To also print the words commonly used with defined words (provided by the Datamuse API)")
+	flags.UintVar(&conf.MinSynonyms, "min-synonyms", 0, "The minimum number of synonyms needed to show the synonyms section (0 to always show it)")
+	flags.BoolVar(&conf.RetryEmpty, "retry-empty", false, "To retry the other sources, in turn, when the selected source doesn't find a word")
+	flags.BoolVar(&conf.ExpandAbbreviations, "expand", false, "To look up abbreviations and acronyms (such as \"etc.\" or \"NASA\") with the Wiktionary source first, for their expansions")
+	flags.BoolVar(&conf.NoEmbedded, "no-embedded", false, "To not fall back to the dictionary embedded in the app when the sources fail to define a word")
+	flags.BoolVar(&conf.NoExamples, "no-examples", false, "To not print the examples of senses (deprecated: use --show-examples=false)")
+	flags.Var(&conf.ShowExamples, "show-examples", "Whether to print the examples of senses (such as \"--show-examples=false\")")
+	flags.Var(&conf.HideThesaurus, "hide-thesaurus", "Whether to hide the synonyms and antonyms sections")
+	flags.BoolVar(&conf.ShowForms, "forms", false, "To print the inflected forms of words (such as plurals and past tenses), when the source provides them")
+	flags.StringVar(&conf.OutputFormat, "output-format", "", "The format to print results in (\"text\", \"porcelain\", or \"json\")")
+	flags.StringVar(&conf.ThemeName, "theme", "", "The name of the built-in color theme to print results with (\"default\" or \"mono\")")
+	flags.Var(&conf.Color, "color", "Whether to print results in color (defaults to when printing to a terminal, unless NO_COLOR is set)")
+	flags.UintVar(&conf.MaxExamplesPerSense, "max-examples-per-sense", 0, "The maximum number of examples to print for each sense (0 for no limit)")
+	flags.BoolVar(&conf.NoPrompt, "no-prompt", false, "To never interactively prompt, such as when suggesting alternative words")
 
 	return &conf
 }
 
 // initializeFileConfig initializes the file configuration by loading the
-// configuration from a file at the given location.
-func initializeFileConfig(fileLocation string) (Configuration, error) {
+// configuration from a file at the given location, in the given format (or
+// else its detected format).
+func initializeFileConfig(fileLocation string, format fileFormat, profile string) (Configuration, error) {
+	fileContents, err := ioutil.ReadFile(paths.Expand(fileLocation))
+
+	if nil != err {
+		return Configuration{}, err
+	}
+
+	return parseFileConfig(fileLocation, format, fileContents, profile)
+}
+
+// parseFileConfig parses the contents of a config file, from the given
+// location, in the given format (or else its detected format), with the
+// values of the named profile (if any, and if the file has it) overlaid on
+// top of its other values.
+func parseFileConfig(fileLocation string, format fileFormat, fileContents []byte, profile string) (Configuration, error) {
 	var conf Configuration
+	var err error
 
-	fileContents, err := ioutil.ReadFile(tryExpandPath(fileLocation))
+	if fileContents, err = decodeFileContents(format, fileLocation, fileContents); nil != err {
+		return conf, err
+	}
 
-	if nil != err {
+	if fileContents, err = migrateKeys(fileContents); nil != err {
+		return conf, err
+	}
+
+	if fileContents, conf.keyringSecrets, err = resolveKeyringReferences(fileContents); nil != err {
+		return conf, err
+	}
+
+	// Values that were redacted when output are left to the credentials file
+	if fileContents, err = removeCredentialsPlaceholders(fileContents); nil != err {
+		return conf, err
+	}
+
+	var hasProfile bool
+
+	if fileContents, hasProfile, err = applyProfile(fileContents, profile); nil != err {
 		return conf, err
 	}
 
 	if len(fileContents) > 0 {
 		err = json.Unmarshal(fileContents, &conf)
+		conf.provided = providedFileFields(fileContents)
+	}
+
+	if hasProfile {
+		conf.profile = profile
 	}
 
 	return conf, err
 }
 
+// providedFileFields returns the names of the configuration fields that are
+// set (to anything other than null) in a config file's (JSON) contents
+func providedFileFields(fileContents []byte) map[string]bool {
+	var configMap map[string]json.RawMessage
+
+	provided := make(map[string]bool)
+
+	if nil != json.Unmarshal(fileContents, &configMap) {
+		return provided
+	}
+
+	configType := reflect.TypeOf(Configuration{})
+
+	for key, value := range configMap {
+		if "null" == string(bytes.TrimSpace(value)) {
+			continue
+		}
+
+		// Keys are matched to fields case-insensitively, as when unmarshalled
+		for i := 0; i < configType.NumField(); i++ {
+			if field := configType.Field(i); "" == field.PkgPath && strings.EqualFold(key, field.Name) {
+				provided[field.Name] = true
+			}
+		}
+	}
+
+	return provided
+}
+
+// providedFlagFields returns the names of the command line configuration
+// fields that were set by the passed flags
+func providedFlagFields(flags *flag.FlagSet, conf *Configuration) map[string]bool {
+	provided := make(map[string]bool)
+	confValue := reflect.ValueOf(conf).Elem()
+
+	flags.Visit(func(visited *flag.Flag) {
+		// Flags are bound to fields by their addresses
+		address := reflect.ValueOf(visited.Value).Pointer()
+
+		for i := 0; i < confValue.NumField(); i++ {
+			if field := confValue.Type().Field(i); "" == field.PkgPath && confValue.Field(i).UnsafeAddr() == address {
+				provided[field.Name] = true
+			}
+		}
+	})
+
+	return provided
+}
+
 // initializeEnvironmentConfig initializes the environment configuration from
 // the application's environment.
 func initializeEnvironmentConfig() Configuration {
 	var conf Configuration
 
-	if val, err := strconv.ParseUint(os.Getenv("DEFINE_APP_INDENT_SIZE"), 10, 0); nil == err {
-		conf.IndentationSize = uint(val)
-	}
+	conf.provided = make(map[string]bool)
+	confValue := reflect.ValueOf(&conf).Elem()
+
+	for _, envVar := range envVars {
+		field := confValue.FieldByName(envVar.Key)
 
-	conf.PreferredSource = os.Getenv("DEFINE_APP_PREFERRED_SOURCE")
-	conf.Source = os.Getenv("DEFINE_APP_SOURCE")
+		// Invalid values are ignored, just as unset values are
+		if value, _, isSet := envVar.Lookup(); isSet && field.CanSet() {
+			conf.provided[envVar.Key] = nil == parseEnvValue(value, field)
+		}
+	}
 
 	return conf
 }
 
+// initializeProviderEnvironmentConfigs fills in the values of the source
+// provider configurations that are still empty from the environment
+// variables that their providers declare.
+func initializeProviderEnvironmentConfigs(providerConfigs map[string]registry.Configuration) {
+	for providerKey, providerConfig := range providerConfigs {
+		confValue := reflect.Indirect(reflect.ValueOf(providerConfig))
+
+		for _, envVar := range registry.ProviderMetadata(providerConfig).EnvVars {
+			field := confValue.FieldByName(envVar.Key)
+			value, name, isSet := envVar.Lookup()
+
+			// Values set by a flag or a config file take priority
+			if !isSet || !field.CanSet() || !reflect.DeepEqual(field.Interface(), reflect.Zero(field.Type()).Interface()) {
+				continue
+			}
+
+			// Invalid values are ignored, just as unset values are
+			if err := parseEnvValue(value, field); nil == err {
+				logger.Debugf("config: using %s%s%s from the environment variable %s", providerKey, keyPathSeparator, envVar.Key, name)
+			}
+		}
+	}
+}
+
+// expandProviderPaths expands the values of the path keys of the provider
+// configurations (see paths.Expand)
+func expandProviderPaths(providerConfigs map[string]registry.Configuration) {
+	for _, providerConfig := range providerConfigs {
+		confValue := reflect.Indirect(reflect.ValueOf(providerConfig))
+
+		for _, key := range registry.ProviderMetadata(providerConfig).PathKeys {
+			if field := confValue.FieldByName(key); field.CanSet() && reflect.String == field.Kind() {
+				field.SetString(paths.Expand(field.String()))
+			}
+		}
+	}
+}
+
 // mergeConfigurations merges multiple configurations values together, from left
 // to right argument position, by filling any of the left arguments zero-values
 // with any non-zero-values from the right.
+//
+// Zero values are treated as unset, unless they're numbers or booleans that a
+// configuration explicitly provided (such as an indentation size of 0 set in
+// a config file), in which case they're merged just as non-zero values are.
 func mergeConfigurations(confs ...Configuration) (Configuration, error) {
 	var merged Configuration
 
@@ -90,14 +390,106 @@ func mergeConfigurations(confs ...Configuration) (Configuration, error) {
 		}
 	}
 
+	merged.provided = make(map[string]bool)
+	mergedValue := reflect.ValueOf(&merged).Elem()
+
+	for _, conf := range confs {
+		confValue := reflect.ValueOf(conf)
+
+		for name, provided := range conf.provided {
+			// Only the first (highest priority) configuration to provide a
+			// field decides its value
+			if !provided || merged.provided[name] {
+				continue
+			}
+
+			merged.provided[name] = true
+
+			if field := confValue.FieldByName(name); isExplicitZero(name, field) {
+				mergedValue.FieldByName(name).Set(field)
+			}
+		}
+	}
+
 	return merged, nil
 }
 
-// tryExpandPath attempts to expand a given path and returns the expanded path
-// if successful. Otherwise, if expansion failed, the original path is returned.
-func tryExpandPath(path string) string {
-	if expanded, err := homedir.Expand(path); nil == err {
-		path = expanded
+// resolveDeprecatedOptions maps the deprecated options of a configuration from
+// a single origin (such as the command line, or a config file) onto the
+// options that replace them, unless the replacements are also set, which then
+// win. The replacements then take part in merging, so that an origin of a
+// higher priority overrides a lower one however either sets the option.
+func (c *Configuration) resolveDeprecatedOptions() {
+	if c.NoExamples && !c.ShowExamples.IsSet() {
+		c.ShowExamples = ToggleOff
+	}
+
+	c.NoExamples = false
+
+	if c.porcelain && "" == c.OutputFormat {
+		c.OutputFormat = OutputFormatPorcelain
+	}
+
+	c.porcelain = false
+}
+
+// meaningfulEmptyFields are the names of the string fields whose empty value
+// is meaningful (rather than unset) when it's explicitly provided
+var meaningfulEmptyFields = map[string]bool{
+	"Bullet": true,
+}
+
+// isExplicitZero returns whether a provided value of the named field is a zero
+// value that's meaningful (rather than unset), being a number or boolean, or
+// a string that's meaningful when empty
+func isExplicitZero(name string, value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String:
+		return meaningfulEmptyFields[name] && "" == value.String()
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return reflect.DeepEqual(value.Interface(), reflect.Zero(value.Type()).Interface())
+	default:
+		return false
+	}
+}
+
+// logMergeDecisions logs which of the named configurations (in merging
+// priority order) each configuration value was merged from
+func logMergeDecisions(names []string, confs []Configuration) {
+	configType := reflect.TypeOf(Configuration{})
+
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+
+		if "" != field.PkgPath {
+			continue
+		}
+
+		for j, conf := range confs {
+			value := reflect.ValueOf(conf).Field(i)
+
+			if (isExplicitZero(field.Name, value) && conf.provided[field.Name]) || !reflect.DeepEqual(value.Interface(), reflect.Zero(field.Type).Interface()) {
+				logger.Debugf("config: using %s from the %s: %v", field.Name, names[j], value.Interface())
+				break
+			}
+		}
+	}
+}
+
+// expandHomeRelativePath expands a given path (see paths.Expand), and then
+// resolves it against the user's home directory if it's still relative
+func expandHomeRelativePath(path string) string {
+	path = paths.Expand(path)
+
+	if "" == path || filepath.IsAbs(path) {
+		return path
+	}
+
+	if home, err := homedir.Dir(); nil == err {
+		path = filepath.Join(home, path)
 	}
 
 	return path
@@ -106,17 +498,31 @@ func tryExpandPath(path string) string {
 // NewFromRuntime builds a Configuration by merging values from multiple
 // different sources. It accepts a Configuration containing default values to
 // fill in any empty/blank configuration values found when merging from the
-// different sources.
+// different sources. The given command line arguments (excluding the program
+// name) are parsed by the given flag set.
+//
+// The config file is the one given by the config file flag, or else by the
+// config file environment variable, or else the first of the given default
+// locations that exists. When the config stdin flag is passed, the config file
+// is instead read from stdin. A system-wide config file, if given and
+// existing, is merged under it.
 //
 // The merging of values from different sources will take this priority:
 // 1. Command line arguments
 // 2. A loaded config file, if available
-// 3. Environment variables
-// 4. Passed in default values
+// 3. A loaded system-wide config file, if available
+// 4. Environment variables
+// 5. Passed in default values
+//
+// The credentials file is then loaded into the source provider configurations,
+// followed by the environment variables that the providers declare, each
+// filling in any of their values that are still empty.
 func NewFromRuntime(
 	flags *flag.FlagSet,
+	arguments []string,
 	providerConfigs map[string]registry.Configuration,
-	defaultConfigFileLocation string,
+	defaultConfigFileLocations []string,
+	systemConfigFileLocation string,
 	defaults Configuration,
 ) (Configuration, error) {
 
@@ -124,47 +530,246 @@ func NewFromRuntime(
 	var err error
 
 	var fileConfig Configuration
+	var configFileLocation string
+	var explicitLocation string
+	var configFiles []string
+	var stdinContents []byte
 
-	// Set our config file location
-	defaults.configFileLocation = tryExpandPath(defaultConfigFileLocation)
+	keyringSecrets := make(map[string]string)
+	profileNames := make(map[string]bool)
+
+	// Set our config file location to the first (most preferred) default
+	if 0 < len(defaultConfigFileLocations) {
+		defaults.configFileLocation = paths.Expand(defaultConfigFileLocations[0])
+	}
 
 	commandLineConfig := initializeCommandLineConfig(flags)
 
 	// Parse our flag set, as we need the values from the commandLineConfig
-	err = flags.Parse(os.Args[1:])
+	err = flags.Parse(arguments)
+
+	if debugEnv, envErr := ParseBool(os.Getenv(debugEnvName)); nil == envErr && debugEnv {
+		commandLineConfig.debug = true
+	}
+
+	// Enable debug logging as early as possible, to log the rest of the setup
+	if commandLineConfig.debug {
+		logger.SetDefault(logger.New(os.Stderr, logger.LevelDebug))
+	}
+
+	if commandLineConfig.noConfigFile {
+		logger.Debugf("config: not loading any config file")
+	}
+
+	commandLineConfig.provided = providedFlagFields(flags, commandLineConfig)
+
+	// The limit flag overrides the MaxSenses option
+	flags.Visit(func(visited *flag.Flag) {
+		if "limit" == visited.Name {
+			commandLineConfig.MaxSenses = commandLineConfig.limit
+			commandLineConfig.provided["MaxSenses"] = true
+		}
+	})
+
+	commandLineConfig.resolveDeprecatedOptions()
+
+	if nil == err {
+		defaults.fileFormat, err = parseFileFormat(commandLineConfig.configFileFormat)
+	}
+
+	explicitLocation = commandLineConfig.configFileLocation
+
+	if "" == explicitLocation {
+		explicitLocation = os.Getenv(configFileEnvName)
+		defaults.configFileEnv = "" != explicitLocation
+	}
+
+	profile := commandLineConfig.profile
+
+	if "" == profile {
+		profile = os.Getenv(profileEnvName)
+	}
+
+	if nil == err && commandLineConfig.configStdin {
+		logger.Debugf("config: reading the config file from stdin")
+
+		configFileLocation = stdinFileLocation
+
+		if stdinContents, err = ioutil.ReadAll(stdin); nil != err {
+			err = fmt.Errorf("error reading config file from stdin with error: %s", err)
+		}
+	}
 
 	if nil == err && !commandLineConfig.noConfigFile {
-		configFileLocation := tryExpandPath(commandLineConfig.configFileLocation)
+		if "" == configFileLocation {
+			configFileLocation = paths.Expand(explicitLocation)
+		}
 
-		if "" == configFileLocation && "" != defaults.configFileLocation {
-			// If we haven't passed a config file flag, and our default exists
-			if _, err := os.Stat(defaults.configFileLocation); !os.IsNotExist(err) {
-				// Set our location to the default, since it exists
-				// (if there are problems reading the file, we'll handle later)
-				configFileLocation = defaults.configFileLocation
+		if "" == configFileLocation {
+			// If we haven't passed a config file flag or environment
+			// variable, use the first of our defaults that exists
+			for i, defaultLocation := range defaultConfigFileLocations {
+				defaultLocation = paths.Expand(defaultLocation)
+
+				if _, err := os.Stat(defaultLocation); !os.IsNotExist(err) {
+					// Set our location to the default, since it exists
+					// (if there are problems reading the file, we'll handle later)
+					configFileLocation = defaultLocation
+					defaults.legacyConfigFile = 0 < i
+					break
+				}
 			}
 		}
 
-		// If we have a config file to load
-		if "" != configFileLocation {
-			fileConfig, err = initializeFileConfig(configFileLocation)
+		if "" == configFileLocation {
+			logger.Debugf("config: no config file exists at the default location %q", defaults.configFileLocation)
+		}
+
+		// Load the system-wide config file first, so that the provider
+		// configurations of the user's config file are unmarshalled over it
+		if _, statErr := os.Stat(systemConfigFileLocation); "" != systemConfigFileLocation && nil == statErr {
+			configFiles = append(configFiles, systemConfigFileLocation)
+		}
+	}
+
+	if nil == err && "" != configFileLocation {
+		configFiles = append(configFiles, configFileLocation)
+	}
+
+	if nil == err {
+		for _, location := range configFiles {
+			logger.Debugf("config: loading config file %q", location)
+
+			// A passed format only applies to the user's config file
+			format := autoFormat
+
+			if location == configFileLocation {
+				format = defaults.fileFormat
+			}
+
+			var loadedConfig Configuration
+			var loadErr error
+
+			if stdinFileLocation == location {
+				loadedConfig, loadErr = parseFileConfig(location, format, stdinContents, profile)
+			} else {
+				loadedConfig, loadErr = initializeFileConfig(location, format, profile)
+			}
+
+			loadedConfig.resolveDeprecatedOptions()
+
+			// Don't leave a mistyped location in the environment to be
+			// mistaken for a problem with the file itself
+			if os.IsNotExist(loadErr) && defaults.configFileEnv && location == configFileLocation {
+				err = missingEnvConfigFileError(location)
+				break
+			}
+
+			if nil != loadErr {
+				err = fmt.Errorf("error reading config file %q with error: %s", location, loadErr)
+				break
+			}
+
+			if fileConfig, err = mergeConfigurations(loadedConfig, fileConfig); nil != err {
+				break
+			}
+
+			for keyPath, secret := range loadedConfig.keyringSecrets {
+				keyringSecrets[keyPath] = secret
+			}
 
-			if nil != err {
-				err = fmt.Errorf("error reading config file %q with error: %s", configFileLocation, err)
+			for name := range loadedConfig.Profiles {
+				profileNames[name] = true
 			}
 		}
 	}
 
+	// A profile must be in at least one of the config files
+	if nil == err && "" != profile && !profileNames[profile] {
+		err = unknownProfileError(profile, profileNames)
+	}
+
 	if nil == err {
+		environmentConfig := initializeEnvironmentConfig()
+		environmentConfig.resolveDeprecatedOptions()
+
 		conf, err = mergeConfigurations(
 			*commandLineConfig,
 			fileConfig,
-			initializeEnvironmentConfig(),
+			environmentConfig,
 			defaults,
 		)
+
+		if logger.Enabled(logger.LevelDebug) {
+			logMergeDecisions(
+				[]string{"command line", "config file", "environment", "defaults"},
+				[]Configuration{*commandLineConfig, fileConfig, environmentConfig, defaults},
+			)
+		}
+	}
+
+	conf.CredentialsFile = paths.Expand(conf.CredentialsFile)
+
+	if nil == err && !commandLineConfig.noConfigFile && "" != conf.CredentialsFile {
+		logger.Debugf("config: loading credentials file %q", conf.CredentialsFile)
+
+		credentials, mode, loadErr := initializeCredentialsConfig(conf.CredentialsFile)
+
+		if nil != loadErr {
+			err = fmt.Errorf("error reading credentials file %q with error: %s", conf.CredentialsFile, loadErr)
+		} else if nil != credentials {
+			conf.credentials = credentials
+			conf.credentialsFile = conf.CredentialsFile
+			conf.credentialsMode = mode
+		}
+	}
+
+	// The provider configurations were already set by any flags and config
+	// files, so the environment only fills in what's still empty
+	if nil == err {
+		initializeProviderEnvironmentConfigs(providerConfigs)
+		expandProviderPaths(providerConfigs)
 	}
 
 	conf.providerConfigs = providerConfigs
+	conf.configFileLocation = configFileLocation
+	conf.configFiles = configFiles
+	conf.keyringSecrets = keyringSecrets
+	conf.configStdin = commandLineConfig.configStdin
+	conf.stdinContents = stdinContents
+	conf.targetFileLocation = paths.Expand(explicitLocation)
+	conf.defaults = &defaults
+	conf.noTrailingNewline = commandLineConfig.noTrailingNewline
+	conf.wordsFile = commandLineConfig.wordsFile
+	conf.quiet = commandLineConfig.quiet
+	conf.allSources = commandLineConfig.allSources
+	conf.ordered = commandLineConfig.ordered
+	conf.firstMatch = commandLineConfig.firstMatch
+	conf.allowSymbols = commandLineConfig.allowSymbols
+	conf.debug = commandLineConfig.debug
+	conf.limit = commandLineConfig.limit
+
+	conf.legacyConfigFile = defaults.legacyConfigFile
+	conf.profile = profile
+	conf.configFileEnv = defaults.configFileEnv && !commandLineConfig.configStdin && !commandLineConfig.noConfigFile
+	conf.fileFormat = defaults.fileFormat
+
+	// Write to the default config file that was loaded, if any, so that it
+	// isn't shadowed by a new file at a more preferred location
+	if "" == conf.targetFileLocation && "" != configFileLocation && !conf.configStdin {
+		conf.targetFileLocation = configFileLocation
+	} else if "" == conf.targetFileLocation {
+		conf.targetFileLocation = defaults.configFileLocation
+	}
+
+	conf.HistoryFile = paths.Expand(conf.HistoryFile)
+	conf.StarredFile = paths.Expand(conf.StarredFile)
+	conf.CACertFile = paths.Expand(conf.CACertFile)
+	conf.CacheDir = expandHomeRelativePath(conf.CacheDir)
+
+	for i := range conf.ExecSources {
+		conf.ExecSources[i].Command = paths.Expand(conf.ExecSources[i].Command)
+	}
 
 	return conf, err
 }
@@ -180,10 +785,152 @@ func (c Configuration) ProviderConfigs() []registry.Configuration {
 	return list
 }
 
+// FileLocation returns the location of the config file that was loaded, or an
+// empty string if no config file was loaded.
+func (c Configuration) FileLocation() string {
+	return c.configFileLocation
+}
+
+// CacheExpiry returns how long source results are cached for, as given by
+// CacheTTL, or 0 if they aren't cached (including if CacheTTL is invalid).
+func (c Configuration) CacheExpiry() time.Duration {
+	expiry, err := time.ParseDuration(c.CacheTTL)
+
+	if nil != err || expiry < 0 {
+		return 0
+	}
+
+	return expiry
+}
+
+// ConfigStdin returns whether the config file was read from stdin, in which
+// case stdin can't also be read for words.
+func (c Configuration) ConfigStdin() bool {
+	return c.configStdin
+}
+
+// ConfigFiles returns the locations of the config files that were loaded, in
+// the order that they were merged (each overriding the previous), such as a
+// system-wide config file followed by the user's config file.
+func (c Configuration) ConfigFiles() []string {
+	return c.configFiles
+}
+
+// ConfigFileFromEnv returns whether the location of the config file that was
+// loaded was given by the DEFINE_APP_CONFIG_FILE environment variable.
+func (c Configuration) ConfigFileFromEnv() bool {
+	return c.configFileEnv
+}
+
+// LegacyConfigFile returns whether the config file that was loaded is at a
+// default location other than the most preferred one, such as a legacy
+// location kept for backwards compatibility.
+func (c Configuration) LegacyConfigFile() bool {
+	return c.legacyConfigFile
+}
+
+// TargetFileLocation returns the location that config file changes should be
+// written to: the location given by the config file flag, if any, otherwise
+// the default location.
+func (c Configuration) TargetFileLocation() string {
+	return c.targetFileLocation
+}
+
+// SenseBullet returns the marker to print before each sense, and whether one
+// was set at all (as an empty marker prints none, rather than numbers).
+func (c Configuration) SenseBullet() (string, bool) {
+	return c.Bullet, "" != c.Bullet || c.provided["Bullet"]
+}
+
+// Porcelain returns whether results should be printed in the stable porcelain
+// format, by the OutputFormat (which the porcelain flag sets).
+func (c Configuration) Porcelain() bool {
+	return OutputFormatPorcelain == c.OutputFormat
+}
+
+// NoTrailingNewline returns whether the output shouldn't end with a line
+// break.
+func (c Configuration) NoTrailingNewline() bool {
+	return c.noTrailingNewline
+}
+
+// WordsFile returns the location of a file of words to use, one per line,
+// where "-" represents stdin.
+func (c Configuration) WordsFile() string {
+	return c.wordsFile
+}
+
+// Quiet returns whether progress information should be suppressed.
+func (c Configuration) Quiet() bool {
+	return c.quiet
+}
+
+// AllSources returns whether words should be defined with every available
+// source.
+func (c Configuration) AllSources() bool {
+	return c.allSources
+}
+
+// Ordered returns whether the results of multiple sources should be printed in
+// their order of priority, rather than as they arrive.
+func (c Configuration) Ordered() bool {
+	return c.ordered
+}
+
+// FirstMatch returns whether querying multiple sources should stop as soon as
+// one of them defines the word.
+func (c Configuration) FirstMatch() bool {
+	return c.firstMatch
+}
+
+// Limit returns the limit passed by flag (such as the maximum number of rhymes
+// to show), or 0 for no limit. The flag also overrides MaxSenses.
+func (c Configuration) Limit() uint {
+	return c.limit
+}
+
+// AllowSymbols returns whether words without any letters (such as numbers or
+// symbols) should be looked up, rather than rejected.
+func (c Configuration) AllowSymbols() bool {
+	return c.allowSymbols
+}
+
+// EffectiveTheme returns the color theme to print results with: the built-in
+// theme named by ThemeName, with the element styles of Theme applied over it.
+func (c Configuration) EffectiveTheme() (printer.Theme, error) {
+	return printer.BuiltinTheme(c.ThemeName, c.Theme)
+}
+
+// Debug returns whether debug logging is enabled.
+func (c Configuration) Debug() bool {
+	return c.debug
+}
+
 // MarshalJSON defines how the configuration should be JSON marshalled.
+//
+// The location of the loaded config file is included as a comment key, so
+// that the output can still be used as a config file.
 func (c Configuration) MarshalJSON() ([]byte, error) {
 	configMap := structs.Map(c)
 
+	// Output the effective theme, rather than only its overrides
+	if theme, err := c.EffectiveTheme(); nil == err {
+		configMap["Theme"] = theme
+	}
+
+	configMap[fileLocationKey] = strings.Join(c.configFiles, ", ")
+
+	if len(c.configFiles) < 1 {
+		configMap[fileLocationKey] = noFileLocationDescription
+	}
+
+	// The profiles were already overlaid, so only the active one is output
+	delete(configMap, profilesKey)
+
+	if "" != c.profile {
+		configMap[profileKey] = c.profile
+	}
+
 	for _, providerConf := range c.providerConfigs {
 		// Skip nil and zero-value configs
 		if nil == providerConf || len(structs.Fields(providerConf)) < 1 {
@@ -193,7 +940,17 @@ func (c Configuration) MarshalJSON() ([]byte, error) {
 		configMap[providerConf.JSONKey()] = providerConf
 	}
 
-	return json.Marshal(configMap)
+	encoded, err := json.Marshal(configMap)
+
+	if nil != err {
+		return nil, err
+	}
+
+	if encoded, err = redactSecrets(encoded, c.keyringSecrets, keyring.Placeholder); nil != err {
+		return nil, err
+	}
+
+	return redactSecrets(encoded, c.credentials, CredentialsPlaceholder)
 }
 
 // UnmarshalJSON defines how the configuration should be JSON unmarshalled.
@@ -222,7 +979,7 @@ func (c *Configuration) UnmarshalJSON(data []byte) error {
 		c.providerConfigs = make(map[string]registry.Configuration)
 	}
 
-	for conf := range registry.Providers() {
+	for _, conf := range registry.ProviderConfigurations() {
 		// If we have config data that matches a provider config
 		if rawConf, exists := configMap[conf.JSONKey()]; exists {
 			// Directly unmarshal the data into the provider config
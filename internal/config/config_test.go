@@ -0,0 +1,362 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	homedir "github.com/mitchellh/go-homedir"
+	flag "github.com/ogier/pflag"
+)
+
+func TestNewFromRuntimeConfigStdin(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-config")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	fileLocation := filepath.Join(dir, "config.json")
+
+	if err := ioutil.WriteFile(fileLocation, []byte(`{"IndentationSize": 2, "Source": "file"}`), 0600); nil != err {
+		t.Fatal(err)
+	}
+
+	defer func() { stdin = os.Stdin }()
+	stdin = strings.NewReader("IndentationSize: 4\n")
+
+	flags := flag.NewFlagSet("define", flag.ContinueOnError)
+	arguments := []string{"--config-stdin", "--config-file=" + fileLocation, "--indent-size=8"}
+
+	conf, err := NewFromRuntime(flags, arguments, nil, nil, "", Configuration{PreferredSource: "default"})
+
+	if nil != err {
+		t.Fatalf("NewFromRuntime returned error %q", err)
+	}
+
+	// Flags still take priority over the config read from stdin, which
+	// replaces the config file
+	if 8 != conf.IndentationSize || "" != conf.Source || "default" != conf.PreferredSource {
+		t.Errorf("NewFromRuntime merged %d, %q, and %q", conf.IndentationSize, conf.Source, conf.PreferredSource)
+	}
+
+	if !conf.ConfigStdin() || 1 != len(conf.ConfigFiles()) || stdinFileLocation != conf.ConfigFiles()[0] {
+		t.Errorf("NewFromRuntime loaded the config files %q", conf.ConfigFiles())
+	}
+
+	if problems, err := conf.ValidateFile(stdinFileLocation); nil != err || 0 != len(problems) {
+		t.Errorf("ValidateFile(%q) returned %q, %v", stdinFileLocation, problems, err)
+	}
+
+	flags = flag.NewFlagSet("define", flag.ContinueOnError)
+	stdin = strings.NewReader("IndentationSize: 4\n")
+
+	if conf, err = NewFromRuntime(flags, []string{"--config-stdin"}, nil, nil, "", Configuration{}); nil != err || 4 != conf.IndentationSize {
+		t.Errorf("NewFromRuntime returned %d, %v, want the indentation size read from stdin", conf.IndentationSize, err)
+	}
+}
+
+func TestNewFromRuntimeConfigFileEnv(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-config")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	fileLocation := filepath.Join(dir, "config.json")
+	defaultLocation := filepath.Join(dir, "default.json")
+
+	for _, location := range []string{fileLocation, defaultLocation} {
+		if err := ioutil.WriteFile(location, []byte(`{"IndentationSize": 2}`), 0600); nil != err {
+			t.Fatal(err)
+		}
+	}
+
+	defer os.Unsetenv(configFileEnvName)
+	os.Setenv(configFileEnvName, fileLocation)
+
+	flags := flag.NewFlagSet("define", flag.ContinueOnError)
+	conf, err := NewFromRuntime(flags, nil, nil, []string{defaultLocation}, "", Configuration{})
+
+	if nil != err || fileLocation != conf.FileLocation() || !conf.ConfigFileFromEnv() {
+		t.Errorf("NewFromRuntime loaded %q (from the environment: %t), %v, want %q", conf.FileLocation(), conf.ConfigFileFromEnv(), err, fileLocation)
+	}
+
+	// The flag takes priority over the environment
+	flags = flag.NewFlagSet("define", flag.ContinueOnError)
+	conf, err = NewFromRuntime(flags, []string{"--config-file=" + defaultLocation}, nil, nil, "", Configuration{})
+
+	if nil != err || defaultLocation != conf.FileLocation() || conf.ConfigFileFromEnv() {
+		t.Errorf("NewFromRuntime loaded %q (from the environment: %t), %v, want %q", conf.FileLocation(), conf.ConfigFileFromEnv(), err, defaultLocation)
+	}
+
+	// A missing file isn't silently replaced by the default
+	missingLocation := filepath.Join(dir, "missing.json")
+	os.Setenv(configFileEnvName, missingLocation)
+
+	flags = flag.NewFlagSet("define", flag.ContinueOnError)
+	conf, err = NewFromRuntime(flags, nil, nil, []string{defaultLocation}, "", Configuration{})
+
+	if nil == err || !strings.Contains(err.Error(), configFileEnvName) {
+		t.Errorf("NewFromRuntime returned error %v, want an error naming %s", err, configFileEnvName)
+	}
+
+	if _, err := conf.ValidateFile(missingLocation); nil == err || !strings.Contains(err.Error(), configFileEnvName) {
+		t.Errorf("ValidateFile returned error %v, want an error naming %s", err, configFileEnvName)
+	}
+}
+
+func TestNewFromRuntimeProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-config")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	fileLocation := filepath.Join(dir, "config.json")
+	contents := `{
+		"IndentationSize": 2,
+		"Source": "base",
+		"Profiles": {
+			"work": {"indentationSize": 0, "ExecSources": [{"Name": "internal", "Command": "lookup"}]},
+			"home": {"Source": "home"}
+		}
+	}`
+
+	if err := ioutil.WriteFile(fileLocation, []byte(contents), 0600); nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.Unsetenv(profileEnvName)
+	os.Setenv(profileEnvName, "home")
+
+	// The flag takes priority over the environment, and an explicit zero
+	// value of the profile is still overlaid
+	flags := flag.NewFlagSet("define", flag.ContinueOnError)
+	arguments := []string{"--config-file=" + fileLocation, "--profile=work"}
+
+	conf, err := NewFromRuntime(flags, arguments, nil, nil, "", Configuration{IndentationSize: 4})
+
+	if nil != err {
+		t.Fatalf("NewFromRuntime returned error %q", err)
+	}
+
+	if 0 != conf.IndentationSize || "base" != conf.Source || 1 != len(conf.ExecSources) || "work" != conf.Profile() {
+		t.Errorf("NewFromRuntime merged %d, %q, and %v with the profile %q", conf.IndentationSize, conf.Source, conf.ExecSources, conf.Profile())
+	}
+
+	if encoded, _ := json.Marshal(conf); !strings.Contains(string(encoded), `"// profile":"work"`) || strings.Contains(string(encoded), "Profiles") {
+		t.Errorf("MarshalJSON returned %s, want the active profile without the others", encoded)
+	}
+
+	flags = flag.NewFlagSet("define", flag.ContinueOnError)
+
+	if conf, err = NewFromRuntime(flags, []string{"--config-file=" + fileLocation}, nil, nil, "", Configuration{}); nil != err || "home" != conf.Source {
+		t.Errorf("NewFromRuntime merged %q, %v, want the source of the environment's profile", conf.Source, err)
+	}
+
+	os.Setenv(profileEnvName, "play")
+	flags = flag.NewFlagSet("define", flag.ContinueOnError)
+
+	if _, err = NewFromRuntime(flags, []string{"--config-file=" + fileLocation}, nil, nil, "", Configuration{}); nil == err || !strings.Contains(err.Error(), `["home" "work"]`) {
+		t.Errorf("NewFromRuntime returned error %v, want an error listing the profiles", err)
+	}
+}
+
+func TestNewFromRuntimeCache(t *testing.T) {
+	home, err := homedir.Dir()
+
+	if nil != err {
+		t.Skip("the home directory can't be found")
+	}
+
+	defer os.Unsetenv("DEFINE_APP_CACHE_TTL")
+	os.Setenv("DEFINE_APP_CACHE_TTL", "a day")
+
+	flags := flag.NewFlagSet("define", flag.ContinueOnError)
+	arguments := []string{"--cache-dir=cache", "--cache-max-size-mb=0"}
+
+	conf, err := NewFromRuntime(flags, arguments, nil, nil, "", Configuration{CacheTTL: "0s", CacheMaxSizeMB: 50})
+
+	if nil != err {
+		t.Fatalf("NewFromRuntime returned error %q", err)
+	}
+
+	if want := filepath.Join(home, "cache"); want != conf.CacheDir || 0 != conf.CacheMaxSizeMB {
+		t.Errorf("NewFromRuntime merged %q and %d, want %q and 0", conf.CacheDir, conf.CacheMaxSizeMB, want)
+	}
+
+	// An invalid duration is reported, rather than silently disabling caching
+	if problems := conf.Validate(); 1 != len(problems) || !strings.Contains(problems[0].Message, "CacheTTL") {
+		t.Errorf("Validate returned %q, want a CacheTTL problem", problems)
+	}
+
+	if 0 != conf.CacheExpiry() {
+		t.Errorf("CacheExpiry returned %s for an invalid CacheTTL", conf.CacheExpiry())
+	}
+}
+
+func TestNewFromRuntimeExplicitZeroValues(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-config")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	defaults := Configuration{IndentationSize: 2, MinSynonyms: 1, HistoryEnabled: true}
+
+	testData := []struct {
+		name      string
+		file      string
+		arguments []string
+		want      Configuration
+	}{
+		{"nothing sets it", `{}`, nil, Configuration{IndentationSize: 2, MinSynonyms: 1, HistoryEnabled: true}},
+		{"file sets 0", `{"IndentationSize": 0, "historyenabled": false}`, nil, Configuration{IndentationSize: 0, MinSynonyms: 1, HistoryEnabled: false}},
+		{"file sets null", `{"IndentationSize": null}`, nil, Configuration{IndentationSize: 2, MinSynonyms: 1, HistoryEnabled: true}},
+		{"flag sets 0", `{}`, []string{"--indent-size=0", "--history-enabled=false"}, Configuration{IndentationSize: 0, MinSynonyms: 1, HistoryEnabled: false}},
+		{"flag sets 0 over file", `{"IndentationSize": 4, "MinSynonyms": 3}`, []string{"--indent-size=0"}, Configuration{IndentationSize: 0, MinSynonyms: 3, HistoryEnabled: true}},
+		{"flag overrides file's 0", `{"IndentationSize": 0}`, []string{"--indent-size=4"}, Configuration{IndentationSize: 4, MinSynonyms: 1, HistoryEnabled: true}},
+	}
+
+	for i, data := range testData {
+		fileLocation := filepath.Join(dir, fmt.Sprintf("config%d.json", i))
+
+		if err := ioutil.WriteFile(fileLocation, []byte(data.file), 0600); nil != err {
+			t.Fatal(err)
+		}
+
+		flags := flag.NewFlagSet("define", flag.ContinueOnError)
+		arguments := append([]string{"--config-file=" + fileLocation}, data.arguments...)
+
+		conf, err := NewFromRuntime(flags, arguments, nil, nil, "", defaults)
+
+		if nil != err {
+			t.Fatalf("%s: NewFromRuntime returned error %q", data.name, err)
+		}
+
+		if data.want.IndentationSize != conf.IndentationSize || data.want.MinSynonyms != conf.MinSynonyms || data.want.HistoryEnabled != conf.HistoryEnabled {
+			t.Errorf("%s: NewFromRuntime merged %d, %d, and %t, want %d, %d, and %t", data.name, conf.IndentationSize, conf.MinSynonyms, conf.HistoryEnabled, data.want.IndentationSize, data.want.MinSynonyms, data.want.HistoryEnabled)
+		}
+	}
+}
+
+func TestMergeConfigurationsEnvironmentZeroValues(t *testing.T) {
+	defer os.Unsetenv("DEFINE_APP_INDENT_SIZE")
+	os.Setenv("DEFINE_APP_INDENT_SIZE", "0")
+
+	conf, err := mergeConfigurations(Configuration{}, initializeEnvironmentConfig(), Configuration{IndentationSize: 2})
+
+	if nil != err || 0 != conf.IndentationSize {
+		t.Errorf("mergeConfigurations merged %d, %v, want the environment's 0", conf.IndentationSize, err)
+	}
+}
+
+func TestNewFromRuntimeDeprecatedAliases(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-config")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	testData := []struct {
+		name             string
+		file             string
+		arguments        []string
+		wantShowExamples Toggle
+		wantOutputFormat string
+	}{
+		{"file alias", `{"NoExamples": true}`, nil, ToggleOff, ""},
+		{"file replacement wins over its alias", `{"NoExamples": true, "ShowExamples": true}`, nil, ToggleOn, ""},
+		{"flag replacement wins over file alias", `{"NoExamples": true}`, []string{"--show-examples=true"}, ToggleOn, ""},
+		{"flag alias wins over file replacement", `{"ShowExamples": true, "OutputFormat": "json"}`, []string{"--no-examples", "--porcelain"}, ToggleOff, OutputFormatPorcelain},
+		{"flag replacement wins over flag alias", `{}`, []string{"--porcelain", "--output-format=json"}, ToggleUnset, OutputFormatJSON},
+	}
+
+	for i, data := range testData {
+		fileLocation := filepath.Join(dir, fmt.Sprintf("config%d.json", i))
+
+		if err := ioutil.WriteFile(fileLocation, []byte(data.file), 0600); nil != err {
+			t.Fatal(err)
+		}
+
+		flags := flag.NewFlagSet("define", flag.ContinueOnError)
+		arguments := append([]string{"--config-file=" + fileLocation}, data.arguments...)
+
+		conf, err := NewFromRuntime(flags, arguments, nil, nil, "", Configuration{})
+
+		if nil != err {
+			t.Fatalf("%s: NewFromRuntime returned error %q", data.name, err)
+		}
+
+		if data.wantShowExamples != conf.ShowExamples || data.wantOutputFormat != conf.OutputFormat || conf.NoExamples {
+			t.Errorf("%s: NewFromRuntime merged %d and %q, want %d and %q", data.name, conf.ShowExamples, conf.OutputFormat, data.wantShowExamples, data.wantOutputFormat)
+		}
+
+		if (OutputFormatPorcelain == data.wantOutputFormat) != conf.Porcelain() {
+			t.Errorf("%s: Porcelain returned %t", data.name, conf.Porcelain())
+		}
+	}
+}
+
+func TestNewFromRuntimeSenseBullet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-config")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	testData := []struct {
+		name       string
+		file       string
+		arguments  []string
+		wantBullet string
+		wantIsSet  bool
+	}{
+		{"unset", `{}`, nil, "", false},
+		{"null", `{"Bullet": null}`, nil, "", false},
+		{"file marker", `{"Bullet": "•"}`, nil, "•", true},
+		{"file empty", `{"Bullet": ""}`, nil, "", true},
+		{"flag empty wins over file marker", `{"Bullet": "•"}`, []string{"--bullet="}, "", true},
+		{"flag marker wins over file empty", `{"Bullet": ""}`, []string{"--bullet=-"}, "-", true},
+	}
+
+	for i, data := range testData {
+		fileLocation := filepath.Join(dir, fmt.Sprintf("config%d.json", i))
+
+		if err := ioutil.WriteFile(fileLocation, []byte(data.file), 0600); nil != err {
+			t.Fatal(err)
+		}
+
+		flags := flag.NewFlagSet("define", flag.ContinueOnError)
+		arguments := append([]string{"--config-file=" + fileLocation}, data.arguments...)
+
+		conf, err := NewFromRuntime(flags, arguments, nil, nil, "", Configuration{})
+
+		if nil != err {
+			t.Fatalf("%s: NewFromRuntime returned error %q", data.name, err)
+		}
+
+		if bullet, isSet := conf.SenseBullet(); data.wantBullet != bullet || data.wantIsSet != isSet {
+			t.Errorf("%s: SenseBullet returned %q and %t, want %q and %t", data.name, bullet, isSet, data.wantBullet, data.wantIsSet)
+		}
+	}
+}
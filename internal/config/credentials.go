@@ -0,0 +1,133 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"runtime"
+
+	"github.com/Rican7/define/registry"
+)
+
+// CredentialsPlaceholder is output in place of the values that were read from
+// the credentials file, so that they're never printed
+const CredentialsPlaceholder = "(stored in credentials file)"
+
+// initializeCredentialsConfig loads the credentials file at the given
+// location into the source provider configurations, returning the string
+// values that were read from it by their dotted key paths, and the file's
+// mode. Only the source provider sections of the file are used, and a file
+// that doesn't exist is treated as empty.
+func initializeCredentialsConfig(fileLocation string) (map[string]string, os.FileMode, error) {
+	info, err := os.Stat(fileLocation)
+
+	if os.IsNotExist(err) {
+		return nil, 0, nil
+	}
+
+	if nil != err {
+		return nil, 0, err
+	}
+
+	fileContents, err := ioutil.ReadFile(fileLocation)
+
+	if nil != err {
+		return nil, 0, err
+	}
+
+	if fileContents, err = decodeFileContents(autoFormat, fileLocation, fileContents); nil != err {
+		return nil, 0, err
+	}
+
+	if 0 == len(bytes.TrimSpace(fileContents)) {
+		return nil, info.Mode(), nil
+	}
+
+	// Unmarshalling a configuration fills in the provider configurations
+	if err = json.Unmarshal(fileContents, &Configuration{}); nil != err {
+		return nil, 0, err
+	}
+
+	var configMap map[string]json.RawMessage
+
+	if err = json.Unmarshal(fileContents, &configMap); nil != err {
+		return nil, 0, err
+	}
+
+	credentials := make(map[string]string)
+
+	for _, providerConf := range registry.ProviderConfigurations() {
+		var section map[string]interface{}
+
+		// Sections that aren't objects were already ignored by the provider
+		if rawConf, exists := configMap[providerConf.JSONKey()]; !exists || nil != json.Unmarshal(rawConf, &section) {
+			continue
+		}
+
+		for key, value := range section {
+			if str, ok := value.(string); ok && "" != str {
+				credentials[providerConf.JSONKey()+keyPathSeparator+key] = str
+			}
+		}
+	}
+
+	return credentials, info.Mode(), nil
+}
+
+// credentialsFileProblems returns the problems of the loaded credentials file,
+// such as it being readable by other users
+func (c Configuration) credentialsFileProblems() []Problem {
+	// File permissions aren't represented by the mode on Windows
+	if "" == c.credentialsFile || "windows" == runtime.GOOS {
+		return nil
+	}
+
+	if 0 != c.credentialsMode.Perm()&0044 {
+		return []Problem{{
+			File:    c.credentialsFile,
+			Message: "the credentials file is readable by other users (mode " + c.credentialsMode.Perm().String() + "); consider restricting it with \"chmod 600\"",
+			Warning: true,
+		}}
+	}
+
+	return nil
+}
+
+// removeCredentialsPlaceholders removes the values of a config file's (JSON)
+// contents that are the placeholder of values read from the credentials file
+// (such as in the output of a printed configuration), so that they don't
+// shadow the credentials
+func removeCredentialsPlaceholders(contents []byte) ([]byte, error) {
+	if !bytes.Contains(contents, []byte(CredentialsPlaceholder)) {
+		return contents, nil
+	}
+
+	var configMap map[string]interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(contents))
+	decoder.UseNumber()
+
+	// Contents that aren't an object are left to fail when they're unmarshalled
+	if err := decoder.Decode(&configMap); nil != err {
+		return contents, nil
+	}
+
+	for _, value := range configMap {
+		section, ok := value.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		for key, sectionValue := range section {
+			if CredentialsPlaceholder == sectionValue {
+				delete(section, key)
+			}
+		}
+	}
+
+	return json.Marshal(configMap)
+}
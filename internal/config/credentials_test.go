@@ -0,0 +1,57 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestRemoveCredentialsPlaceholders(t *testing.T) {
+	testData := []struct {
+		contents string
+		want     string
+	}{
+		{`{"OxfordDictionary":{"AppKey":"key"}}`, `{"OxfordDictionary":{"AppKey":"key"}}`},
+		{`{"OxfordDictionary":{"AppID":"id","AppKey":"` + CredentialsPlaceholder + `"}}`, `{"OxfordDictionary":{"AppID":"id"}}`},
+		{`not an object ` + CredentialsPlaceholder, `not an object ` + CredentialsPlaceholder},
+	}
+
+	for _, data := range testData {
+		got, err := removeCredentialsPlaceholders([]byte(data.contents))
+
+		if nil != err || data.want != string(got) {
+			t.Errorf("removeCredentialsPlaceholders(%s) returned %s, %v, want %s", data.contents, got, err, data.want)
+		}
+	}
+}
+
+func TestCredentialsFileProblems(t *testing.T) {
+	if "windows" == runtime.GOOS {
+		t.Skip("file permissions aren't represented by the mode on Windows")
+	}
+
+	testData := []struct {
+		conf         Configuration
+		wantProblems int
+	}{
+		{Configuration{}, 0},
+		{Configuration{credentialsFile: "credentials.json", credentialsMode: 0600}, 0},
+		{Configuration{credentialsFile: "credentials.json", credentialsMode: 0640}, 1},
+		{Configuration{credentialsFile: "credentials.json", credentialsMode: 0604}, 1},
+	}
+
+	for _, data := range testData {
+		problems := data.conf.credentialsFileProblems()
+
+		if data.wantProblems != len(problems) {
+			t.Errorf("credentialsFileProblems with mode %s returned %q", data.conf.credentialsMode, problems)
+		}
+
+		for _, problem := range problems {
+			if !problem.Warning {
+				t.Errorf("credentialsFileProblems returned the non-warning %q", problem)
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Duration defines a configurable length of time, represented in its string
+// form (such as "1.5s" or "2m") when used as a flag or in JSON
+type Duration time.Duration
+
+// String returns the duration's string form
+func (d *Duration) String() string {
+	return time.Duration(*d).String()
+}
+
+// Set sets the duration from its string form
+func (d *Duration) Set(value string) error {
+	parsed, err := time.ParseDuration(value)
+
+	if nil != err {
+		return err
+	}
+
+	*d = Duration(parsed)
+
+	return nil
+}
+
+// Type returns the type name of the duration, for flag usage
+func (d *Duration) Type() string {
+	return "duration"
+}
+
+// MarshalJSON defines how the duration should be JSON marshalled.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON defines how the duration should be JSON unmarshalled.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+
+	if err := json.Unmarshal(data, &value); nil != err {
+		return fmt.Errorf("duration must be a string, such as \"10s\": %s", err)
+	}
+
+	return d.Set(value)
+}
@@ -0,0 +1,435 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/Rican7/define/internal/toml"
+	"github.com/Rican7/define/internal/yaml"
+)
+
+// jsoncObject defines the layout of a JSON object within JSON with comments
+// (JSONC) contents, by the offsets of its braces and members
+type jsoncObject struct {
+	start   int
+	end     int
+	members []jsoncMember
+}
+
+// jsoncMember defines the layout of a member of a JSON object, by the offsets
+// of its key and value
+type jsoncMember struct {
+	key        string
+	keyStart   int
+	valueStart int
+	valueEnd   int
+}
+
+// writeFileValue writes the value of a key path to the config file at the
+// location, given the file's object with the value already set.
+//
+// Only the key's value is changed (or the key is added), where the file's
+// format and layout allow, so that the rest of the file, including its
+// comments, is kept as it is. Otherwise, the file is rewritten from the
+// object, unless it has comments, which rewriting it would remove.
+func writeFileValue(location string, format fileFormat, keys []string, value json.RawMessage, object *rawObject) error {
+	contents, err := ioutil.ReadFile(location)
+
+	if os.IsNotExist(err) || (nil == err && 0 == len(bytes.TrimSpace(contents))) {
+		return writeFileObject(location, format, object)
+	} else if nil != err {
+		return err
+	}
+
+	edited, err := setContentsValue(format, contents, keys, value)
+
+	if nil == err && equalContents(format, edited, object) {
+		return writeFileContents(location, edited)
+	}
+
+	if hasComments(format, contents) {
+		return fmt.Errorf("can't set %q without removing the comments of config file %q (set it by editing the file instead)", strings.Join(keys, keyPathSeparator), location)
+	}
+
+	return writeFileObject(location, format, object)
+}
+
+// setContentsValue sets the value of a key path in the contents of a config
+// file, by editing only the key's value (or adding the key)
+func setContentsValue(format fileFormat, contents []byte, keys []string, value json.RawMessage) ([]byte, error) {
+	switch format {
+	case tomlFormat:
+		return toml.SetValue(contents, keys, value)
+	case yamlFormat:
+		return nil, fmt.Errorf("YAML config files can't be edited in place")
+	}
+
+	return setJSONCValue(contents, keys, value)
+}
+
+// equalContents returns whether the contents of a config file are equivalent
+// to the given object
+func equalContents(format fileFormat, contents []byte, object *rawObject) bool {
+	decoded, err := decodeFileContents(format, "", contents)
+
+	if nil != err {
+		return false
+	}
+
+	encoded, err := json.Marshal(object)
+
+	if nil != err {
+		return false
+	}
+
+	var got, want interface{}
+
+	if nil != json.Unmarshal(decoded, &got) || nil != json.Unmarshal(encoded, &want) {
+		return false
+	}
+
+	return reflect.DeepEqual(want, got)
+}
+
+// hasComments returns whether the contents of a config file have comments (or
+// any other syntax, such as the trailing commas of JSONC) that rewriting the
+// file would remove
+func hasComments(format fileFormat, contents []byte) bool {
+	switch format {
+	case tomlFormat:
+		return toml.HasComments(contents)
+	case yamlFormat:
+		return yaml.HasComments(contents)
+	}
+
+	_, extensions := stripJSONC(contents)
+
+	return 0 < len(extensions)
+}
+
+// fileHasComments returns whether the config file at the location has comments
+// that rewriting it would remove (see hasComments)
+func fileHasComments(location string, format fileFormat) bool {
+	contents, err := ioutil.ReadFile(location)
+
+	return nil == err && hasComments(format, contents)
+}
+
+// setJSONCValue sets the value of a key path in JSONC contents, replacing the
+// value of the key if it exists, or otherwise adding the key (and any objects
+// of the key path that don't exist) as the last member of its object
+func setJSONCValue(contents []byte, keys []string, value json.RawMessage) ([]byte, error) {
+	stripped, extensions := stripJSONC(contents)
+	unit := jsoncIndentUnit(contents, stripped)
+
+	object, err := parseJSONCObject(stripped, 0)
+
+	if nil != err {
+		return nil, err
+	}
+
+	for depth, key := range keys {
+		member, exists := object.find(key)
+
+		if !exists {
+			// Add the rest of the key path as nested objects
+			for i := len(keys) - 1; depth < i; i-- {
+				nested := &rawObject{values: make(map[string]json.RawMessage)}
+				nested.set(keys[i], value)
+
+				if value, err = json.Marshal(nested); nil != err {
+					return nil, err
+				}
+			}
+
+			return insertJSONCMember(contents, extensions, object, key, value, unit)
+		}
+
+		if len(keys)-1 == depth {
+			indent, _ := lineIndent(contents, member.keyStart)
+
+			return splice(contents, member.valueStart, member.valueEnd, formatJSONValue(contents, value, indent, unit)), nil
+		}
+
+		if object, err = parseJSONCObject(stripped, member.valueStart); nil != err {
+			return nil, fmt.Errorf("error reading key %q: %s", member.key, err)
+		}
+	}
+
+	return contents, nil
+}
+
+// insertJSONCMember inserts a member into an object of JSONC contents, after
+// its last member (on its own line, if the last member is), keeping the style
+// of any trailing comma
+func insertJSONCMember(contents []byte, extensions []jsoncExtension, object *jsoncObject, key string, value json.RawMessage, unit string) ([]byte, error) {
+	encodedKey, err := json.Marshal(key)
+
+	if nil != err {
+		return nil, err
+	}
+
+	newline := lineBreakOf(contents)
+
+	if 0 == len(object.members) {
+		objectIndent, _ := lineIndent(contents, object.start)
+		member := objectIndent + unit + string(encodedKey) + ": " + formatJSONValue(contents, value, objectIndent+unit, unit)
+
+		if 0 == len(bytes.TrimSpace(contents[object.start+1:object.end])) {
+			return splice(contents, object.start+1, object.end, newline+member+newline+objectIndent), nil
+		}
+
+		// Keep any comments of the empty object before the new member
+		if _, ownLine := lineIndent(contents, object.end); ownLine {
+			start := bytes.LastIndexByte(contents[:object.end], '\n') + 1
+
+			return splice(contents, start, start, member+newline), nil
+		}
+
+		return splice(contents, object.end, object.end, newline+member+newline+objectIndent), nil
+	}
+
+	last := object.members[len(object.members)-1]
+	indent, ownLine := lineIndent(contents, last.keyStart)
+	trailingComma := false
+
+	for _, extension := range extensions {
+		if "a trailing comma" == extension.syntax && int64(last.valueEnd) <= extension.offset && extension.offset < int64(object.end) {
+			trailingComma = true
+		}
+	}
+
+	lineEnd := bytes.IndexByte(contents[last.valueEnd:object.end], '\n')
+
+	if !ownLine || -1 == lineEnd {
+		var compact bytes.Buffer
+
+		if err = json.Compact(&compact, value); nil != err {
+			return nil, err
+		}
+
+		separator := ", "
+
+		if ownLine {
+			separator = "," + newline + indent
+		}
+
+		return splice(contents, last.valueEnd, last.valueEnd, separator+string(encodedKey)+": "+compact.String()), nil
+	}
+
+	member := indent + string(encodedKey) + ": " + formatJSONValue(contents, value, indent, unit)
+
+	if trailingComma {
+		member += ","
+	}
+
+	// Insert the member on the line after the last one, so that any comment
+	// that follows the last member on its line stays with it
+	offset := last.valueEnd + lineEnd + 1
+	edited := splice(contents, offset, offset, member+newline)
+
+	if !trailingComma {
+		edited = splice(edited, last.valueEnd, last.valueEnd, ",")
+	}
+
+	return edited, nil
+}
+
+// parseJSONCObject parses the layout of the object at the offset of stripped
+// JSONC contents (see stripJSONC)
+func parseJSONCObject(stripped []byte, offset int) (*jsoncObject, error) {
+	i := skipJSONSpace(stripped, offset)
+
+	if len(stripped) <= i || '{' != stripped[i] {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+
+	object := &jsoncObject{start: i}
+
+	for i = skipJSONSpace(stripped, i+1); i < len(stripped) && '}' != stripped[i]; {
+		keyEnd, err := scanJSONValue(stripped, i)
+
+		if nil != err {
+			return nil, err
+		}
+
+		member := jsoncMember{keyStart: i}
+
+		if '"' != stripped[i] || nil != json.Unmarshal(stripped[i:keyEnd], &member.key) {
+			return nil, fmt.Errorf("expected a key at offset %d", i)
+		}
+
+		if i = skipJSONSpace(stripped, keyEnd); len(stripped) <= i || ':' != stripped[i] {
+			return nil, fmt.Errorf("expected a colon at offset %d", i)
+		}
+
+		member.valueStart = skipJSONSpace(stripped, i+1)
+
+		if member.valueEnd, err = scanJSONValue(stripped, member.valueStart); nil != err {
+			return nil, err
+		}
+
+		object.members = append(object.members, member)
+
+		if i = skipJSONSpace(stripped, member.valueEnd); i < len(stripped) && ',' == stripped[i] {
+			i = skipJSONSpace(stripped, i+1)
+		} else if len(stripped) <= i || '}' != stripped[i] {
+			return nil, fmt.Errorf("expected a comma or a closing brace at offset %d", i)
+		}
+	}
+
+	if len(stripped) <= i {
+		return nil, fmt.Errorf("unexpected end of JSON input")
+	}
+
+	object.end = i
+
+	return object, nil
+}
+
+// find finds the last member of the object with the key, matched the same way
+// that the JSON decoder does (see findKey)
+func (o *jsoncObject) find(key string) (jsoncMember, bool) {
+	indexes := make(map[string]int, len(o.members))
+
+	for i, member := range o.members {
+		indexes[member.key] = i
+	}
+
+	if i, exists := indexes[findKey(indexes, key)]; exists {
+		return o.members[i], true
+	}
+
+	return jsoncMember{}, false
+}
+
+// scanJSONValue scans the JSON value at the offset of stripped JSONC contents,
+// returning the offset after it
+func scanJSONValue(stripped []byte, offset int) (int, error) {
+	if len(stripped) <= offset {
+		return 0, fmt.Errorf("unexpected end of JSON input")
+	}
+
+	switch stripped[offset] {
+	case '"':
+		for i := offset + 1; i < len(stripped); i++ {
+			if '\\' == stripped[i] {
+				i++
+			} else if '"' == stripped[i] {
+				return i + 1, nil
+			}
+		}
+	case '{', '[':
+		depth := 0
+
+		for i := offset; i < len(stripped); i++ {
+			switch stripped[i] {
+			case '"':
+				end, err := scanJSONValue(stripped, i)
+
+				if nil != err {
+					return 0, err
+				}
+
+				i = end - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				if depth--; 0 == depth {
+					return i + 1, nil
+				}
+			}
+		}
+	default:
+		i := offset
+
+		for i < len(stripped) && -1 == strings.IndexByte(",}] \t\r\n", stripped[i]) {
+			i++
+		}
+
+		if offset < i {
+			return i, nil
+		}
+
+		return 0, fmt.Errorf("expected a value at offset %d", offset)
+	}
+
+	return 0, fmt.Errorf("unexpected end of JSON input")
+}
+
+// skipJSONSpace returns the offset of the first character from the given
+// offset that isn't whitespace
+func skipJSONSpace(stripped []byte, offset int) int {
+	for offset < len(stripped) && -1 != strings.IndexByte(" \t\r\n", stripped[offset]) {
+		offset++
+	}
+
+	return offset
+}
+
+// jsoncIndentUnit returns the indentation of the members of the top-level
+// object of JSONC contents, or the indentation of example config files if
+// they aren't on their own lines
+func jsoncIndentUnit(contents []byte, stripped []byte) string {
+	object, err := parseJSONCObject(stripped, 0)
+
+	if nil != err || 0 == len(object.members) {
+		return exampleIndent
+	}
+
+	if indent, ownLine := lineIndent(contents, object.members[0].keyStart); ownLine && "" != indent {
+		return indent
+	}
+
+	return exampleIndent
+}
+
+// lineIndent returns the indentation of the line of the offset, and whether
+// the offset is the first thing on its line
+func lineIndent(contents []byte, offset int) (string, bool) {
+	start := bytes.LastIndexByte(contents[:offset], '\n') + 1
+	indent := contents[start:offset]
+
+	if 0 != len(bytes.Trim(indent, " \t")) {
+		return "", false
+	}
+
+	return string(indent), true
+}
+
+// formatJSONValue formats a JSON value to be written at the given indentation,
+// with the line breaks of the contents it's written to
+func formatJSONValue(contents []byte, value json.RawMessage, indent string, unit string) string {
+	var formatted bytes.Buffer
+
+	if nil != json.Indent(&formatted, value, indent, unit) {
+		return string(value)
+	}
+
+	return strings.Replace(formatted.String(), "\n", lineBreakOf(contents), -1)
+}
+
+// lineBreakOf returns the line break that contents use
+func lineBreakOf(contents []byte) string {
+	if bytes.Contains(contents, []byte("\r\n")) {
+		return "\r\n"
+	}
+
+	return "\n"
+}
+
+// splice returns a copy of contents with the given range replaced by text
+func splice(contents []byte, start int, end int, text string) []byte {
+	spliced := make([]byte, 0, len(contents)-(end-start)+len(text))
+	spliced = append(spliced, contents[:start]...)
+	spliced = append(spliced, text...)
+
+	return append(spliced, contents[end:]...)
+}
@@ -0,0 +1,248 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Rican7/define/registry"
+)
+
+// EnvVarStatus defines the state of an environment variable's value in the
+// effective configuration.
+type EnvVarStatus string
+
+// List of environment variable statuses.
+const (
+	EnvVarUnset      EnvVarStatus = "unset"
+	EnvVarUsed       EnvVarStatus = "used"
+	EnvVarOverridden EnvVarStatus = "overridden"
+	EnvVarInvalid    EnvVarStatus = "invalid"
+)
+
+// configFileEnvName is the name of the environment variable of the location of
+// the config file to use, when the config file flag isn't passed
+const configFileEnvName = "DEFINE_APP_CONFIG_FILE"
+
+// profileEnvName is the name of the environment variable of the config file
+// profile to use, when the profile flag isn't passed
+const profileEnvName = "DEFINE_APP_PROFILE"
+
+// debugEnvName is the name of the environment variable that enables debug
+// logging, in addition to the debug flag
+const debugEnvName = "DEFINE_APP_DEBUG"
+
+// envVars is the list of environment variables that the configuration reads
+// its values from
+var envVars = []registry.EnvVar{
+	{Name: "DEFINE_APP_INDENT_SIZE", Key: "IndentationSize"},
+	{Name: "DEFINE_APP_MIN_SYNONYMS", Key: "MinSynonyms"},
+	{Name: "DEFINE_APP_LIMIT_PER_POS", Key: "LimitPerPOS"},
+	{Name: "DEFINE_APP_MAX_SENSES", Key: "MaxSenses"},
+	{Name: "DEFINE_APP_PREFERRED_SOURCE", Key: "PreferredSource"},
+	{Name: "DEFINE_APP_SOURCE", Key: "Source"},
+	{Name: "DEFINE_APP_THESAURUS_SOURCE", Key: "ThesaurusSource"},
+	{Name: "DEFINE_APP_NO_EMBEDDED", Key: "NoEmbedded"},
+	{Name: "DEFINE_APP_RETRY_EMPTY", Key: "RetryEmpty"},
+	{Name: "DEFINE_APP_EXPAND_ABBREVIATIONS", Key: "ExpandAbbreviations"},
+	{Name: "DEFINE_APP_NO_EXAMPLES", Key: "NoExamples"},
+	{Name: "DEFINE_APP_SHOW_EXAMPLES", Key: "ShowExamples"},
+	{Name: "DEFINE_APP_HIDE_THESAURUS", Key: "HideThesaurus"},
+	{Name: "DEFINE_APP_SHOW_FORMS", Key: "ShowForms"},
+	{Name: "DEFINE_APP_OUTPUT_FORMAT", Key: "OutputFormat"},
+	{Name: "DEFINE_APP_COLOR", Key: "Color"},
+	{Name: "DEFINE_APP_THEME", Key: "ThemeName"},
+	{Name: "DEFINE_APP_MAX_EXAMPLES_PER_SENSE", Key: "MaxExamplesPerSense"},
+	{Name: "DEFINE_APP_HEADWORD_CASE", Key: "HeadwordCase"},
+	{Name: "DEFINE_APP_BULLET", Key: "Bullet"},
+	{Name: "DEFINE_APP_NORMALIZATION", Key: "Normalization"},
+	{Name: "DEFINE_APP_TRANSLATE", Key: "Translate"},
+	{Name: "DEFINE_APP_RELATED", Key: "Related"},
+	{Name: "DEFINE_APP_CA_CERT", Key: "CACertFile"},
+	{Name: "DEFINE_APP_INSECURE", Key: "Insecure"},
+	{Name: "DEFINE_APP_POST_PROCESS", Key: "PostProcess"},
+	{Name: "DEFINE_APP_HISTORY_ENABLED", Key: "HistoryEnabled"},
+	{Name: "DEFINE_APP_HISTORY_FILE", Key: "HistoryFile"},
+	{Name: "DEFINE_APP_STARRED_FILE", Key: "StarredFile"},
+	{Name: "DEFINE_APP_CREDENTIALS_FILE", Key: "CredentialsFile"},
+	{Name: "DEFINE_APP_CACHE_DIR", Key: "CacheDir"},
+	{Name: "DEFINE_APP_CACHE_TTL", Key: "CacheTTL"},
+	{Name: "DEFINE_APP_CACHE_MAX_SIZE_MB", Key: "CacheMaxSizeMB"},
+	{Name: "DEFINE_APP_TIMEOUT", Key: "Timeout"},
+	{Name: "DEFINE_APP_PER_SOURCE_TIMEOUT", Key: "PerSourceTimeout"},
+	{Name: configFileEnvName, Key: "configFileLocation"},
+	{Name: profileEnvName, Key: "profile"},
+	{Name: debugEnvName, Key: "debug"},
+}
+
+// EnvVarInfo describes an environment variable that the app consults, and its
+// current value and status.
+type EnvVarInfo struct {
+	// Name is the name of the environment variable.
+	Name string
+
+	// KeyPath is the dotted key path of the configuration value that the
+	// variable provides (such as "OxfordDictionary.AppKey").
+	KeyPath string
+
+	// Alias is the name of the alias (such as a legacy name) that the value
+	// was read from, if the variable itself isn't set.
+	Alias string
+
+	// Value is the variable's current value, if set.
+	Value string
+
+	// Status is the state of the variable's value in the configuration.
+	Status EnvVarStatus
+}
+
+// ParseBool parses a boolean value in any of its common forms, such as of an
+// environment variable: "true", "false", "1", "0", "yes", "no", "on", or
+// "off" (case-insensitively, and ignoring surrounding whitespace).
+func ParseBool(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "t", "1", "yes", "y", "on":
+		return true, nil
+	case "false", "f", "0", "no", "n", "off":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid boolean value %q", value)
+	}
+}
+
+// parseEnvValue parses an environment variable's value into the given
+// (settable) value, according to its type
+func parseEnvValue(value string, target reflect.Value) error {
+	value = strings.TrimSpace(value)
+
+	switch target.Interface().(type) {
+	case Duration:
+		parsed, err := time.ParseDuration(value)
+
+		if nil != err {
+			return err
+		}
+
+		target.Set(reflect.ValueOf(Duration(parsed)))
+	case bool:
+		parsed, err := ParseBool(value)
+
+		if nil != err {
+			return err
+		}
+
+		target.SetBool(parsed)
+	case Toggle:
+		parsed, err := ParseBool(value)
+
+		if nil != err {
+			return err
+		}
+
+		target.Set(reflect.ValueOf(NewToggle(parsed)))
+	case uint:
+		parsed, err := strconv.ParseUint(value, 10, 0)
+
+		if nil != err {
+			return err
+		}
+
+		target.SetUint(parsed)
+	case string:
+		target.SetString(value)
+	default:
+		return fmt.Errorf("unsupported type %s", target.Type())
+	}
+
+	return nil
+}
+
+// envVarStatus returns the status of an environment variable's value,
+// compared to the effective value of the key in the given configuration struct
+func envVarStatus(value string, isSet bool, confValue reflect.Value, key string) EnvVarStatus {
+	if !isSet {
+		return EnvVarUnset
+	}
+
+	field := confValue.FieldByName(key)
+
+	if !field.IsValid() {
+		return EnvVarInvalid
+	}
+
+	parsed := reflect.New(field.Type()).Elem()
+
+	if err := parseEnvValue(value, parsed); nil != err {
+		return EnvVarInvalid
+	}
+
+	// Compare by the printed values, as unexported fields can't be compared
+	// as interfaces
+	if fmt.Sprint(field) != fmt.Sprint(parsed) {
+		return EnvVarOverridden
+	}
+
+	return EnvVarUsed
+}
+
+// newEnvVarInfo returns the information of an environment variable of the
+// given key path, compared to the given configuration struct
+func newEnvVarInfo(envVar registry.EnvVar, keyPath string, confValue reflect.Value) EnvVarInfo {
+	value, name, isSet := envVar.Lookup()
+
+	info := EnvVarInfo{
+		Name:    envVar.Name,
+		KeyPath: keyPath,
+		Value:   value,
+		Status:  envVarStatus(value, isSet, confValue, envVar.Key),
+	}
+
+	if name != envVar.Name {
+		info.Alias = name
+	}
+
+	return info
+}
+
+// EnvVars returns the environment variables that the app consults, for both
+// the app's configuration and the configurations of the source providers, and
+// the status of each of their values in the configuration.
+func (c Configuration) EnvVars() []EnvVarInfo {
+	var infos []EnvVarInfo
+
+	confValue := reflect.ValueOf(c)
+
+	for _, envVar := range envVars {
+		infos = append(infos, newEnvVarInfo(envVar, envVar.Key, confValue))
+	}
+
+	var providerKeys []string
+
+	for key := range c.providerConfigs {
+		providerKeys = append(providerKeys, key)
+	}
+
+	sort.Strings(providerKeys)
+
+	for _, providerKey := range providerKeys {
+		providerConfig := c.providerConfigs[providerKey]
+		providerValue := reflect.Indirect(reflect.ValueOf(providerConfig))
+
+		for _, envVar := range registry.ProviderMetadata(providerConfig).EnvVars {
+			infos = append(infos, newEnvVarInfo(envVar, providerKey+keyPathSeparator+envVar.Key, providerValue))
+		}
+	}
+
+	return infos
+}
+
+// missingEnvConfigFileError returns the error of a config file, at the given
+// location, that was given by the environment but doesn't exist
+func missingEnvConfigFileError(location string) error {
+	return fmt.Errorf("the config file %q given by the %s environment variable doesn't exist", location, configFileEnvName)
+}
@@ -0,0 +1,153 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Rican7/define/registry"
+	"github.com/Rican7/define/source"
+)
+
+type envTestConfig struct {
+	Name     string
+	Language string
+	Region   string
+}
+
+func (c *envTestConfig) JSONKey() string {
+	return "EnvTest"
+}
+
+type envTestProvider struct{}
+
+func (p *envTestProvider) Name() string {
+	return "Env Test"
+}
+
+func (p *envTestProvider) Provide(registry.Configuration) (source.Source, error) {
+	return nil, nil
+}
+
+func (p *envTestProvider) Metadata() registry.Metadata {
+	return registry.Metadata{
+		EnvVars: []registry.EnvVar{
+			{Name: "DEFINE_ENV_TEST_NAME", Key: "Name"},
+			{Name: "DEFINE_ENV_TEST_LANGUAGE", Key: "Language", Aliases: []string{"ENV_TEST_LANGUAGE"}},
+			{Name: "DEFINE_ENV_TEST_REGION", Key: "Region"},
+		},
+	}
+}
+
+func TestParseBool(t *testing.T) {
+	testData := map[string]bool{
+		"true":  true,
+		"TRUE":  true,
+		"1":     true,
+		"yes":   true,
+		" on ":  true,
+		"false": false,
+		"0":     false,
+		"No":    false,
+		"off":   false,
+	}
+
+	for value, want := range testData {
+		got, err := ParseBool(value)
+
+		if nil != err {
+			t.Errorf("ParseBool(%q) returned an error: %s", value, err)
+		} else if want != got {
+			t.Errorf("ParseBool(%q) returned %t, want %t", value, got, want)
+		}
+	}
+
+	for _, value := range []string{"", "maybe", "2"} {
+		if _, err := ParseBool(value); nil == err {
+			t.Errorf("ParseBool(%q) didn't return an error", value)
+		}
+	}
+}
+
+func TestInitializeEnvironmentConfig(t *testing.T) {
+	env := map[string]string{
+		"DEFINE_APP_NO_EXAMPLES":   "yes",
+		"DEFINE_APP_INSECURE":      "nonsense",
+		"DEFINE_APP_INDENT_SIZE":   " 4 ",
+		"DEFINE_APP_TIMEOUT":       "2s",
+		"DEFINE_APP_HEADWORD_CASE": "upper",
+	}
+
+	for name, value := range env {
+		os.Setenv(name, value)
+		defer os.Unsetenv(name)
+	}
+
+	conf := initializeEnvironmentConfig()
+
+	if !conf.NoExamples {
+		t.Errorf("NoExamples is false, want true")
+	}
+
+	if conf.Insecure {
+		t.Errorf("Insecure is true for an invalid value, want false")
+	}
+
+	if 4 != conf.IndentationSize {
+		t.Errorf("IndentationSize is %d, want 4", conf.IndentationSize)
+	}
+
+	if Duration(2*time.Second) != conf.Timeout {
+		t.Errorf("Timeout is %s, want 2s", time.Duration(conf.Timeout))
+	}
+
+	if "upper" != conf.HeadwordCase {
+		t.Errorf("HeadwordCase is %q, want \"upper\"", conf.HeadwordCase)
+	}
+}
+
+func TestInitializeProviderEnvironmentConfigs(t *testing.T) {
+	env := map[string]string{
+		"DEFINE_ENV_TEST_NAME":   "from env",
+		"ENV_TEST_LANGUAGE":      "fr",
+		"DEFINE_ENV_TEST_REGION": "CA",
+	}
+
+	for name, value := range env {
+		os.Setenv(name, value)
+		defer os.Unsetenv(name)
+	}
+
+	conf := &envTestConfig{Region: "from flag"}
+
+	if err := registry.RegisterConfigured(&envTestProvider{}, conf); nil != err {
+		t.Fatal(err)
+	}
+
+	initializeProviderEnvironmentConfigs(map[string]registry.Configuration{conf.JSONKey(): conf})
+
+	if "from env" != conf.Name {
+		t.Errorf("Name is %q, want \"from env\"", conf.Name)
+	}
+
+	if "fr" != conf.Language {
+		t.Errorf("Language is %q, want the value of the alias \"fr\"", conf.Language)
+	}
+
+	if "from flag" != conf.Region {
+		t.Errorf("Region is %q, want the already set \"from flag\"", conf.Region)
+	}
+
+	infos := Configuration{providerConfigs: map[string]registry.Configuration{conf.JSONKey(): conf}}.EnvVars()
+	aliases := make(map[string]string)
+
+	for _, info := range infos {
+		aliases[info.Name] = info.Alias
+	}
+
+	if "ENV_TEST_LANGUAGE" != aliases["DEFINE_ENV_TEST_LANGUAGE"] || "" != aliases["DEFINE_ENV_TEST_NAME"] {
+		t.Errorf("EnvVars returned the aliases %q", aliases)
+	}
+}
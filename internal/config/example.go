@@ -0,0 +1,301 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"github.com/Rican7/define/registry"
+)
+
+// commentKeyPrefix is the prefix of keys that act as comments in an example
+// config file, as JSON has no comment syntax
+const commentKeyPrefix = "//"
+
+// exampleIndent is the indentation used in an example config file
+const exampleIndent = "    "
+
+// fieldDescriptions defines the descriptions of the configuration's fields,
+// used to comment an example config file
+var fieldDescriptions = map[string]string{
+	"IndentationSize":     "The number of spaces to indent output by",
+	"PreferredSource":     "The preferred source to use, if available and able to be provided",
+	"Source":              "The source to use (will error if unavailable or unable to be provided)",
+	"ThesaurusSource":     "A source to add the synonyms and antonyms of, to those of the defining source's results",
+	"NoPrompt":            "Whether to never interactively prompt, such as when suggesting alternative words",
+	"NoEmbedded":          "Whether to not fall back to the dictionary embedded in the app when the sources fail to define a word",
+	"RetryEmpty":          "Whether to retry the other sources, in turn, when the selected source doesn't find a word",
+	"ExpandAbbreviations": "Whether to look up abbreviations and acronyms (such as \"etc.\" or \"NASA\") with the Wiktionary source first, for their expansions",
+	"ShowExamples":        "Whether to print the examples of senses (null for the default of showing them)",
+	"MaxExamplesPerSense": "The maximum number of examples to print for each sense (0 for no limit)",
+	"HideThesaurus":       "Whether to hide the synonyms and antonyms sections (null for the default of showing them)",
+	"ShowForms":           "Whether to print the inflected forms of words (such as plurals and past tenses), when the source provides them",
+	"OutputFormat":        "The format to print results in (\"text\", \"porcelain\", or \"json\")",
+	"Color":               "Whether to print results in color (null for when printing to a terminal, unless NO_COLOR is set)",
+	"ThemeName":           "The name of the built-in color theme to print results with (\"default\" or \"mono\")",
+	"Theme":               "The styles of output elements (headword, partOfSpeech, definition, example, synonym, source) applied over the named theme, as color names (such as \"bold blue\") or ANSI codes (such as \"38;5;208\")",
+	"HeadwordCase":        "The capitalization to display headwords in (\"source\", \"lower\", \"upper\", or \"title\")",
+	"Bullet":              "The marker to print before each sense, instead of its number (such as \"•\" or \"-\", or empty for no marker; null for numbers)",
+	"Normalization":       "How to normalize the capitalization of words before looking them up (\"none\", \"lower\", or \"smart\", which preserves likely proper nouns and acronyms)",
+	"Translate":           "The language code (ISO 639-1) to also translate defined words into (such as \"fr\")",
+	"Related":             "Whether to also print the words commonly used with defined words (provided by the Datamuse API)",
+	"MinSynonyms":         "The minimum number of synonyms needed to show the synonyms section (0 to always show it)",
+	"LimitPerPOS":         "The maximum number of senses to show for each part of speech (0 for no limit)",
+	"MaxSenses":           "The maximum number of senses to show in total (0 for no limit)",
+	"Timeout":             "The overall time limit of the lookups, including any fallbacks (such as \"30s\"; defaults to \"10s\")",
+	"PerSourceTimeout":    "The time limit of each individual source lookup (such as \"10s\"), or \"0s\" for none",
+	"CACertFile":          "The location of a PEM encoded bundle of CA certificates to trust, such as for a TLS-intercepting proxy",
+	"Insecure":            "Whether to skip verifying the TLS certificates of sources (discouraged; prefer CACertFile)",
+	"PostProcess":         "A command to pipe the JSON result through, printing the command's output instead",
+	"HistoryEnabled":      "Whether to record each successfully defined word in the lookup history",
+	"HistoryFile":         "The location of the lookup history file",
+	"StarredFile":         "The location of the starred words file",
+	"CredentialsFile":     "The location of a file of source credentials (such as API keys), merged into the source sections below",
+	"CacheDir":            "The directory to cache source results in (relative to the home directory, if not absolute)",
+	"CacheTTL":            "How long to cache source results for (such as \"24h\"), or \"0s\" to not cache them",
+	"CacheMaxSizeMB":      "The maximum size of the cache, in megabytes (0 for no limit)",
+	"ExecSources":         "Sources provided by external commands, as a list of {\"Name\", \"Command\", \"Args\", \"Stdin\"} objects",
+	"Profiles":            "Named sets of values (such as {\"work\": {\"CACertFile\": \"...\"}}) to overlay on top of the others, selected with --profile",
+}
+
+// WriteExample writes a commented example config file to the location given by
+// TargetFileLocation, containing the default values of the global options
+// and the keys of every registered source provider's configuration. An
+// existing file is only overwritten if force is true, in which case any of its
+// keys that the example doesn't have (such as the section of a source that
+// isn't built into the app) are kept, unless it has comments, which
+// overwriting it would remove.
+func (c Configuration) WriteExample(force bool) error {
+	location := c.TargetFileLocation()
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+
+	if force {
+		existing, format, err := readFileObject(location, c.fileFormat)
+
+		if nil == err && fileHasComments(location, format) {
+			return fmt.Errorf("can't overwrite config file %q without removing its comments (move it away to write a new one)", location)
+		}
+
+		if nil == err && 0 < len(existing.keys) {
+			return c.rewriteExample(location, existing)
+		}
+	}
+
+	if !force {
+		flags |= os.O_EXCL
+	}
+
+	if err := os.MkdirAll(filepath.Dir(location), 0700); nil != err {
+		return err
+	}
+
+	file, err := os.OpenFile(location, flags, 0600)
+
+	if os.IsExist(err) {
+		return fmt.Errorf("config file %q already exists (use --force to overwrite it)", location)
+	} else if nil != err {
+		return err
+	}
+
+	contents, err := c.example()
+
+	if nil == err {
+		contents, err = encodeFileContents(detectFormat(c.fileFormat, location, nil), contents)
+	}
+
+	if nil == err {
+		_, err = file.Write(contents)
+	}
+
+	if closeErr := file.Close(); nil == err {
+		err = closeErr
+	}
+
+	return err
+}
+
+// rewriteExample writes an example config file over an existing one, given as
+// a rawObject, keeping the existing keys that the example doesn't have
+func (c Configuration) rewriteExample(location string, existing *rawObject) error {
+	contents, err := c.example()
+
+	if nil != err {
+		return err
+	}
+
+	example, err := newRawObject(contents)
+
+	if nil != err {
+		return err
+	}
+
+	for _, key := range existing.keys {
+		if _, exists := example.values[findKey(example.values, key)]; !exists {
+			example.set(key, existing.values[key])
+		}
+	}
+
+	return writeFileObject(location, detectFormat(c.fileFormat, location, nil), example)
+}
+
+// example generates the contents of an example config file
+func (c Configuration) example() ([]byte, error) {
+	var buffer bytes.Buffer
+	var defaults Configuration
+
+	if nil != c.defaults {
+		defaults = *c.defaults
+	}
+
+	buffer.WriteString("{\n")
+
+	defaultsValue := reflect.ValueOf(defaults)
+	isFirst := true
+
+	for i := 0; i < defaultsValue.NumField(); i++ {
+		field := defaultsValue.Type().Field(i)
+
+		if _, deprecated := deprecatedFields[field.Name]; deprecated || "" != field.PkgPath {
+			continue
+		}
+
+		value := defaultsValue.Field(i).Interface()
+
+		// An empty value would be provided, rather than left to the default
+		if meaningfulEmptyFields[field.Name] && "" == value {
+			value = nil
+		}
+
+		err := writeExampleValue(&buffer, exampleIndent, field.Name, fieldDescriptions[field.Name], value, isFirst)
+
+		if nil != err {
+			return nil, err
+		}
+
+		isFirst = false
+	}
+
+	providerNames := make(map[string]string)
+
+	for providerConfig, provider := range registry.Providers() {
+		providerNames[providerConfig.JSONKey()] = provider.Name()
+	}
+
+	keys := make([]string, 0, len(c.providerConfigs))
+
+	for key := range c.providerConfigs {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		configType := reflect.Indirect(reflect.ValueOf(c.providerConfigs[key])).Type()
+		description := fmt.Sprintf("The configuration of the %q source", providerNames[key])
+
+		if err := writeExampleProvider(&buffer, key, description, configType); nil != err {
+			return nil, err
+		}
+	}
+
+	buffer.WriteString("\n}\n")
+
+	return buffer.Bytes(), nil
+}
+
+// writeExampleProvider writes a provider's configuration section, with the
+// zero values of each of its exported fields, to an example config file
+func writeExampleProvider(buffer *bytes.Buffer, key string, description string, configType reflect.Type) error {
+	var fields []reflect.StructField
+
+	for i := 0; i < configType.NumField(); i++ {
+		if field := configType.Field(i); "" == field.PkgPath {
+			fields = append(fields, field)
+		}
+	}
+
+	// Skip configurations without any keys
+	if len(fields) < 1 {
+		return nil
+	}
+
+	if err := writeExampleLine(buffer, exampleIndent, commentKeyPrefix+" "+key, description, false); nil != err {
+		return err
+	}
+
+	buffer.WriteString(",\n" + exampleIndent)
+
+	if err := writeJSON(buffer, key); nil != err {
+		return err
+	}
+
+	buffer.WriteString(": {\n")
+
+	for i, field := range fields {
+		value := reflect.Zero(field.Type).Interface()
+
+		// Show maps as empty objects, rather than as null
+		if reflect.Map == field.Type.Kind() {
+			value = reflect.MakeMap(field.Type).Interface()
+		}
+
+		if err := writeExampleLine(buffer, exampleIndent+exampleIndent, field.Name, value, 0 == i); nil != err {
+			return err
+		}
+	}
+
+	buffer.WriteString("\n" + exampleIndent + "}")
+
+	return nil
+}
+
+// writeExampleValue writes a key and its value, preceded by a comment key with
+// its description (if any), to an example config file
+func writeExampleValue(buffer *bytes.Buffer, indent string, key string, description string, value interface{}, isFirst bool) error {
+	if "" != description {
+		if err := writeExampleLine(buffer, indent, commentKeyPrefix+" "+key, description, isFirst); nil != err {
+			return err
+		}
+
+		isFirst = false
+	}
+
+	return writeExampleLine(buffer, indent, key, value, isFirst)
+}
+
+// writeExampleLine writes a single key and value line to an example config
+// file, separated from any previous line
+func writeExampleLine(buffer *bytes.Buffer, indent string, key string, value interface{}, isFirst bool) error {
+	if !isFirst {
+		buffer.WriteString(",\n")
+	}
+
+	buffer.WriteString(indent)
+
+	if err := writeJSON(buffer, key); nil != err {
+		return err
+	}
+
+	buffer.WriteString(": ")
+
+	return writeJSON(buffer, value)
+}
+
+// writeJSON writes the JSON encoding of a value
+func writeJSON(buffer *bytes.Buffer, value interface{}) error {
+	encoded, err := json.Marshal(value)
+
+	if nil != err {
+		return err
+	}
+
+	buffer.Write(encoded)
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Rican7/define/internal/toml"
+	"github.com/Rican7/define/internal/yaml"
+)
+
+// fileFormat defines a format of config files
+type fileFormat int
+
+// List of the formats of config files. The zero value detects the format.
+const (
+	autoFormat fileFormat = iota
+	jsonFormat
+	tomlFormat
+	yamlFormat
+)
+
+// formatsByName maps the names of the formats of config files (as passed to
+// the config file format flag) to their formats
+var formatsByName = map[string]fileFormat{
+	"json": jsonFormat,
+	"toml": tomlFormat,
+	"yaml": yamlFormat,
+	"yml":  yamlFormat,
+}
+
+// formatsByExtension maps the file extensions of config files to their formats
+var formatsByExtension = map[string]fileFormat{
+	".json": jsonFormat,
+	".toml": tomlFormat,
+	".yaml": yamlFormat,
+	".yml":  yamlFormat,
+}
+
+// parseFileFormat parses the name of a format of config files, where an empty
+// name detects the format
+func parseFileFormat(name string) (fileFormat, error) {
+	if "" == name {
+		return autoFormat, nil
+	}
+
+	format, ok := formatsByName[strings.ToLower(name)]
+
+	if !ok {
+		return autoFormat, fmt.Errorf("invalid config file format %q (expected json, toml, or yaml)", name)
+	}
+
+	return format, nil
+}
+
+// detectFormat returns the format of a config file, unless the format is
+// forced, by its extension, or else by sniffing its contents. A JSON config
+// file must be an object (possibly preceded by comments), so anything else is
+// treated as TOML or YAML.
+func detectFormat(forced fileFormat, location string, contents []byte) fileFormat {
+	if autoFormat != forced {
+		return forced
+	}
+
+	if format, ok := formatsByExtension[strings.ToLower(filepath.Ext(location))]; ok {
+		return format
+	}
+
+	if stripped, _ := stripJSONC(contents); 0 < len(bytes.TrimSpace(stripped)) && '{' != bytes.TrimSpace(stripped)[0] {
+		return sniffFormat(bytes.TrimSpace(contents))
+	}
+
+	return jsonFormat
+}
+
+// sniffFormat distinguishes TOML from YAML contents, by the first line that
+// isn't blank or a comment. TOML begins with a table header or an "=" key,
+// while YAML begins with a ":" key or a sequence item.
+func sniffFormat(contents []byte) fileFormat {
+	for _, line := range strings.Split(string(contents), "\n") {
+		line = strings.TrimSpace(line)
+
+		if "" == line || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if '[' == line[0] {
+			return tomlFormat
+		}
+
+		if equals := strings.Index(line, "="); -1 != equals {
+			if colon := strings.Index(line, ":"); -1 == colon || equals < colon {
+				return tomlFormat
+			}
+		}
+
+		return yamlFormat
+	}
+
+	return yamlFormat
+}
+
+// decodeFileContents converts the contents of a config file into JSON, so that
+// every format is decoded through the same JSON structures. The comments and
+// trailing commas of a JSON config file are stripped (see stripJSONC).
+func decodeFileContents(forced fileFormat, location string, contents []byte) ([]byte, error) {
+	if 0 == len(bytes.TrimSpace(contents)) {
+		return contents, nil
+	}
+
+	switch detectFormat(forced, location, contents) {
+	case tomlFormat:
+		return toml.ToJSON(contents)
+	case yamlFormat:
+		return yaml.ToJSON(contents)
+	}
+
+	stripped, _ := stripJSONC(contents)
+
+	return stripped, nil
+}
+
+// encodeFileContents converts JSON into the contents of a config file of the
+// given format
+func encodeFileContents(format fileFormat, contents []byte) ([]byte, error) {
+	switch format {
+	case tomlFormat:
+		return toml.FromJSON(contents)
+	case yamlFormat:
+		return yaml.FromJSON(contents)
+	}
+
+	return contents, nil
+}
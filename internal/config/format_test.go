@@ -0,0 +1,115 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+	testData := []struct {
+		forced   fileFormat
+		location string
+		contents string
+		want     fileFormat
+	}{
+		{autoFormat, "config.json", "", jsonFormat},
+		{autoFormat, "config.json", "Key = 1", jsonFormat},
+		{autoFormat, "config.toml", "", tomlFormat},
+		{autoFormat, "config.TOML", `{"Key": 1}`, tomlFormat},
+		{autoFormat, "config.yaml", "", yamlFormat},
+		{autoFormat, "config.yml", "Key = 1", yamlFormat},
+		{autoFormat, ".define.conf", "", jsonFormat},
+		{autoFormat, ".define.conf", "\n  {\"Key\": 1}", jsonFormat},
+		{autoFormat, ".define.conf", "# A comment\nKey = 1", tomlFormat},
+		{autoFormat, ".define.conf", "// A comment\n/* Another */ {\"Key\": 1}", jsonFormat},
+		{autoFormat, ".define.conf", "[Section]", tomlFormat},
+		{autoFormat, ".define.conf", "Key = \"a: b\"", tomlFormat},
+		{autoFormat, ".define.conf", "# A comment\nKey: 1", yamlFormat},
+		{autoFormat, ".define.conf", "Key: \"a = b\"", yamlFormat},
+		{autoFormat, ".define.conf", "---\nKey: 1", yamlFormat},
+		{yamlFormat, "config.json", `{"Key": 1}`, yamlFormat},
+		{tomlFormat, "config.yaml", "", tomlFormat},
+	}
+
+	for _, data := range testData {
+		if got := detectFormat(data.forced, data.location, []byte(data.contents)); data.want != got {
+			t.Errorf("detectFormat(%d, %q, %q) returned %d, want %d", data.forced, data.location, data.contents, got, data.want)
+		}
+	}
+}
+
+func TestParseFileFormat(t *testing.T) {
+	testData := []struct {
+		name    string
+		want    fileFormat
+		wantErr bool
+	}{
+		{"", autoFormat, false},
+		{"json", jsonFormat, false},
+		{"TOML", tomlFormat, false},
+		{"yaml", yamlFormat, false},
+		{"yml", yamlFormat, false},
+		{"xml", autoFormat, true},
+	}
+
+	for _, data := range testData {
+		got, err := parseFileFormat(data.name)
+
+		if data.want != got || data.wantErr != (nil != err) {
+			t.Errorf("parseFileFormat(%q) returned %d, %v, want %d (error: %t)", data.name, got, err, data.want, data.wantErr)
+		}
+	}
+}
+
+func TestDecodeFileContents(t *testing.T) {
+	contents := "\"// IndentationSize\" = \"The indentation\"\nIndentationSize = 4\n\n[Provider]\nKey = \"value\"\n"
+
+	decoded, err := decodeFileContents(autoFormat, "config.toml", []byte(contents))
+
+	if nil != err {
+		t.Fatalf("decodeFileContents returned error %q", err)
+	}
+
+	want := `{"// IndentationSize":"The indentation","IndentationSize":4,"Provider":{"Key":"value"}}`
+
+	if want != string(decoded) {
+		t.Errorf("decodeFileContents returned %s, want %s", decoded, want)
+	}
+
+	encoded, err := encodeFileContents(tomlFormat, decoded)
+
+	if nil != err {
+		t.Fatalf("encodeFileContents returned error %q", err)
+	}
+
+	if contents != string(encoded) {
+		t.Errorf("encodeFileContents returned %q, want %q", encoded, contents)
+	}
+}
+
+func TestDecodeYAMLFileContents(t *testing.T) {
+	contents := "// IndentationSize: The indentation\nIndentationSize: 4\nProvider:\n  Key: value\n"
+
+	decoded, err := decodeFileContents(autoFormat, "config.yaml", []byte(contents))
+
+	if nil != err {
+		t.Fatalf("decodeFileContents returned error %q", err)
+	}
+
+	want := `{"// IndentationSize":"The indentation","IndentationSize":4,"Provider":{"Key":"value"}}`
+
+	if want != string(decoded) {
+		t.Errorf("decodeFileContents returned %s, want %s", decoded, want)
+	}
+
+	encoded, err := encodeFileContents(yamlFormat, decoded)
+
+	if nil != err {
+		t.Fatalf("encodeFileContents returned error %q", err)
+	}
+
+	if contents != string(encoded) {
+		t.Errorf("encodeFileContents returned %q, want %q", encoded, contents)
+	}
+}
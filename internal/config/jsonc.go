@@ -0,0 +1,82 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import "bytes"
+
+// jsoncExtension defines a use of the syntax that JSON with comments (JSONC)
+// allows beyond standard JSON, by its offset in the contents
+type jsoncExtension struct {
+	offset int64
+	syntax string
+}
+
+// stripJSONC converts JSON with comments (JSONC) into standard JSON, returning
+// the converted contents and the uses of the JSONC syntax that were stripped.
+//
+// Line ("//") and block ("/* */") comments and trailing commas are replaced
+// with spaces (keeping any line breaks), so that the offsets, and so the lines
+// and columns, of the rest of the contents are unchanged. Comment-like
+// sequences within strings, such as those of URLs, are left alone.
+func stripJSONC(contents []byte) ([]byte, []jsoncExtension) {
+	var extensions []jsoncExtension
+
+	stripped := contents
+	inString := false
+	pendingComma := -1
+
+	blank := func(start int, end int) {
+		// Only copy the contents once they're actually changed
+		if 0 == len(extensions) {
+			stripped = append([]byte(nil), contents...)
+		}
+
+		for i := start; i < end; i++ {
+			if '\n' != stripped[i] && '\r' != stripped[i] {
+				stripped[i] = ' '
+			}
+		}
+	}
+
+	for i := 0; i < len(contents); i++ {
+		char := contents[i]
+
+		switch {
+		case inString:
+			if '\\' == char {
+				i++
+			} else if '"' == char {
+				inString = false
+			}
+		case '/' == char && i+1 < len(contents) && ('/' == contents[i+1] || '*' == contents[i+1]):
+			end := len(contents)
+
+			if '/' == contents[i+1] {
+				if lineEnd := bytes.IndexByte(contents[i:], '\n'); -1 != lineEnd {
+					end = i + lineEnd
+				}
+			} else if closing := bytes.Index(contents[i+2:], []byte("*/")); -1 != closing {
+				end = i + 2 + closing + 2
+			}
+
+			blank(i, end)
+			extensions = append(extensions, jsoncExtension{offset: int64(i), syntax: "a comment"})
+
+			i = end - 1
+		case ' ' == char || '\t' == char || '\n' == char || '\r' == char:
+			// Whitespace (like comments) may follow a trailing comma
+		case ',' == char:
+			pendingComma = i
+		default:
+			if -1 != pendingComma && ('}' == char || ']' == char) {
+				blank(pendingComma, pendingComma+1)
+				extensions = append(extensions, jsoncExtension{offset: int64(pendingComma), syntax: "a trailing comma"})
+			}
+
+			inString = '"' == char
+			pendingComma = -1
+		}
+	}
+
+	return stripped, extensions
+}
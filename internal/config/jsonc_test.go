@@ -0,0 +1,98 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestStripJSONC(t *testing.T) {
+	testData := []struct {
+		contents string
+		want     string
+		syntaxes []string
+	}{
+		{
+			`{"Key": 1}`,
+			`{"Key": 1}`,
+			nil,
+		},
+		{
+			"{\n  // The personal account\n  \"Key\": 1, // Trailing\n}",
+			"{\n                         \n  \"Key\": 1             \n}",
+			[]string{"a comment", "a comment", "a trailing comma"},
+		},
+		{
+			"{/* A\nblock */\"Key\": [1, 2,],}",
+			"{    \n        \"Key\": [1, 2 ] }",
+			[]string{"a comment", "a trailing comma", "a trailing comma"},
+		},
+		{
+			`{"URL": "https://example.com/*path*/", "Quoted": "a \"//b\" c"}`,
+			`{"URL": "https://example.com/*path*/", "Quoted": "a \"//b\" c"}`,
+			nil,
+		},
+		{
+			`{"Key": "a,"}`,
+			`{"Key": "a,"}`,
+			nil,
+		},
+		{
+			`{"Key": 1} /* unclosed`,
+			`{"Key": 1}            `,
+			[]string{"a comment"},
+		},
+	}
+
+	for _, data := range testData {
+		stripped, extensions := stripJSONC([]byte(data.contents))
+
+		if data.want != string(stripped) {
+			t.Errorf("stripJSONC(%q) returned %q, want %q", data.contents, stripped, data.want)
+		}
+
+		var syntaxes []string
+
+		for _, extension := range extensions {
+			syntaxes = append(syntaxes, extension.syntax)
+		}
+
+		if !reflect.DeepEqual(data.syntaxes, syntaxes) {
+			t.Errorf("stripJSONC(%q) stripped %q, want %q", data.contents, syntaxes, data.syntaxes)
+		}
+	}
+}
+
+func TestValidateFileStrict(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-config")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	location := filepath.Join(dir, "config.json")
+	contents := "{\n  // The work account\n  \"IndentationSize\": 4,\n}\n"
+
+	if err := ioutil.WriteFile(location, []byte(contents), 0600); nil != err {
+		t.Fatal(err)
+	}
+
+	if problems, err := (Configuration{}).ValidateFile(location); nil != err || 0 != len(problems) {
+		t.Errorf("ValidateFile returned %q and error %v, want no problems", problems, err)
+	}
+
+	want := []Problem{
+		{Line: 2, Column: 3, Message: "a comment isn't allowed in strict JSON"},
+		{Line: 3, Column: 23, Message: "a trailing comma isn't allowed in strict JSON"},
+	}
+
+	if problems, err := (Configuration{}).ValidateFileStrict(location); nil != err || !reflect.DeepEqual(want, problems) {
+		t.Errorf("ValidateFileStrict returned %q and error %v, want %q", problems, err, want)
+	}
+}
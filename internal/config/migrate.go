@@ -0,0 +1,249 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// keyMigration defines the renaming of a deprecated config file key, by the
+// dotted key paths of the key and its replacement (such as from
+// "OxfordDictionary.AppID" to "OxfordDictionary.ApplicationID"). A key is only
+// ever renamed within its section, or is itself a section that's renamed.
+type keyMigration struct {
+	From string
+	To   string
+}
+
+// keyMigrations is the table of the renamed config file keys, which are
+// migrated to their replacements when a config file is loaded, so that
+// existing config files keep working. A key is added here when it's renamed.
+var keyMigrations []keyMigration
+
+// migrateKeys renames the deprecated keys of a config file's (JSON) contents,
+// where a replacement that's also set takes priority over its deprecated key
+func migrateKeys(fileContents []byte) ([]byte, error) {
+	if len(keyMigrations) < 1 {
+		return fileContents, nil
+	}
+
+	// Contents that aren't an object are reported when unmarshalled
+	object, err := newRawObject(fileContents)
+
+	if nil != err {
+		return fileContents, nil
+	}
+
+	if migrated, err := migrateObject(object, ""); nil != err || len(migrated) < 1 {
+		return fileContents, err
+	}
+
+	return json.Marshal(object)
+}
+
+// migrateObject renames the deprecated keys of a config file's object (and of
+// the objects of its profiles), returning the migrations that were applied,
+// by their key paths qualified by the given prefix
+func migrateObject(object *rawObject, keyPrefix string) ([]keyMigration, error) {
+	var migrated []keyMigration
+
+	for _, migration := range keyMigrations {
+		keys := strings.Split(migration.From, keyPathSeparator)
+		renamed := false
+
+		switch len(keys) {
+		case 1:
+			renamed = object.rename(keys[0], migration.newName())
+		case 2:
+			section := findKey(object.values, keys[0])
+			raw, exists := object.values[section]
+
+			if !exists || !isJSONObject(raw) {
+				continue
+			}
+
+			sectionObject, err := newRawObject(raw)
+
+			if nil != err {
+				return nil, fmt.Errorf("error reading key %q: %s", keyPrefix+section, err)
+			}
+
+			if renamed = sectionObject.rename(keys[1], migration.newName()); renamed {
+				if object.values[section], err = json.Marshal(sectionObject); nil != err {
+					return nil, err
+				}
+			}
+		}
+
+		if renamed {
+			migrated = append(migrated, keyMigration{From: keyPrefix + migration.From, To: keyPrefix + migration.To})
+		}
+	}
+
+	profiles := findKey(object.values, profilesKey)
+
+	if raw, exists := object.values[profiles]; "" != keyPrefix || !exists || !isJSONObject(raw) {
+		return migrated, nil
+	}
+
+	profilesObject, err := newRawObject(object.values[profiles])
+
+	if nil != err {
+		return nil, fmt.Errorf("error reading key %q: %s", profiles, err)
+	}
+
+	profilesMigrated := false
+
+	for _, name := range profilesObject.keys {
+		if !isJSONObject(profilesObject.values[name]) {
+			continue
+		}
+
+		profileObject, err := newRawObject(profilesObject.values[name])
+
+		if nil != err {
+			return nil, fmt.Errorf("error reading key %q: %s", profiles+keyPathSeparator+name, err)
+		}
+
+		profileMigrated, err := migrateObject(profileObject, profiles+keyPathSeparator+name+keyPathSeparator)
+
+		if nil != err {
+			return nil, err
+		} else if len(profileMigrated) < 1 {
+			continue
+		}
+
+		if profilesObject.values[name], err = json.Marshal(profileObject); nil != err {
+			return nil, err
+		}
+
+		migrated = append(migrated, profileMigrated...)
+		profilesMigrated = true
+	}
+
+	if profilesMigrated {
+		if object.values[profiles], err = json.Marshal(profilesObject); nil != err {
+			return nil, err
+		}
+	}
+
+	return migrated, nil
+}
+
+// newName returns the new name of the migration's key, within its section
+func (m keyMigration) newName() string {
+	return m.To[strings.LastIndex(m.To, keyPathSeparator)+1:]
+}
+
+// findMigration returns the migration of a deprecated key, by its key path
+// (within any profile), and whether the key is deprecated at all
+func findMigration(keyPath string) (keyMigration, bool) {
+	for _, migration := range keyMigrations {
+		if strings.EqualFold(migration.From, keyPath) {
+			return migration, true
+		}
+	}
+
+	return keyMigration{}, false
+}
+
+// findDeprecatedField returns the field replacing the deprecated option of a
+// key (see deprecatedFields), and whether the key's option is deprecated at all
+func findDeprecatedField(key string) (string, bool) {
+	for field, replacement := range deprecatedFields {
+		if strings.EqualFold(field, key) {
+			return replacement, true
+		}
+	}
+
+	return "", false
+}
+
+// deprecatedKeyProblem returns the warning of a deprecated key (qualified by
+// its sections), noting whether its replacement is also set
+func deprecatedKeyProblem(qualifiedKey string, migration keyMigration, replaced bool) Problem {
+	if replaced {
+		return Problem{Message: fmt.Sprintf("deprecated key %q is ignored, as its new name %q is also set", qualifiedKey, migration.newName()), Warning: true}
+	}
+
+	return Problem{Message: fmt.Sprintf("deprecated key %q has been renamed to %q (use --migrate-config to rename it in the file)", qualifiedKey, migration.newName()), Warning: true}
+}
+
+// MigrateFile renames the deprecated keys of the config file at the location
+// given by TargetFileLocation to their current names, preserving the rest of
+// the file, and returns the renamed keys (mapped to their new names). The file
+// is left untouched if it has no deprecated keys, and isn't rewritten if it has
+// comments, which rewriting it would remove.
+func (c Configuration) MigrateFile() (map[string]string, error) {
+	location := c.TargetFileLocation()
+	fileObject, format, err := readFileObject(location, c.fileFormat)
+
+	if nil != err {
+		return nil, err
+	}
+
+	migrated, err := migrateObject(fileObject, "")
+
+	if nil != err || len(migrated) < 1 {
+		return nil, err
+	}
+
+	if fileHasComments(location, format) {
+		return nil, fmt.Errorf("can't migrate config file %q without removing its comments (rename its deprecated keys by editing the file instead)", location)
+	}
+
+	renamed := make(map[string]string, len(migrated))
+
+	for _, migration := range migrated {
+		renamed[migration.From] = migration.To
+	}
+
+	return renamed, writeFileObject(location, format, fileObject)
+}
+
+// rename renames a key of the object (and its comment key), keeping its place,
+// or removes it if the new key is already set, returning whether the object
+// had the key
+func (o *rawObject) rename(key string, newKey string) bool {
+	key = findKey(o.values, key)
+
+	if _, exists := o.values[key]; !exists {
+		return false
+	}
+
+	for _, keys := range [][2]string{{key, newKey}, {commentKeyPrefix + " " + key, commentKeyPrefix + " " + newKey}} {
+		if _, exists := o.values[keys[0]]; !exists {
+			continue
+		}
+
+		if _, exists := o.values[findKey(o.values, keys[1])]; exists {
+			o.remove(keys[0])
+			continue
+		}
+
+		for i := range o.keys {
+			if keys[0] == o.keys[i] {
+				o.keys[i] = keys[1]
+			}
+		}
+
+		o.values[keys[1]] = o.values[keys[0]]
+		delete(o.values, keys[0])
+	}
+
+	return true
+}
+
+// remove removes a key of the object
+func (o *rawObject) remove(key string) {
+	for i := range o.keys {
+		if key == o.keys[i] {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+
+	delete(o.values, key)
+}
@@ -0,0 +1,168 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testKeyMigrations are the key migrations used by the tests, in place of
+// the actual table
+var testKeyMigrations = []keyMigration{
+	{From: "DefaultSource", To: "Source"},
+	{From: "OxfordDictionary.ApplicationKey", To: "OxfordDictionary.AppKey"},
+}
+
+func TestParseFileConfigMigratesKeys(t *testing.T) {
+	defer func(migrations []keyMigration) { keyMigrations = migrations }(keyMigrations)
+
+	keyMigrations = testKeyMigrations
+
+	testData := []struct {
+		contents string
+		profile  string
+		want     string
+	}{
+		{`{"DefaultSource": "old"}`, "", "old"},
+		{`{"defaultsource": "old"}`, "", "old"},
+		{`{"DefaultSource": "old", "Source": "new"}`, "", "new"},
+		{`{"Source": "new", "DefaultSource": "old"}`, "", "new"},
+		{`{"Source": "new", "Profiles": {"work": {"DefaultSource": "work"}}}`, "work", "work"},
+		{`{"Source": "new", "Profiles": {"work": {"DefaultSource": "work"}}}`, "", "new"},
+	}
+
+	for _, data := range testData {
+		conf, err := parseFileConfig("config.json", autoFormat, []byte(data.contents), data.profile)
+
+		if nil != err {
+			t.Fatalf("parseFileConfig(%s) returned error %q", data.contents, err)
+		}
+
+		if data.want != conf.Source {
+			t.Errorf("parseFileConfig(%s) with profile %q loaded the source %q, want %q", data.contents, data.profile, conf.Source, data.want)
+		}
+
+		if !conf.provided["Source"] {
+			t.Errorf("parseFileConfig(%s) didn't mark the migrated key as provided", data.contents)
+		}
+	}
+}
+
+func TestMigrateKeysOfSections(t *testing.T) {
+	defer func(migrations []keyMigration) { keyMigrations = migrations }(keyMigrations)
+
+	keyMigrations = testKeyMigrations
+
+	testData := map[string]string{
+		`{"OxfordDictionary": {"AppID": "id", "ApplicationKey": "key"}}`:                   `{"OxfordDictionary":{"AppID":"id","AppKey":"key"}}`,
+		`{"OxfordDictionary": {"ApplicationKey": "old", "AppKey": "new"}}`:                 `{"OxfordDictionary":{"AppKey":"new"}}`,
+		`{"OxfordDictionary": "invalid", "ApplicationKey": "top-level"}`:                   `{"OxfordDictionary": "invalid", "ApplicationKey": "top-level"}`,
+		`{"// DefaultSource": "The source", "DefaultSource": "old", "IndentationSize": 2}`: `{"// Source":"The source","Source":"old","IndentationSize":2}`,
+	}
+
+	for contents, want := range testData {
+		if migrated, err := migrateKeys([]byte(contents)); nil != err || want != string(migrated) {
+			t.Errorf("migrateKeys(%s) returned %s and error %v, want %s", contents, migrated, err, want)
+		}
+	}
+}
+
+func TestMigrateFile(t *testing.T) {
+	defer func(migrations []keyMigration) { keyMigrations = migrations }(keyMigrations)
+
+	keyMigrations = testKeyMigrations
+
+	dir, err := ioutil.TempDir("", "define-config")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	location := filepath.Join(dir, "config.json")
+	contents := `{"IndentationSize": 4, "DefaultSource": "old", "Profiles": {"work": {"Source": "new", "DefaultSource": "old"}}}`
+	want := "{\n    \"IndentationSize\": 4,\n    \"Source\": \"old\",\n    \"Profiles\": {\n        \"work\": {\n            \"Source\": \"new\"\n        }\n    }\n}\n"
+
+	if err := ioutil.WriteFile(location, []byte(contents), 0600); nil != err {
+		t.Fatal(err)
+	}
+
+	conf := Configuration{targetFileLocation: location}
+	renamed, err := conf.MigrateFile()
+
+	if nil != err {
+		t.Fatalf("MigrateFile returned error %q", err)
+	}
+
+	wantRenamed := map[string]string{"DefaultSource": "Source", "Profiles.work.DefaultSource": "Profiles.work.Source"}
+
+	if !reflect.DeepEqual(wantRenamed, renamed) {
+		t.Errorf("MigrateFile renamed %q, want %q", renamed, wantRenamed)
+	}
+
+	if written, _ := ioutil.ReadFile(location); want != string(written) {
+		t.Errorf("MigrateFile wrote %q, want %q", written, want)
+	}
+
+	if renamed, err = conf.MigrateFile(); nil != err || 0 != len(renamed) {
+		t.Errorf("MigrateFile of a migrated file renamed %q, with error %v", renamed, err)
+	}
+
+	commented := "{\n  // the old name\n  \"DefaultSource\": \"old\"\n}\n"
+
+	if err := ioutil.WriteFile(location, []byte(commented), 0600); nil != err {
+		t.Fatal(err)
+	}
+
+	if _, err = conf.MigrateFile(); nil == err || !strings.Contains(err.Error(), "comments") {
+		t.Errorf("MigrateFile of a file with comments returned error %v, want an error about the comments", err)
+	}
+
+	if written, _ := ioutil.ReadFile(location); commented != string(written) {
+		t.Errorf("MigrateFile changed a file with comments to %q", written)
+	}
+}
+
+func TestValidateFileDeprecatedKeys(t *testing.T) {
+	defer func(migrations []keyMigration) { keyMigrations = migrations }(keyMigrations)
+
+	keyMigrations = testKeyMigrations
+
+	dir, err := ioutil.TempDir("", "define-config")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	testData := map[string]string{
+		`{"DefaultSource": "old"}`:                  `deprecated key "DefaultSource" has been renamed to "Source" (use --migrate-config to rename it in the file)`,
+		`{"DefaultSource": "old", "Source": "new"}`: `deprecated key "DefaultSource" is ignored, as its new name "Source" is also set`,
+		`{"noexamples": true}`:                      `deprecated key "noexamples" is an alias of "ShowExamples", which wins if both are set`,
+	}
+
+	for contents, want := range testData {
+		location := filepath.Join(dir, "config.json")
+
+		if err := ioutil.WriteFile(location, []byte(contents), 0600); nil != err {
+			t.Fatal(err)
+		}
+
+		problems, err := Configuration{}.ValidateFile(location)
+
+		if nil != err {
+			t.Fatalf("ValidateFile(%s) returned error %q", contents, err)
+		}
+
+		if 1 != len(problems) || want != problems[0].Message || !problems[0].Warning {
+			t.Errorf("ValidateFile(%s) returned %q, want the warning %q", contents, problems, want)
+		}
+	}
+}
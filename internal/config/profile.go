@@ -0,0 +1,111 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// profilesKey is the key of the profiles in a config file
+const profilesKey = "Profiles"
+
+// profileKey is the (comment) key of the active profile in the marshalled
+// configuration
+const profileKey = commentKeyPrefix + " profile"
+
+// applyProfile overlays the values of the named profile of a config file's
+// (JSON) contents on top of the file's other values, returning the contents
+// and whether the file has the profile
+func applyProfile(fileContents []byte, profile string) ([]byte, bool, error) {
+	var configMap map[string]json.RawMessage
+	var profiles map[string]json.RawMessage
+
+	if "" == profile || nil != json.Unmarshal(fileContents, &configMap) {
+		return fileContents, false, nil
+	}
+
+	// The profiles are checked when the contents are unmarshalled
+	if nil != json.Unmarshal(configMap[findKey(configMap, profilesKey)], &profiles) {
+		return fileContents, false, nil
+	}
+
+	overlay, exists := profiles[profile]
+
+	if !exists {
+		return fileContents, false, nil
+	}
+
+	overlaid, err := overlayJSON(fileContents, overlay)
+
+	if nil != err {
+		return nil, true, fmt.Errorf("profile %q: %s", profile, err)
+	}
+
+	return overlaid, true, nil
+}
+
+// overlayJSON overlays the values of a JSON object on top of another's, where
+// the values of keys that are objects in both are overlaid in turn (so that
+// a single key of a source's section can be overlaid), and the other values
+// are replaced. Keys are matched case-insensitively, as when unmarshalled.
+func overlayJSON(base []byte, overlay []byte) ([]byte, error) {
+	var baseMap, overlayMap map[string]json.RawMessage
+
+	if err := json.Unmarshal(base, &baseMap); nil != err {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(overlay, &overlayMap); nil != err {
+		return nil, err
+	}
+
+	for key, value := range overlayMap {
+		baseKey := findKey(baseMap, key)
+
+		if isJSONObject(baseMap[baseKey]) && isJSONObject(value) {
+			overlaid, err := overlayJSON(baseMap[baseKey], value)
+
+			if nil != err {
+				return nil, err
+			}
+
+			value = overlaid
+		}
+
+		delete(baseMap, baseKey)
+		baseMap[key] = value
+	}
+
+	return json.Marshal(baseMap)
+}
+
+// isJSONObject returns whether a raw JSON value is an object
+func isJSONObject(raw json.RawMessage) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{"))
+}
+
+// unknownProfileError returns the error of a profile that isn't in any of the
+// config files, listing the given names of the profiles that are
+func unknownProfileError(profile string, names map[string]bool) error {
+	if len(names) < 1 {
+		return fmt.Errorf("unknown profile %q (no profiles are defined in the config file)", profile)
+	}
+
+	available := make([]string, 0, len(names))
+
+	for name := range names {
+		available = append(available, name)
+	}
+
+	sort.Strings(available)
+
+	return fmt.Errorf("unknown profile %q (must be one of %q)", profile, available)
+}
+
+// Profile returns the name of the active profile of the config file, if any.
+func (c Configuration) Profile() string {
+	return c.profile
+}
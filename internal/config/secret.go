@@ -0,0 +1,118 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Rican7/define/internal/keyring"
+)
+
+// resolveKeyringReferences replaces the values of a config file's (JSON)
+// contents that reference secrets stored in the keyring with the secrets,
+// returning the resolved contents and the secrets by their dotted key paths.
+// Both the top-level keys and the keys of the source provider sections are
+// resolved.
+func resolveKeyringReferences(contents []byte) ([]byte, map[string]string, error) {
+	var configMap map[string]interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(contents))
+	decoder.UseNumber()
+
+	// Contents that aren't an object are left to fail when they're unmarshalled
+	if err := decoder.Decode(&configMap); nil != err {
+		return contents, nil, nil
+	}
+
+	secrets := make(map[string]string)
+
+	resolve := func(object map[string]interface{}, key string, keyPath string) error {
+		value, ok := object[key].(string)
+
+		if !ok {
+			return nil
+		}
+
+		name, isReference := keyring.ParseReference(value)
+
+		if !isReference {
+			return nil
+		}
+
+		secret, err := keyring.Get(name)
+
+		if nil != err {
+			return fmt.Errorf("key %q references the secret %q, which couldn't be read from the keyring: %s", keyPath, name, err)
+		}
+
+		object[key] = secret
+		secrets[keyPath] = secret
+
+		return nil
+	}
+
+	for key, value := range configMap {
+		if err := resolve(configMap, key, key); nil != err {
+			return nil, nil, err
+		}
+
+		if section, ok := value.(map[string]interface{}); ok {
+			for sectionKey := range section {
+				if err := resolve(section, sectionKey, key+keyPathSeparator+sectionKey); nil != err {
+					return nil, nil, err
+				}
+			}
+		}
+	}
+
+	if len(secrets) < 1 {
+		return contents, nil, nil
+	}
+
+	resolved, err := json.Marshal(configMap)
+
+	return resolved, secrets, err
+}
+
+// redactSecrets replaces the values of a marshalled configuration that are
+// still the given secrets (by their dotted key paths) with a placeholder, so
+// that secrets stored outside of the config file are never output
+func redactSecrets(encoded []byte, secrets map[string]string, placeholder string) ([]byte, error) {
+	if len(secrets) < 1 {
+		return encoded, nil
+	}
+
+	var configMap map[string]interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&configMap); nil != err {
+		return nil, err
+	}
+
+	for keyPath, secret := range secrets {
+		keys := strings.SplitN(keyPath, keyPathSeparator, 2)
+		object, key := configMap, keys[0]
+
+		if 2 == len(keys) {
+			section, ok := configMap[keys[0]].(map[string]interface{})
+
+			if !ok {
+				continue
+			}
+
+			object, key = section, keys[1]
+		}
+
+		// A value that was overridden (such as by a flag) isn't the secret
+		if secret == object[key] {
+			object[key] = placeholder
+		}
+	}
+
+	return json.Marshal(configMap)
+}
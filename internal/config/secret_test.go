@@ -0,0 +1,67 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"testing"
+
+	"github.com/Rican7/define/internal/keyring"
+	gokeyring "github.com/zalando/go-keyring"
+)
+
+func TestResolveKeyringReferences(t *testing.T) {
+	gokeyring.MockInit()
+
+	if err := keyring.Set("OxfordDictionary.AppKey", "secret-key"); nil != err {
+		t.Fatalf("storing the secret failed: %s", err)
+	}
+
+	contents := `{"Source":"keychain:OxfordDictionary.AppKey","OxfordDictionary":{"AppID":"id","AppKey":"keyring:OxfordDictionary.AppKey"}}`
+
+	resolved, secrets, err := resolveKeyringReferences([]byte(contents))
+
+	if nil != err {
+		t.Fatalf("resolveKeyringReferences returned error %q", err)
+	}
+
+	want := `{"OxfordDictionary":{"AppID":"id","AppKey":"secret-key"},"Source":"secret-key"}`
+
+	if want != string(resolved) {
+		t.Errorf("resolveKeyringReferences returned %s, want %s", resolved, want)
+	}
+
+	if 2 != len(secrets) || "secret-key" != secrets["OxfordDictionary.AppKey"] {
+		t.Errorf("resolveKeyringReferences returned the secrets %q", secrets)
+	}
+
+	// A value that was overridden (such as by a flag) is output as it is
+	encoded := `{"OxfordDictionary":{"AppID":"id","AppKey":"secret-key"},"Source":"overridden"}`
+
+	redacted, err := redactSecrets([]byte(encoded), secrets, keyring.Placeholder)
+
+	if nil != err {
+		t.Fatalf("redactSecrets returned error %q", err)
+	}
+
+	want = `{"OxfordDictionary":{"AppID":"id","AppKey":"` + keyring.Placeholder + `"},"Source":"overridden"}`
+
+	if want != string(redacted) {
+		t.Errorf("redactSecrets returned %s, want %s", redacted, want)
+	}
+}
+
+func TestResolveKeyringReferencesErrors(t *testing.T) {
+	gokeyring.MockInit()
+
+	plain := `{"OxfordDictionary":{"AppKey":"plaintext"}}`
+
+	if resolved, secrets, err := resolveKeyringReferences([]byte(plain)); nil != err || plain != string(resolved) || 0 != len(secrets) {
+		t.Errorf("resolveKeyringReferences(%s) returned %s, %q, %v, want the contents unchanged", plain, resolved, secrets, err)
+	}
+
+	missing := `{"OxfordDictionary":{"AppKey":"keyring:missing"}}`
+
+	if _, _, err := resolveKeyringReferences([]byte(missing)); nil == err {
+		t.Errorf("resolveKeyringReferences(%s) didn't return an error", missing)
+	}
+}
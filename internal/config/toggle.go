@@ -0,0 +1,105 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Toggle defines a configurable boolean whose "off" is as meaningful as its
+// "on", so that an explicit false (such as in the config file) isn't mistaken
+// for an unset value and overridden when merging. It's represented as a
+// boolean when used as a flag or in JSON, or as null in JSON when unset.
+type Toggle uint8
+
+// List of toggle states
+const (
+	ToggleUnset Toggle = iota
+	ToggleOff
+	ToggleOn
+)
+
+// NewToggle returns a toggle that's set to the given boolean
+func NewToggle(on bool) Toggle {
+	if on {
+		return ToggleOn
+	}
+
+	return ToggleOff
+}
+
+// IsSet returns whether the toggle is set
+func (t Toggle) IsSet() bool {
+	return ToggleUnset != t
+}
+
+// Or returns whether the toggle is on, or else the given fallback if it's
+// unset
+func (t Toggle) Or(fallback bool) bool {
+	if !t.IsSet() {
+		return fallback
+	}
+
+	return ToggleOn == t
+}
+
+// String returns the toggle's string form
+func (t *Toggle) String() string {
+	if !t.IsSet() {
+		return ""
+	}
+
+	return strconv.FormatBool(t.Or(false))
+}
+
+// Set sets the toggle from its string form
+func (t *Toggle) Set(value string) error {
+	on, err := ParseBool(value)
+
+	if nil != err {
+		return err
+	}
+
+	*t = NewToggle(on)
+
+	return nil
+}
+
+// Type returns the type name of the toggle, for flag usage
+func (t *Toggle) Type() string {
+	return "bool"
+}
+
+// IsBoolFlag returns true, so that the toggle's flag can be passed without a
+// value to turn it on
+func (t *Toggle) IsBoolFlag() bool {
+	return true
+}
+
+// MarshalJSON defines how the toggle should be JSON marshalled.
+func (t Toggle) MarshalJSON() ([]byte, error) {
+	if !t.IsSet() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(t.Or(false))
+}
+
+// UnmarshalJSON defines how the toggle should be JSON unmarshalled.
+func (t *Toggle) UnmarshalJSON(data []byte) error {
+	var value *bool
+
+	if err := json.Unmarshal(data, &value); nil != err {
+		return fmt.Errorf("toggle must be true, false, or null: %s", err)
+	}
+
+	*t = ToggleUnset
+
+	if nil != value {
+		*t = NewToggle(*value)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestToggleJSON(t *testing.T) {
+	testData := []struct {
+		encoded string
+		want    Toggle
+	}{
+		{"null", ToggleUnset},
+		{"false", ToggleOff},
+		{"true", ToggleOn},
+	}
+
+	for _, data := range testData {
+		var got Toggle
+
+		if err := json.Unmarshal([]byte(data.encoded), &got); nil != err || data.want != got {
+			t.Errorf("unmarshalling %s returned %d, %v, want %d", data.encoded, got, err, data.want)
+		}
+
+		if encoded, err := json.Marshal(got); nil != err || data.encoded != string(encoded) {
+			t.Errorf("marshalling %d returned %s, %v, want %s", got, encoded, err, data.encoded)
+		}
+	}
+
+	var toggle Toggle
+
+	if err := json.Unmarshal([]byte(`"yes"`), &toggle); nil == err {
+		t.Error("unmarshalling a string didn't return an error")
+	}
+}
+
+func TestToggleOr(t *testing.T) {
+	if !ToggleUnset.Or(true) || ToggleUnset.Or(false) {
+		t.Error("an unset toggle didn't return the fallback")
+	}
+
+	if ToggleOff.Or(true) || !ToggleOn.Or(false) {
+		t.Error("a set toggle returned the fallback")
+	}
+}
+
+func TestToggleMerge(t *testing.T) {
+	// An explicit "off" takes priority over a lower priority "on"
+	merged, err := mergeConfigurations(
+		Configuration{},
+		Configuration{ShowExamples: ToggleOff},
+		Configuration{ShowExamples: ToggleOn, HideThesaurus: ToggleOn},
+	)
+
+	if nil != err {
+		t.Fatalf("mergeConfigurations returned error %q", err)
+	}
+
+	if ToggleOff != merged.ShowExamples || ToggleOn != merged.HideThesaurus {
+		t.Errorf("mergeConfigurations merged ShowExamples %d and HideThesaurus %d", merged.ShowExamples, merged.HideThesaurus)
+	}
+}
@@ -0,0 +1,515 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Rican7/define/internal/io/printer"
+	"github.com/Rican7/define/internal/toml"
+	"github.com/Rican7/define/internal/yaml"
+	"github.com/Rican7/define/registry"
+	"github.com/Rican7/define/source"
+)
+
+// maxIndentationSize is the largest sane indentation size of output
+const maxIndentationSize = 16
+
+// Problem defines a problem found when validating a configuration
+type Problem struct {
+	// File is the location of the config file with the problem, when it
+	// needs to be named (such as when several config files were loaded)
+	File string
+
+	// Line and Column locate the problem in the file, when known (otherwise
+	// they're 0)
+	Line   int
+	Column int
+
+	Message string
+
+	// Warning is whether the problem still allows the app to run, such as an
+	// unknown key that's ignored
+	Warning bool
+}
+
+// String returns the problem as a printable string
+func (p Problem) String() string {
+	var prefix string
+
+	if p.Warning {
+		prefix = "warning: "
+	}
+
+	if "" != p.File {
+		prefix += p.File + ": "
+	}
+
+	if 0 < p.Line && 0 < p.Column {
+		prefix += fmt.Sprintf("line %d, column %d: ", p.Line, p.Column)
+	} else if 0 < p.Line {
+		prefix += fmt.Sprintf("line %d: ", p.Line)
+	}
+
+	return prefix + p.Message
+}
+
+// Validate validates the semantic constraints of the configuration's values,
+// beyond their types, returning any problems found.
+func (c Configuration) Validate() []Problem {
+	var problems []Problem
+
+	if maxIndentationSize < c.IndentationSize {
+		problems = append(problems, Problem{Message: fmt.Sprintf(
+			"IndentationSize %d is too large (the maximum is %d)",
+			c.IndentationSize,
+			maxIndentationSize,
+		)})
+	}
+
+	if _, err := printer.ParseHeadwordCase(c.HeadwordCase); nil != err {
+		problems = append(problems, Problem{Message: fmt.Sprintf("HeadwordCase: %s", err)})
+	}
+
+	if _, err := source.ParseNormalization(c.Normalization); nil != err {
+		problems = append(problems, Problem{Message: fmt.Sprintf("Normalization: %s", err)})
+	}
+
+	switch c.OutputFormat {
+	case "", OutputFormatText, OutputFormatPorcelain, OutputFormatJSON:
+	default:
+		problems = append(problems, Problem{Message: fmt.Sprintf(
+			"OutputFormat %q is unknown (expected %q, %q, or %q)",
+			c.OutputFormat,
+			OutputFormatText,
+			OutputFormatPorcelain,
+			OutputFormatJSON,
+		)})
+	}
+
+	if theme, err := c.EffectiveTheme(); nil != err {
+		problems = append(problems, Problem{Message: fmt.Sprintf("ThemeName: %s", err)})
+	} else {
+		for _, err := range theme.Validate() {
+			problems = append(problems, Problem{Message: fmt.Sprintf("Theme: %s", err)})
+		}
+	}
+
+	if c.Timeout < 0 {
+		problems = append(problems, Problem{Message: fmt.Sprintf("Timeout %s can't be negative", time.Duration(c.Timeout))})
+	}
+
+	if c.PerSourceTimeout < 0 {
+		problems = append(problems, Problem{Message: fmt.Sprintf("PerSourceTimeout %s can't be negative", time.Duration(c.PerSourceTimeout))})
+	}
+
+	if expiry, err := time.ParseDuration(c.CacheTTL); "" != c.CacheTTL && nil != err {
+		problems = append(problems, Problem{Message: fmt.Sprintf("CacheTTL %q isn't a valid duration (such as \"24h\")", c.CacheTTL)})
+	} else if expiry < 0 {
+		problems = append(problems, Problem{Message: fmt.Sprintf("CacheTTL %s can't be negative", expiry)})
+	}
+
+	return append(problems, c.credentialsFileProblems()...)
+}
+
+// ValidateFile validates a loaded configuration file (see ConfigFiles) at the
+// given location against the application's configuration structure and the
+// source provider configurations, returning any problems found. The sources
+// of any provider configurations with values set in the file are also checked
+// to be able to be provided with the effective configuration (to find any
+// missing required keys). An error is only returned if the file couldn't be
+// read.
+func (c Configuration) ValidateFile(location string) ([]Problem, error) {
+	contents, format, err := c.readLoadedFile(location)
+
+	if nil != err {
+		return nil, err
+	}
+
+	// An empty file is treated as an empty configuration when loaded
+	if 0 == len(bytes.TrimSpace(contents)) {
+		return nil, nil
+	}
+
+	if contents, err = decodeFileContents(format, location, contents); nil != err {
+		return []Problem{newDecodeProblem(contents, "", err)}, nil
+	}
+
+	var configMap map[string]json.RawMessage
+
+	if err = json.Unmarshal(contents, &configMap); nil != err {
+		return []Problem{newDecodeProblem(contents, "", err)}, nil
+	}
+
+	return c.validateConfigMap(contents, "", configMap), nil
+}
+
+// ValidateFileStrict is like ValidateFile, but also reports the comments and
+// trailing commas of a JSON config file (which are otherwise allowed) as
+// problems, as they aren't valid in standard JSON.
+func (c Configuration) ValidateFileStrict(location string) ([]Problem, error) {
+	problems, err := c.ValidateFile(location)
+
+	if nil != err {
+		return nil, err
+	}
+
+	contents, format, err := c.readLoadedFile(location)
+
+	if nil != err || jsonFormat != detectFormat(format, location, contents) {
+		return problems, err
+	}
+
+	_, extensions := stripJSONC(contents)
+	strictProblems := make([]Problem, 0, len(extensions)+len(problems))
+
+	for _, extension := range extensions {
+		line, column := position(contents, extension.offset)
+
+		strictProblems = append(strictProblems, Problem{Line: line, Column: column, Message: fmt.Sprintf("%s isn't allowed in strict JSON", extension.syntax)})
+	}
+
+	return append(strictProblems, problems...), nil
+}
+
+// readLoadedFile reads the contents of a loaded config file, along with the
+// format that it was loaded in
+func (c Configuration) readLoadedFile(location string) ([]byte, fileFormat, error) {
+	contents := c.stdinContents
+	var err error
+
+	if stdinFileLocation != location {
+		if contents, err = ioutil.ReadFile(location); nil != err {
+			if os.IsNotExist(err) && c.configFileEnv && location == c.configFileLocation {
+				err = missingEnvConfigFileError(location)
+			}
+
+			return nil, autoFormat, err
+		}
+	}
+
+	// A passed format only applies to the user's config file
+	if location == c.configFileLocation {
+		return contents, c.fileFormat, nil
+	}
+
+	return contents, autoFormat, nil
+}
+
+// validateConfigMap validates the raw JSON values of a configuration's keys,
+// qualified by the given prefix (such as that of a profile)
+func (c Configuration) validateConfigMap(contents []byte, keyPrefix string, configMap map[string]json.RawMessage) []Problem {
+	var problems []Problem
+
+	for _, key := range sortedKeys(configMap) {
+		if strings.HasPrefix(key, commentKeyPrefix) {
+			continue
+		}
+
+		qualifiedKey := keyPrefix + key
+
+		if strings.EqualFold(profilesKey, key) && "" == keyPrefix {
+			problems = append(problems, c.validateProfiles(contents, qualifiedKey, configMap[key])...)
+		} else if replacement, deprecated := findDeprecatedField(key); deprecated {
+			fieldType, _ := findField(reflect.TypeOf(Configuration{}), key)
+			problems = appendTypeProblem(problems, contents, qualifiedKey, configMap[key], fieldType)
+			problems = append(problems, Problem{Message: fmt.Sprintf("deprecated key %q is an alias of %q, which wins if both are set", qualifiedKey, replacement), Warning: true})
+		} else if fieldType, exists := findField(reflect.TypeOf(Configuration{}), key); exists {
+			problems = appendTypeProblem(problems, contents, qualifiedKey, configMap[key], fieldType)
+		} else if providerConfig, exists := c.providerConfigs[key]; exists {
+			problems = append(problems, validateProviderConfig(contents, qualifiedKey, key, configMap[key], providerConfig)...)
+
+			if !isConfigured(configMap[key]) {
+				continue
+			}
+
+			if _, err := registry.Provide(providerConfig); nil != err {
+				// The source is just unavailable, so others can still be used
+				problems = append(problems, Problem{Message: err.Error(), Warning: true})
+			}
+		} else if migration, deprecated := findMigration(key); deprecated {
+			_, replaced := configMap[findKey(configMap, migration.newName())]
+
+			problems = append(problems, deprecatedKeyProblem(qualifiedKey, migration, replaced))
+		} else {
+			candidates := fieldNames(reflect.TypeOf(Configuration{}))
+
+			for providerKey := range c.providerConfigs {
+				candidates = append(candidates, providerKey)
+			}
+
+			problems = append(problems, unknownKeyProblem(qualifiedKey, key, candidates))
+		}
+	}
+
+	return problems
+}
+
+// validateProfiles validates the raw JSON of the profiles of a config file,
+// each of which is validated as a configuration of its own
+func (c Configuration) validateProfiles(contents []byte, key string, raw json.RawMessage) []Problem {
+	var problems []Problem
+	var profiles map[string]json.RawMessage
+
+	if err := json.Unmarshal(raw, &profiles); nil != err {
+		return []Problem{newDecodeProblem(contents, key, err)}
+	}
+
+	for _, name := range sortedKeys(profiles) {
+		var profileMap map[string]json.RawMessage
+
+		qualifiedKey := key + "." + name
+
+		if err := json.Unmarshal(profiles[name], &profileMap); nil != err {
+			problems = append(problems, newDecodeProblem(contents, qualifiedKey, err))
+			continue
+		}
+
+		problems = append(problems, c.validateConfigMap(contents, qualifiedKey+".", profileMap)...)
+	}
+
+	return problems
+}
+
+// validateProviderConfig validates the raw JSON of a provider's configuration,
+// of the given section key (qualified by the given key)
+func validateProviderConfig(contents []byte, key string, sectionKey string, raw json.RawMessage, providerConfig registry.Configuration) []Problem {
+	var problems []Problem
+	var providerMap map[string]json.RawMessage
+
+	if err := json.Unmarshal(raw, &providerMap); nil != err {
+		return []Problem{newDecodeProblem(contents, key, err)}
+	}
+
+	configType := reflect.Indirect(reflect.ValueOf(providerConfig)).Type()
+
+	for _, providerKey := range sortedKeys(providerMap) {
+		if strings.HasPrefix(providerKey, commentKeyPrefix) {
+			continue
+		}
+
+		qualifiedKey := key + "." + providerKey
+
+		if fieldType, exists := findField(configType, providerKey); exists {
+			problems = appendTypeProblem(problems, contents, qualifiedKey, providerMap[providerKey], fieldType)
+		} else if migration, deprecated := findMigration(sectionKey + keyPathSeparator + providerKey); deprecated {
+			_, replaced := providerMap[findKey(providerMap, migration.newName())]
+
+			problems = append(problems, deprecatedKeyProblem(qualifiedKey, migration, replaced))
+		} else {
+			problems = append(problems, unknownKeyProblem(qualifiedKey, providerKey, fieldNames(configType)))
+		}
+	}
+
+	return problems
+}
+
+// unknownKeyProblem returns the warning of an unknown key (qualified by its
+// sections), suggesting the most similar of the given known keys, if any
+func unknownKeyProblem(qualifiedKey string, key string, knownKeys []string) Problem {
+	message := fmt.Sprintf("unknown key %q", qualifiedKey)
+
+	if suggestion := suggestKey(key, knownKeys); "" != suggestion {
+		message += fmt.Sprintf(" (did you mean %q?)", suggestion)
+	}
+
+	return Problem{Message: message, Warning: true}
+}
+
+// suggestKey returns the known key that's most similar to the given key (by
+// their case-insensitive edit distance), or an empty string if none are
+// similar enough to be a likely typo
+func suggestKey(key string, knownKeys []string) string {
+	var suggestion string
+
+	// Allow about one edit for every two characters of the key, such as the
+	// missing "ation" of "IndentSize"
+	bestDistance := len(key)/2 + 1
+
+	sort.Strings(knownKeys)
+
+	for _, knownKey := range knownKeys {
+		if distance := editDistance(strings.ToLower(key), strings.ToLower(knownKey)); distance < bestDistance {
+			suggestion, bestDistance = knownKey, distance
+		}
+	}
+
+	return suggestion
+}
+
+// editDistance returns the Levenshtein distance between two strings: the
+// number of single character insertions, deletions, or substitutions needed
+// to change one into the other
+func editDistance(a string, b string) int {
+	aRunes, bRunes := []rune(a), []rune(b)
+	previous := make([]int, len(bRunes)+1)
+	current := make([]int, len(bRunes)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(aRunes); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(bRunes); j++ {
+			cost := 1
+
+			if aRunes[i-1] == bRunes[j-1] {
+				cost = 0
+			}
+
+			current[j] = previous[j-1] + cost
+
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(bRunes)]
+}
+
+// fieldNames returns the JSON keys of the exported fields of a struct type
+func fieldNames(structType reflect.Type) []string {
+	var names []string
+
+	for i := 0; i < structType.NumField(); i++ {
+		if name, ok := jsonFieldName(structType.Field(i)); ok {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// isConfigured returns whether a raw provider configuration has any non-empty
+// values set (ignoring comment keys)
+func isConfigured(raw json.RawMessage) bool {
+	var providerMap map[string]interface{}
+
+	if err := json.Unmarshal(raw, &providerMap); nil != err {
+		return false
+	}
+
+	for key, value := range providerMap {
+		if strings.HasPrefix(key, commentKeyPrefix) {
+			continue
+		}
+
+		switch value {
+		case nil, "", false, float64(0):
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// appendTypeProblem appends a problem to the given list if the raw JSON value
+// can't be decoded into the given type
+func appendTypeProblem(problems []Problem, contents []byte, key string, raw json.RawMessage, fieldType reflect.Type) []Problem {
+	if err := json.Unmarshal(raw, reflect.New(fieldType).Interface()); nil != err {
+		problems = append(problems, newDecodeProblem(contents, key, err))
+	}
+
+	return problems
+}
+
+// newDecodeProblem creates a problem from a JSON decoding error
+func newDecodeProblem(contents []byte, key string, err error) Problem {
+	switch err := err.(type) {
+	case *json.SyntaxError:
+		line, column := position(contents, err.Offset)
+
+		return Problem{Line: line, Column: column, Message: fmt.Sprintf("invalid JSON: %s", err)}
+	case *toml.SyntaxError:
+		return Problem{Line: err.Line, Column: err.Column, Message: fmt.Sprintf("invalid TOML: %s", err.Message)}
+	case *yaml.SyntaxError:
+		return Problem{Line: err.Line, Message: fmt.Sprintf("invalid YAML: %s", err.Message)}
+	case *json.UnmarshalTypeError:
+		if "" == key {
+			return Problem{Message: fmt.Sprintf("expected a JSON object, but got %s", err.Value)}
+		}
+
+		return Problem{Message: fmt.Sprintf("key %q expects a value of type %s, but got %s", key, err.Type, err.Value)}
+	}
+
+	if "" == key {
+		return Problem{Message: err.Error()}
+	}
+
+	return Problem{Message: fmt.Sprintf("key %q: %s", key, err)}
+}
+
+// findField finds the type of an exported field of a struct type that matches
+// a JSON key, the same way that the JSON decoder does (case-insensitively)
+func findField(structType reflect.Type, key string) (reflect.Type, bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		if name, ok := jsonFieldName(field); ok && strings.EqualFold(name, key) {
+			return field.Type, true
+		}
+	}
+
+	return nil, false
+}
+
+// jsonFieldName returns the JSON key of a struct field, and whether the field
+// is (un)marshalled at all
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	if "" != field.PkgPath {
+		return "", false
+	}
+
+	if tag := strings.Split(field.Tag.Get("json"), ",")[0]; "-" == tag {
+		return "", false
+	} else if "" != tag {
+		return tag, true
+	}
+
+	return field.Name, true
+}
+
+// sortedKeys returns the keys of a map of raw JSON values in sorted order
+func sortedKeys(rawMap map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(rawMap))
+
+	for key := range rawMap {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// position converts a byte offset into a 1-based line and column
+func position(contents []byte, offset int64) (line int, column int) {
+	if offset > int64(len(contents)) {
+		offset = int64(len(contents))
+	}
+
+	preceding := contents[:offset]
+	line = bytes.Count(preceding, []byte("\n")) + 1
+	column = len(preceding) - bytes.LastIndexByte(preceding, '\n')
+
+	return line, column
+}
@@ -0,0 +1,95 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Rican7/define/internal/io/printer"
+)
+
+func TestValidate(t *testing.T) {
+	testData := []struct {
+		name         string
+		conf         Configuration
+		wantProblems int
+	}{
+		{"defaults", Configuration{}, 0},
+		{"valid", Configuration{IndentationSize: 4, HeadwordCase: "Title", Timeout: Duration(time.Second)}, 0},
+		{"indentation too large", Configuration{IndentationSize: 40}, 1},
+		{"unknown headword case", Configuration{HeadwordCase: "loud"}, 1},
+		{"negative timeouts", Configuration{Timeout: Duration(-time.Second), PerSourceTimeout: Duration(-time.Second)}, 2},
+		{"unknown output format", Configuration{OutputFormat: "xml"}, 1},
+		{"valid theme", Configuration{ThemeName: "mono", Theme: printer.Theme{"headword": "bold blue", "example": "38;5;208"}}, 0},
+		{"unknown theme name", Configuration{ThemeName: "neon"}, 1},
+		{"invalid theme", Configuration{Theme: printer.Theme{"heading": "red", "example": "sparkly", "synonym": "1;x"}}, 3},
+		{"valid cache TTL", Configuration{CacheTTL: "24h"}, 0},
+		{"invalid cache TTL", Configuration{CacheTTL: "a day"}, 1},
+		{"negative cache TTL", Configuration{CacheTTL: "-1h"}, 1},
+	}
+
+	for _, data := range testData {
+		if problems := data.conf.Validate(); data.wantProblems != len(problems) {
+			t.Errorf("%s: Validate returned %d problem(s) %q, want %d", data.name, len(problems), problems, data.wantProblems)
+		}
+	}
+}
+
+func TestProblemString(t *testing.T) {
+	testData := []struct {
+		problem Problem
+		want    string
+	}{
+		{Problem{Message: "bad"}, "bad"},
+		{Problem{Line: 2, Column: 3, Message: "bad"}, "line 2, column 3: bad"},
+		{Problem{Line: 2, Message: "bad"}, "line 2: bad"},
+		{Problem{File: "a.json", Message: "unknown key \"A\"", Warning: true}, "warning: a.json: unknown key \"A\""},
+	}
+
+	for _, data := range testData {
+		if got := data.problem.String(); data.want != got {
+			t.Errorf("%#v.String() returned %q, want %q", data.problem, got, data.want)
+		}
+	}
+}
+
+func TestSuggestKey(t *testing.T) {
+	knownKeys := []string{"IndentationSize", "HeadwordCase", "Timeout", "PerSourceTimeout", "Oxford"}
+
+	testData := map[string]string{
+		"IndentSize":       "IndentationSize",
+		"indentationsize":  "IndentationSize",
+		"HeadwordCsae":     "HeadwordCase",
+		"Timout":           "Timeout",
+		"Oxfrod":           "Oxford",
+		"Colour":           "",
+		"CompletelyUnlike": "",
+		"":                 "",
+	}
+
+	for key, want := range testData {
+		if got := suggestKey(key, knownKeys); want != got {
+			t.Errorf("suggestKey(%q) returned %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	testData := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"rēad", "read", 1},
+	}
+
+	for _, data := range testData {
+		if got := editDistance(data.a, data.b); data.want != got {
+			t.Errorf("editDistance(%q, %q) returned %d, want %d", data.a, data.b, got, data.want)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// keyPathSeparator is the separator of the keys of a dotted key path, such as
+// "OxfordDictionary.AppKey"
+const keyPathSeparator = "."
+
+// secretKeyIndicators is a list of (lowercase) key name parts that indicate
+// that a key's value is a secret
+var secretKeyIndicators = []string{"key", "secret", "token", "password"}
+
+// IsSecretKey returns whether the value of the dotted key path is a secret,
+// such as an API key.
+func IsSecretKey(keyPath string) bool {
+	keys := strings.Split(keyPath, keyPathSeparator)
+	name := strings.ToLower(keys[len(keys)-1])
+
+	for _, indicator := range secretKeyIndicators {
+		if strings.Contains(name, indicator) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Value returns the effective value of the dotted key path (such as
+// "IndentationSize" or "OxfordDictionary.AppKey").
+func (c Configuration) Value(keyPath string) (interface{}, error) {
+	encoded, err := json.Marshal(c)
+
+	if nil != err {
+		return nil, err
+	}
+
+	var value interface{}
+
+	if err = json.Unmarshal(encoded, &value); nil != err {
+		return nil, err
+	}
+
+	for _, key := range strings.Split(keyPath, keyPathSeparator) {
+		valueMap, ok := value.(map[string]interface{})
+
+		if !ok {
+			return nil, fmt.Errorf("unknown key %q", keyPath)
+		}
+
+		if value, ok = valueMap[findKey(valueMap, key)]; !ok {
+			return nil, fmt.Errorf("unknown key %q", keyPath)
+		}
+	}
+
+	return value, nil
+}
+
+// SetFileValue sets the value of the dotted key path (such as
+// "OxfordDictionary.AppKey") in the config file at the location given by
+// TargetFileLocation, creating the file if it doesn't exist.
+//
+// The value is given in its string form, and is converted to the type of the
+// key. The rest of the file's keys and values, and its comments, are preserved
+// (see writeFileValue).
+func (c Configuration) SetFileValue(keyPath string, value string) error {
+	location := c.TargetFileLocation()
+	keys := strings.Split(keyPath, keyPathSeparator)
+
+	var structType reflect.Type
+
+	switch len(keys) {
+	case 1:
+		structType = reflect.TypeOf(c)
+	case 2:
+		providerConfig, exists := c.providerConfigs[keys[0]]
+
+		if !exists {
+			return fmt.Errorf("unknown key %q", keyPath)
+		}
+
+		structType = reflect.Indirect(reflect.ValueOf(providerConfig)).Type()
+	default:
+		return fmt.Errorf("unknown key %q", keyPath)
+	}
+
+	fieldType, exists := findField(structType, keys[len(keys)-1])
+
+	if !exists {
+		return fmt.Errorf("unknown key %q", keyPath)
+	}
+
+	encodedValue, err := encodeValue(value, fieldType)
+
+	if nil != err {
+		return fmt.Errorf("invalid value for key %q: %s", keyPath, err)
+	}
+
+	fileObject, format, err := readFileObject(location, c.fileFormat)
+
+	if nil != err {
+		return err
+	}
+
+	if 1 == len(keys) {
+		fileObject.set(findKey(fileObject.values, keys[0]), encodedValue)
+	} else {
+		providerObject := &rawObject{values: make(map[string]json.RawMessage)}
+
+		if raw, exists := fileObject.values[keys[0]]; exists {
+			if providerObject, err = newRawObject(raw); nil != err {
+				return fmt.Errorf("error reading key %q of config file %q: %s", keys[0], location, err)
+			}
+		}
+
+		providerObject.set(findKey(providerObject.values, keys[1]), encodedValue)
+
+		encodedProvider, err := json.Marshal(providerObject)
+
+		if nil != err {
+			return err
+		}
+
+		fileObject.set(keys[0], encodedProvider)
+	}
+
+	return writeFileValue(location, format, keys, encodedValue, fileObject)
+}
+
+// encodeValue converts a value in its string form to the JSON encoding of the
+// given type
+func encodeValue(value string, valueType reflect.Type) (json.RawMessage, error) {
+	decoded := reflect.New(valueType).Interface()
+
+	// Try the value as JSON first (for numbers and booleans), and then as a
+	// string (for strings and types with string representations)
+	if err := json.Unmarshal([]byte(value), decoded); nil != err {
+		encodedString, _ := json.Marshal(value)
+
+		if err = json.Unmarshal(encodedString, decoded); nil != err {
+			return nil, fmt.Errorf("expected a value of type %s", valueType)
+		}
+	}
+
+	return json.Marshal(decoded)
+}
+
+// rawObject defines a JSON object of raw values, which preserves the order of
+// its keys
+type rawObject struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+// newRawObject parses a JSON object into a rawObject
+func newRawObject(data []byte) (*rawObject, error) {
+	object := &rawObject{values: make(map[string]json.RawMessage)}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	if token, err := decoder.Token(); nil != err {
+		return nil, err
+	} else if json.Delim('{') != token {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+
+		if nil != err {
+			return nil, err
+		}
+
+		var value json.RawMessage
+
+		if err = decoder.Decode(&value); nil != err {
+			return nil, err
+		}
+
+		object.set(token.(string), value)
+	}
+
+	if _, err := decoder.Token(); nil != err {
+		return nil, err
+	}
+
+	return object, nil
+}
+
+// set sets the value of a key, appending the key if it doesn't already exist
+func (o *rawObject) set(key string, value json.RawMessage) {
+	if _, exists := o.values[key]; !exists {
+		o.keys = append(o.keys, key)
+	}
+
+	o.values[key] = value
+}
+
+// MarshalJSON defines how the object should be JSON marshalled.
+func (o *rawObject) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("{")
+
+	for i, key := range o.keys {
+		if 0 < i {
+			buffer.WriteString(",")
+		}
+
+		encodedKey, err := json.Marshal(key)
+
+		if nil != err {
+			return nil, err
+		}
+
+		buffer.Write(encodedKey)
+		buffer.WriteString(":")
+
+		if err = json.Compact(&buffer, o.values[key]); nil != err {
+			return nil, err
+		}
+	}
+
+	buffer.WriteString("}")
+
+	return buffer.Bytes(), nil
+}
+
+// readFileObject reads a config file into a rawObject, along with the file's
+// format (unless forced), returning an empty object if the file doesn't exist
+func readFileObject(location string, forced fileFormat) (*rawObject, fileFormat, error) {
+	contents, err := ioutil.ReadFile(location)
+	format := detectFormat(forced, location, contents)
+
+	if os.IsNotExist(err) || (nil == err && 0 == len(bytes.TrimSpace(contents))) {
+		return &rawObject{values: make(map[string]json.RawMessage)}, format, nil
+	} else if nil != err {
+		return nil, format, err
+	}
+
+	if contents, err = decodeFileContents(format, location, contents); nil != err {
+		return nil, format, fmt.Errorf("error reading config file %q with error: %s", location, err)
+	}
+
+	object, err := newRawObject(contents)
+
+	if nil != err {
+		return nil, format, fmt.Errorf("error reading config file %q with error: %s", location, err)
+	}
+
+	return object, format, nil
+}
+
+// writeFileObject atomically writes a rawObject to a config file of the given
+// format (see writeFileContents)
+func writeFileObject(location string, format fileFormat, object *rawObject) error {
+	compact, err := json.Marshal(object)
+
+	if nil != err {
+		return err
+	}
+
+	var encoded bytes.Buffer
+
+	if err = json.Indent(&encoded, compact, "", exampleIndent); nil != err {
+		return err
+	}
+
+	encoded.WriteString("\n")
+
+	contents, err := encodeFileContents(format, encoded.Bytes())
+
+	if nil != err {
+		return err
+	}
+
+	return writeFileContents(location, contents)
+}
+
+// writeFileContents atomically writes the contents of a config file, by
+// writing to a temporary file and renaming it into place
+func writeFileContents(location string, contents []byte) error {
+	if err := os.MkdirAll(filepath.Dir(location), 0700); nil != err {
+		return err
+	}
+
+	temp, err := ioutil.TempFile(filepath.Dir(location), "."+filepath.Base(location)+".")
+
+	if nil != err {
+		return err
+	}
+
+	_, err = temp.Write(contents)
+
+	if closeErr := temp.Close(); nil == err {
+		err = closeErr
+	}
+
+	if nil == err {
+		err = os.Rename(temp.Name(), location)
+	}
+
+	if nil != err {
+		os.Remove(temp.Name())
+	}
+
+	return err
+}
+
+// findKey finds the key of a map that matches the given key the same way that
+// the JSON decoder does (case-insensitively, preferring an exact match),
+// returning the given key if there's no match
+func findKey(valueMap interface{}, key string) string {
+	keys := reflect.ValueOf(valueMap).MapKeys()
+
+	for _, mapKey := range keys {
+		if key == mapKey.String() {
+			return key
+		}
+	}
+
+	for _, mapKey := range keys {
+		if strings.EqualFold(key, mapKey.String()) {
+			return mapKey.String()
+		}
+	}
+
+	return key
+}
@@ -0,0 +1,254 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Rican7/define/registry"
+)
+
+func TestSetFileValuePreservesUnknownKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-config")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	testData := []struct {
+		name     string
+		contents string
+		want     string
+	}{
+		{
+			"config.json",
+			`{"// IndentationSize": "The indentation", "IndentationSize": 2, "RemovedSource": {"AppKey": "key"}, "FutureOption": [1, 2]}`,
+			`{"// IndentationSize": "The indentation", "IndentationSize": 4, "RemovedSource": {"AppKey": "key"}, "FutureOption": [1, 2]}`,
+		},
+		{
+			"config.toml",
+			"\"// IndentationSize\" = \"The indentation\"\nIndentationSize = 2\nFutureOption = [1, 2]\n\n[RemovedSource]\nAppKey = \"key\"\n",
+			"\"// IndentationSize\" = \"The indentation\"\nIndentationSize = 4\nFutureOption = [1, 2]\n\n[RemovedSource]\nAppKey = \"key\"\n",
+		},
+	}
+
+	for _, data := range testData {
+		location := filepath.Join(dir, data.name)
+
+		if err := ioutil.WriteFile(location, []byte(data.contents), 0600); nil != err {
+			t.Fatal(err)
+		}
+
+		conf := Configuration{targetFileLocation: location}
+
+		if err := conf.SetFileValue("IndentationSize", "4"); nil != err {
+			t.Fatalf("%s: SetFileValue returned error %q", data.name, err)
+		}
+
+		if contents, _ := ioutil.ReadFile(location); data.want != string(contents) {
+			t.Errorf("%s: SetFileValue wrote %q, want %q", data.name, contents, data.want)
+		}
+	}
+}
+
+func TestSetFileValuePreservesComments(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-config")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	testData := []struct {
+		name     string
+		contents string
+		keyPath  string
+		value    string
+		want     string
+	}{
+		{
+			"config.json",
+			"// My config\n{\n  \"IndentationSize\": 2, // two spaces\n  /* sources */\n  \"Source\": \"x\",\n}\n",
+			"IndentationSize", "3",
+			"// My config\n{\n  \"IndentationSize\": 3, // two spaces\n  /* sources */\n  \"Source\": \"x\",\n}\n",
+		},
+		{
+			"config.json",
+			"{\n  \"IndentationSize\": 2, // two spaces\n  \"Source\": \"x\" // the source\n}\n",
+			"NoPrompt", "true",
+			"{\n  \"IndentationSize\": 2, // two spaces\n  \"Source\": \"x\", // the source\n  \"NoPrompt\": true\n}\n",
+		},
+		{
+			"config.json",
+			"{\n  \"EnvTest\": {\n    // my oxford work account\n    \"Name\": \"work\",\n  },\n}\n",
+			"EnvTest.Language", "fr",
+			"{\n  \"EnvTest\": {\n    // my oxford work account\n    \"Name\": \"work\",\n    \"Language\": \"fr\",\n  },\n}\n",
+		},
+		{
+			"config.json",
+			"{\n  // Accounts\n  \"IndentationSize\": 2\n}\n",
+			"EnvTest.Name", "work",
+			"{\n  // Accounts\n  \"IndentationSize\": 2,\n  \"EnvTest\": {\n    \"Name\": \"work\"\n  }\n}\n",
+		},
+		{
+			"config.toml",
+			"# My config\nIndentationSize = 2 # two spaces\n\n[EnvTest]\n# my oxford work account\nName = \"work\"\n",
+			"EnvTest.Language", "fr",
+			"# My config\nIndentationSize = 2 # two spaces\n\n[EnvTest]\n# my oxford work account\nName = \"work\"\nLanguage = \"fr\"\n",
+		},
+		{
+			"config.toml",
+			"# My config\nIndentationSize = 2 # two spaces\n",
+			"IndentationSize", "3",
+			"# My config\nIndentationSize = 3 # two spaces\n",
+		},
+	}
+
+	for _, data := range testData {
+		location := filepath.Join(dir, data.name)
+
+		if err := ioutil.WriteFile(location, []byte(data.contents), 0600); nil != err {
+			t.Fatal(err)
+		}
+
+		conf := Configuration{targetFileLocation: location, providerConfigs: map[string]registry.Configuration{"EnvTest": &envTestConfig{}}}
+
+		if err := conf.SetFileValue(data.keyPath, data.value); nil != err {
+			t.Fatalf("%s: SetFileValue(%q) returned error %q", data.name, data.keyPath, err)
+		}
+
+		if contents, _ := ioutil.ReadFile(location); data.want != string(contents) {
+			t.Errorf("%s: SetFileValue(%q) wrote %q, want %q", data.name, data.keyPath, contents, data.want)
+		}
+	}
+}
+
+func TestSetFileValueRefusesToRemoveComments(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-config")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	testData := map[string]string{
+		"config.yaml": "# My config\nIndentationSize: 2\n",
+		"config.toml": "# My config\nEnvTest = { Name = \"work\" }\n",
+	}
+
+	for name, contents := range testData {
+		location := filepath.Join(dir, name)
+
+		if err := ioutil.WriteFile(location, []byte(contents), 0600); nil != err {
+			t.Fatal(err)
+		}
+
+		conf := Configuration{targetFileLocation: location, providerConfigs: map[string]registry.Configuration{"EnvTest": &envTestConfig{}}}
+
+		if err := conf.SetFileValue("EnvTest.Language", "fr"); nil == err || !strings.Contains(err.Error(), "comments") {
+			t.Errorf("%s: SetFileValue returned error %v, want an error about the comments", name, err)
+		}
+
+		if written, _ := ioutil.ReadFile(location); contents != string(written) {
+			t.Errorf("%s: SetFileValue changed the file to %q", name, written)
+		}
+	}
+}
+
+func TestWriteExampleForcePreservesUnknownKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-config")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"config.json", "config.toml"} {
+		location := filepath.Join(dir, name)
+		contents := `{"IndentationSize": 8, "// RemovedSource": "A source that isn't built in", "RemovedSource": {"AppKey": "key"}}`
+
+		if "config.toml" == name {
+			contents = "IndentationSize = 8\n\"// RemovedSource\" = \"A source that isn't built in\"\n\n[RemovedSource]\nAppKey = \"key\"\n"
+		}
+
+		if err := ioutil.WriteFile(location, []byte(contents), 0600); nil != err {
+			t.Fatal(err)
+		}
+
+		conf := Configuration{targetFileLocation: location, defaults: &Configuration{IndentationSize: 2}}
+
+		if err := conf.WriteExample(false); nil == err {
+			t.Errorf("%s: WriteExample overwrote an existing file without force", name)
+		}
+
+		if err := conf.WriteExample(true); nil != err {
+			t.Fatalf("%s: WriteExample returned error %q", name, err)
+		}
+
+		written, err := ioutil.ReadFile(location)
+
+		if nil != err {
+			t.Fatal(err)
+		}
+
+		decoded, err := decodeFileContents(autoFormat, location, written)
+
+		if nil != err {
+			t.Fatalf("%s: the written file couldn't be decoded: %s", name, err)
+		}
+
+		object, err := newRawObject(decoded)
+
+		if nil != err {
+			t.Fatalf("%s: the written file isn't an object: %s", name, err)
+		}
+
+		if "2" != string(object.values["IndentationSize"]) {
+			t.Errorf("%s: WriteExample wrote the indentation size %s, want the default", name, object.values["IndentationSize"])
+		}
+
+		if section := string(object.values["RemovedSource"]); !strings.Contains(section, `"key"`) {
+			t.Errorf("%s: WriteExample didn't keep the unknown section, writing %s", name, written)
+		}
+
+		if comment := string(object.values[commentKeyPrefix+" RemovedSource"]); !strings.Contains(comment, "isn't built in") {
+			t.Errorf("%s: WriteExample didn't keep the unknown section's comment, writing %s", name, written)
+		}
+	}
+}
+
+func TestWriteExampleForceRefusesToRemoveComments(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-config")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	location := filepath.Join(dir, "config.json")
+	contents := "{\n  // my work account\n  \"IndentationSize\": 8\n}\n"
+
+	if err := ioutil.WriteFile(location, []byte(contents), 0600); nil != err {
+		t.Fatal(err)
+	}
+
+	conf := Configuration{targetFileLocation: location, defaults: &Configuration{IndentationSize: 2}}
+
+	if err := conf.WriteExample(true); nil == err || !strings.Contains(err.Error(), "comments") {
+		t.Errorf("WriteExample returned error %v, want an error about the comments", err)
+	}
+
+	if written, _ := ioutil.ReadFile(location); contents != string(written) {
+		t.Errorf("WriteExample changed the file to %q", written)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package history provides a persistent, append-only history of lookups.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Record defines a single lookup in the history.
+type Record struct {
+	Word   string    `json:"word"`
+	Source string    `json:"source"`
+	Time   time.Time `json:"time"`
+}
+
+// Append appends a record to the history file at the given path, creating the
+// file and its parent directories if they don't already exist.
+//
+// Each record is written as a single line in a single write to a file opened
+// in append mode, so that multiple processes appending simultaneously don't
+// interleave or corrupt each other's lines.
+func Append(path string, record Record) error {
+	line, err := json.Marshal(record)
+
+	if nil != err {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0700); nil != err {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+
+	if nil != err {
+		return err
+	}
+
+	if _, err = file.Write(append(line, '\n')); nil != err {
+		file.Close()
+
+		return err
+	}
+
+	return file.Close()
+}
+
+// Read reads the most recent records, up to the given limit, from the history
+// file at the given path, in chronological order. A limit less than 1 returns
+// all records. Lines that can't be parsed are skipped.
+func Read(path string, limit int) ([]Record, error) {
+	var records []Record
+
+	file, err := os.Open(path)
+
+	if os.IsNotExist(err) {
+		return records, nil
+	} else if nil != err {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		var record Record
+
+		if err := json.Unmarshal(scanner.Bytes(), &record); nil != err {
+			continue
+		}
+
+		records = append(records, record)
+	}
+
+	if 0 < limit && limit < len(records) {
+		records = records[len(records)-limit:]
+	}
+
+	return records, scanner.Err()
+}
+
+// Clear wipes the history file at the given path.
+func Clear(path string) error {
+	if err := os.Remove(path); nil != err && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
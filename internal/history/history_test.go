@@ -0,0 +1,124 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package history
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAppendAndRead(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-history")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "nested", "history.jsonl")
+	start := time.Date(2018, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	records := []Record{
+		{Word: "run", Source: "Wiktionary", Time: start},
+		{Word: "walk", Source: "Oxford Dictionaries", Time: start.Add(time.Minute)},
+		{Word: "swim", Source: "Wiktionary", Time: start.Add(time.Hour)},
+	}
+
+	for _, record := range records {
+		if err := Append(path, record); nil != err {
+			t.Fatalf("Append returned error %q", err)
+		}
+	}
+
+	read, err := Read(path, 0)
+
+	if nil != err {
+		t.Fatalf("Read returned error %q", err)
+	}
+
+	if !reflect.DeepEqual(records, read) {
+		t.Errorf("Read returned %+v, want %+v", read, records)
+	}
+
+	if read, _ := Read(path, 2); !reflect.DeepEqual(records[1:], read) {
+		t.Errorf("Read with a limit returned %+v, want %+v", read, records[1:])
+	}
+
+	if read, _ := Read(path, 10); !reflect.DeepEqual(records, read) {
+		t.Errorf("Read with a limit beyond the records returned %+v, want %+v", read, records)
+	}
+}
+
+func TestReadSkipsMalformedLines(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-history")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "history.jsonl")
+	contents := `{"word":"run","source":"Wiktionary","time":"2018-03-01T12:00:00Z"}
+{"word":"walk","source":
+not json at all
+
+{"word":"swim","source":"Wiktionary","time":"2018-03-01T13:00:00Z"}
+`
+
+	if err := ioutil.WriteFile(path, []byte(contents), 0600); nil != err {
+		t.Fatal(err)
+	}
+
+	read, err := Read(path, 0)
+
+	if nil != err {
+		t.Fatalf("Read returned error %q", err)
+	}
+
+	want := []Record{
+		{Word: "run", Source: "Wiktionary", Time: time.Date(2018, time.March, 1, 12, 0, 0, 0, time.UTC)},
+		{Word: "swim", Source: "Wiktionary", Time: time.Date(2018, time.March, 1, 13, 0, 0, 0, time.UTC)},
+	}
+
+	if !reflect.DeepEqual(want, read) {
+		t.Errorf("Read returned %+v, want %+v", read, want)
+	}
+}
+
+func TestReadAndClearMissingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-history")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "history.jsonl")
+
+	if read, err := Read(path, 0); nil != err || 0 != len(read) {
+		t.Errorf("Read of a missing file returned %+v, with error %v", read, err)
+	}
+
+	if err := Append(path, Record{Word: "run"}); nil != err {
+		t.Fatalf("Append returned error %q", err)
+	}
+
+	if err := Clear(path); nil != err {
+		t.Errorf("Clear returned error %q", err)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Clear didn't remove the file, with error %v", err)
+	}
+
+	if err := Clear(path); nil != err {
+		t.Errorf("Clear of a missing file returned error %q", err)
+	}
+}
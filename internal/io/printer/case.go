@@ -0,0 +1,71 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package printer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// HeadwordCase defines the capitalization to display headwords in
+type HeadwordCase string
+
+// The available headword capitalizations
+const (
+	// HeadwordCaseSource displays headwords exactly as the source returns them
+	HeadwordCaseSource HeadwordCase = "source"
+	HeadwordCaseLower  HeadwordCase = "lower"
+	HeadwordCaseUpper  HeadwordCase = "upper"
+	HeadwordCaseTitle  HeadwordCase = "title"
+)
+
+// headwordCases is the list of valid headword capitalizations
+var headwordCases = []HeadwordCase{HeadwordCaseSource, HeadwordCaseLower, HeadwordCaseUpper, HeadwordCaseTitle}
+
+// ParseHeadwordCase parses a headword capitalization by its name, where an
+// empty name is the same as HeadwordCaseSource.
+func ParseHeadwordCase(name string) (HeadwordCase, error) {
+	if "" == name {
+		return HeadwordCaseSource, nil
+	}
+
+	for _, headwordCase := range headwordCases {
+		if strings.EqualFold(name, string(headwordCase)) {
+			return headwordCase, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid headword case %q (must be one of %q)", name, headwordCases)
+}
+
+// Apply applies the capitalization to a headword.
+func (c HeadwordCase) Apply(headword string) string {
+	switch c {
+	case HeadwordCaseLower:
+		return strings.ToLower(headword)
+	case HeadwordCaseUpper:
+		return strings.ToUpper(headword)
+	case HeadwordCaseTitle:
+		return toTitle(headword)
+	default:
+		return headword
+	}
+}
+
+// toTitle capitalizes the first letter of each word of a string (separated by
+// spaces or hyphens), and lowercases the rest
+func toTitle(str string) string {
+	runes := []rune(strings.ToLower(str))
+	isWordStart := true
+
+	for i, r := range runes {
+		if isWordStart {
+			runes[i] = unicode.ToTitle(r)
+		}
+
+		isWordStart = unicode.IsSpace(r) || '-' == r
+	}
+
+	return string(runes)
+}
@@ -0,0 +1,39 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package printer
+
+import (
+	defineio "github.com/Rican7/define/internal/io"
+	"github.com/Rican7/define/source"
+)
+
+// jsonIndent is the indentation of the JSON output
+const jsonIndent = "    "
+
+// JSONPrinter is a printer for source.Result structures that outputs their
+// JSON encoding (see source.MarshalResultJSON).
+//
+// Each result is printed as an indented JSON document, on lines of its own,
+// followed by exactly one line break (so that several results are printed as
+// a stream of documents).
+type JSONPrinter struct {
+	out *defineio.PanicWriter
+}
+
+// NewJSONPrinter creates a new JSONPrinter.
+func NewJSONPrinter(out *defineio.PanicWriter) *JSONPrinter {
+	return &JSONPrinter{out: out}
+}
+
+// PrintResult prints a source.Result.
+func (p *JSONPrinter) PrintResult(result source.Result) error {
+	encoded, err := source.MarshalResultJSONIndent(result, "", jsonIndent)
+
+	if nil != err {
+		return err
+	}
+
+	p.out.WriteStringLine(string(encoded))
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package printer
+
+import (
+	"strings"
+
+	defineio "github.com/Rican7/define/internal/io"
+	"github.com/Rican7/define/source"
+)
+
+// PorcelainFormatVersion is the version of the porcelain output format.
+//
+// The format is a contract with scripts, so it must never change within a
+// version. Version 1 of the format is defined as:
+//
+//   - One record per sense, including each sub-sense, in the order given by
+//     the source, with each record on its own line terminated by "\n".
+//   - Each record contains exactly three fields, separated by a tab ("\t"):
+//     the headword, the part of speech (lexical category), and the definition.
+//   - Fields that are unknown are empty, but are never omitted.
+//   - Multiple definitions of a single sense are joined by "; ".
+//   - Within fields, a backslash is escaped as "\\", a tab as "\t", a
+//     newline as "\n", and a carriage return as "\r".
+//   - Nothing else, such as headers or source attribution, is output.
+const PorcelainFormatVersion = 1
+
+const (
+	porcelainFieldSeparator      = "\t"
+	porcelainRecordSeparator     = "\n"
+	porcelainDefinitionSeparator = "; "
+)
+
+// porcelainEscaper escapes the characters that have meaning in the format
+var porcelainEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// PorcelainPrinter is a printer for source.Result structures that outputs a
+// stable, field-delimited format intended to be consumed by scripts.
+//
+// See PorcelainFormatVersion for the definition of the format.
+type PorcelainPrinter struct {
+	out *defineio.PanicWriter
+}
+
+// NewPorcelainPrinter creates a new PorcelainPrinter.
+func NewPorcelainPrinter(out *defineio.PanicWriter) *PorcelainPrinter {
+	return &PorcelainPrinter{out: out}
+}
+
+// PrintResult prints a source.Result.
+func (p *PorcelainPrinter) PrintResult(result source.Result) {
+	for _, entry := range result.Entries() {
+		headword := result.Headword()
+		var partOfSpeech string
+
+		if wordEntry, isWordEntry := entry.(source.WordEntry); isWordEntry {
+			if "" != wordEntry.Word() {
+				headword = wordEntry.Word()
+			}
+
+			partOfSpeech = wordEntry.Category()
+		}
+
+		for _, sense := range entry.Senses() {
+			p.printSense(headword, partOfSpeech, sense)
+		}
+	}
+}
+
+// printSense prints the record for a sense, and then those of its sub-senses
+func (p *PorcelainPrinter) printSense(headword string, partOfSpeech string, sense source.Sense) {
+	if definitions := sense.Definitions(); len(definitions) > 0 {
+		fields := []string{
+			porcelainEscaper.Replace(headword),
+			porcelainEscaper.Replace(partOfSpeech),
+			porcelainEscaper.Replace(strings.Join(definitions, porcelainDefinitionSeparator)),
+		}
+
+		p.out.WriteString(strings.Join(fields, porcelainFieldSeparator) + porcelainRecordSeparator)
+	}
+
+	for _, subsense := range sense.Subsenses() {
+		p.printSense(headword, partOfSpeech, subsense)
+	}
+}
@@ -7,7 +7,9 @@ package printer
 import (
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	defineio "github.com/Rican7/define/internal/io"
 	"github.com/Rican7/define/source"
@@ -16,17 +18,104 @@ import (
 const (
 	etymologyHeader = "Origin"
 	synonymHeader   = "Synonyms"
+	inflectionLabel = "Inflections"
 	antonymHeader   = "Antonyms"
 )
 
+// DefaultMinSynonyms is the default minimum number of synonyms needed to print
+// the synonyms section
+const DefaultMinSynonyms = 1
+
+// DefaultMaxExamplesPerSense is the default maximum number of examples printed
+// for each sense
+const DefaultMaxExamplesPerSense = 2
+
+// unlimitedExamples is the maximum number of examples that doesn't limit them
+const unlimitedExamples = -1
+
+// resetStyle is the ANSI escape sequence that resets the style of output
+const resetStyle = "\x1b[0m"
+
 // ResultPrinter is a printer for source.Result structures.
 type ResultPrinter struct {
-	out *defineio.PanicWriter
+	out             *defineio.PanicWriter
+	headwordCase    HeadwordCase
+	bullet          string
+	numbered        bool
+	minSynonyms     uint
+	showExamples    bool
+	maxExamples     uint
+	showThesaurus   bool
+	showInflections bool
+	color           bool
+	styles          map[string]string
 }
 
 // NewResultPrinter creates a new ResultPrinter.
 func NewResultPrinter(out *defineio.PanicWriter) *ResultPrinter {
-	return &ResultPrinter{out: out}
+	return &ResultPrinter{out: out, headwordCase: HeadwordCaseSource, minSynonyms: DefaultMinSynonyms, numbered: true, showExamples: true, maxExamples: DefaultMaxExamplesPerSense, showThesaurus: true, styles: builtinThemes[DefaultThemeName].escapes()}
+}
+
+// SetHeadwordCase sets the capitalization to display headwords in.
+func (p *ResultPrinter) SetHeadwordCase(headwordCase HeadwordCase) {
+	p.headwordCase = headwordCase
+}
+
+// SetBullet sets the marker to print before each sense, instead of its number.
+// An empty bullet prints the senses without any marker.
+func (p *ResultPrinter) SetBullet(bullet string) {
+	p.bullet = bullet
+	p.numbered = false
+}
+
+// SetMinSynonyms sets the minimum number of synonyms needed to print the
+// synonyms section. A minimum of 0 always prints it, even when empty.
+func (p *ResultPrinter) SetMinSynonyms(minSynonyms uint) {
+	p.minSynonyms = minSynonyms
+}
+
+// SetShowExamples sets whether to print the examples of senses.
+func (p *ResultPrinter) SetShowExamples(showExamples bool) {
+	p.showExamples = showExamples
+}
+
+// SetMaxExamplesPerSense sets the maximum number of examples to print for each
+// sense. A maximum of 0 prints all of them.
+func (p *ResultPrinter) SetMaxExamplesPerSense(maxExamples uint) {
+	p.maxExamples = maxExamples
+}
+
+// SetShowThesaurus sets whether to print the synonyms and antonyms sections.
+func (p *ResultPrinter) SetShowThesaurus(showThesaurus bool) {
+	p.showThesaurus = showThesaurus
+}
+
+// SetShowInflections sets whether to print the inflected forms of entries.
+func (p *ResultPrinter) SetShowInflections(showInflections bool) {
+	p.showInflections = showInflections
+}
+
+// SetColor sets whether to print in color, styled by the theme.
+func (p *ResultPrinter) SetColor(color bool) {
+	p.color = color
+}
+
+// SetTheme sets the theme that styles the output, when printing in color.
+// Elements with invalid styles are left unstyled.
+func (p *ResultPrinter) SetTheme(theme Theme) {
+	p.styles = theme.escapes()
+}
+
+// style returns the given text in the style of the given theme element, if
+// printing in color
+func (p *ResultPrinter) style(text string, element string) string {
+	escape, styled := p.styles[element]
+
+	if !p.color || !styled || "" == text {
+		return text
+	}
+
+	return escape + text + resetStyle
 }
 
 // PrintSourceName prints the name of a source.Source.
@@ -37,25 +126,50 @@ func (p *ResultPrinter) PrintSourceName(src source.Source) {
 
 		writer.WriteNewLine()
 		writer.WriteStringLine(strings.Repeat("-", separatorSize))
-		writer.WriteStringLine(text)
+		writer.WriteStringLine(p.style(text, ThemeSource))
+
+		if labeledSource, ok := src.(source.LabeledSource); ok && "" != labeledSource.Label() {
+			writer.WriteStringLine(fmt.Sprintf("(%s)", labeledSource.Label()))
+		}
+
 		writer.WriteNewLine()
 	})
 }
 
 // PrintResult prints a source.Result.
 func (p *ResultPrinter) PrintResult(result source.Result) {
+	maxExamples := unlimitedExamples
+
+	if !p.showExamples {
+		maxExamples = 0
+	} else if 0 < p.maxExamples {
+		maxExamples = int(p.maxExamples)
+	}
+
 	p.out.IndentWrites(func(writer *defineio.PanicWriter) {
-		writer.WritePaddedStringLine(getHeader(result), 1)
+		writer.WritePaddedStringLine(p.style(getHeader(result, p.headwordCase), ThemeHeadword), 1)
+
+		language := result.Language()
 
 		for _, entry := range result.Entries() {
-			if entryHeader := getEntryHeader(result, entry); "" != entryHeader {
+			// Group entries of results spanning multiple languages under
+			// headings of their language
+			if languageEntry, ok := entry.(source.LanguageEntry); ok && language != languageEntry.Language() {
+				language = languageEntry.Language()
+
+				writer.WriteNewLine()
+				writer.WriteNewLine()
+				writer.WriteStringLine(fmt.Sprintf("[%s]", language))
+			}
+
+			if entryHeader := getEntryHeader(result, entry, p.headwordCase); "" != entryHeader {
 				writer.WriteNewLine()
 				writer.WriteNewLine()
-				writer.WriteStringLine(entryHeader)
+				writer.WriteStringLine(p.style(entryHeader, ThemeHeadword))
 			}
 
 			writer.IndentWrites(func(writer *defineio.PanicWriter) {
-				printEntry(writer, entry)
+				p.printEntry(writer, entry, maxExamples)
 			})
 		}
 
@@ -63,57 +177,116 @@ func (p *ResultPrinter) PrintResult(result source.Result) {
 	})
 }
 
-func printEntry(writer *defineio.PanicWriter, entry source.DictionaryEntry) {
+func (p *ResultPrinter) printEntry(writer *defineio.PanicWriter, entry source.DictionaryEntry, maxExamples int) {
+	var heading []string
+
 	if wordEntry, isWordEntry := entry.(source.WordEntry); isWordEntry && "" != wordEntry.Category() {
-		writer.WritePaddedStringLine(fmt.Sprintf("(%s)", wordEntry.Category()), 1)
+		heading = append(heading, p.style(fmt.Sprintf("(%s)", wordEntry.Category()), ThemePartOfSpeech))
 	}
 
-	for senseIndex, sense := range entry.Senses() {
-		prefix := fmt.Sprintf("%d. ", senseIndex+1)
+	if inflectionEntry, ok := entry.(source.InflectionEntry); ok && p.showInflections && 0 < len(inflectionEntry.Inflections()) {
+		heading = append(heading, fmt.Sprintf("%s: %s", inflectionLabel, strings.Join(inflectionEntry.Inflections(), ", ")))
+	}
 
-		for defIndex, definition := range sense.Definitions() {
-			// Change the prefix after the first definition
-			if 0 < defIndex {
-				prefix = " - "
-			}
+	// The part of speech and inflections are padded together, as a block
+	if 0 < len(heading) {
+		writer.WriteNewLine()
 
-			writer.WriteStringLine(prefix + definition)
+		for _, line := range heading {
+			writer.WriteStringLine(line)
 		}
 
-		writer.IndentWritesBy(uint(len(prefix)), func(writer *defineio.PanicWriter) {
-			for _, examples := range sense.Examples() {
-				writer.WriteStringLine(fmt.Sprintf("%q", examples))
-			}
+		writer.WriteNewLine()
+	}
 
-			for _, notes := range sense.Notes() {
-				writer.WriteStringLine(fmt.Sprintf("[%s]", notes))
-			}
-		})
+	for senseIndex, sense := range entry.Senses() {
+		p.printSense(writer, sense, strconv.Itoa(senseIndex+1), false, maxExamples)
+	}
 
-		writer.IndentWrites(func(writer *defineio.PanicWriter) {
-			for _, subSense := range sense.Subsenses() {
-				prefix := " - "
+	if etymologyEntry, ok := entry.(source.EtymologyEntry); ok {
+		printEtymologyEntry(writer, etymologyEntry)
+	}
 
-				for _, definition := range subSense.Definitions() {
-					writer.WriteStringLine(prefix + definition)
-				}
+	if thesaurusEntry, ok := entry.(source.ThesaurusEntry); ok && p.showThesaurus {
+		p.printThesaurusEntry(writer, thesaurusEntry)
+	}
+}
 
-				writer.IndentWritesBy(uint(len(prefix)), func(writer *defineio.PanicWriter) {
-					if len(subSense.Examples()) > 0 {
-						writer.WriteStringLine(fmt.Sprintf("%q", subSense.Examples()[0]))
-					}
-				})
-			}
-		})
+// printSense prints a sense, numbered by the given number, and then its
+// sub-senses indented and numbered hierarchically beneath it (1.1, 1.2, etc).
+// At most maxExamples of its examples are printed (all of them if negative).
+func (p *ResultPrinter) printSense(writer *defineio.PanicWriter, sense source.Sense, number string, isSubsense bool, maxExamples int) {
+	prefix := p.senseMarker(number)
+
+	for defIndex, definition := range sense.Definitions() {
+		// Change the prefix after the first definition
+		if 0 < defIndex && "" != prefix {
+			prefix = " - "
+		}
+
+		writer.WriteStringLine(prefix + p.style(definition, ThemeDefinition))
 	}
 
-	if etymologyEntry, ok := entry.(source.EtymologyEntry); ok {
-		printEtymologyEntry(writer, etymologyEntry)
+	writer.IndentWritesBy(uint(utf8.RuneCountInString(prefix)), func(writer *defineio.PanicWriter) {
+		// Senses pronounced differently than their entry show their own
+		if pronunciations := source.SensePronunciations(sense); 0 < len(pronunciations) {
+			writer.WriteStringLine(formatPronunciations(pronunciations))
+		}
+
+		examples := sense.Examples()
+
+		// Only show a single example for sub-senses, to keep them brief
+		if isSubsense && (maxExamples < 0 || maxExamples > 1) {
+			maxExamples = 1
+		}
+
+		if 0 <= maxExamples && len(examples) > maxExamples {
+			examples = examples[:maxExamples]
+		}
+
+		for _, example := range examples {
+			writer.WriteStringLine(p.style(fmt.Sprintf("%q", example), ThemeExample))
+		}
+
+		for _, note := range sense.Notes() {
+			writer.WriteStringLine(fmt.Sprintf("[%s]", note))
+		}
+	})
+
+	writer.IndentWrites(func(writer *defineio.PanicWriter) {
+		for subsenseIndex, subsense := range sense.Subsenses() {
+			p.printSense(writer, subsense, fmt.Sprintf("%s.%d", number, subsenseIndex+1), true, maxExamples)
+		}
+	})
+}
+
+// senseMarker returns the marker printed before the first definition of the
+// sense of the given number, by the printer's bullet
+func (p *ResultPrinter) senseMarker(number string) string {
+	switch {
+	case p.numbered:
+		return number + ". "
+	case "" == p.bullet:
+		return ""
+	default:
+		return p.bullet + " "
 	}
+}
+
+// formatPronunciations formats pronunciations for display, each with its
+// region (if known), such as "/rɛd/ (US), /red/ (UK)"
+func formatPronunciations(pronunciations []source.RegionalPronunciation) string {
+	formatted := make([]string, len(pronunciations))
+
+	for i, pronunciation := range pronunciations {
+		formatted[i] = fmt.Sprintf("/%s/", pronunciation.Transcription)
 
-	if thesaurusEntry, ok := entry.(source.ThesaurusEntry); ok {
-		printThesaurusEntry(writer, thesaurusEntry)
+		if "" != pronunciation.Region {
+			formatted[i] += fmt.Sprintf(" (%s)", pronunciation.Region)
+		}
 	}
+
+	return strings.Join(formatted, ", ")
 }
 
 func printEtymologyEntry(writer *defineio.PanicWriter, entry source.EtymologyEntry) {
@@ -128,11 +301,11 @@ func printEtymologyEntry(writer *defineio.PanicWriter, entry source.EtymologyEnt
 	}
 }
 
-func printThesaurusEntry(writer *defineio.PanicWriter, entry source.ThesaurusEntry) {
-	if 0 < len(entry.Synonyms()) {
+func (p *ResultPrinter) printThesaurusEntry(writer *defineio.PanicWriter, entry source.ThesaurusEntry) {
+	if p.minSynonyms <= uint(len(entry.Synonyms())) {
 		writer.WritePaddedStringLine(synonymHeader, 1)
 
-		writer.WriteStringLine(strings.Join(entry.Synonyms(), " ; "))
+		writer.WriteStringLine(p.style(strings.Join(entry.Synonyms(), " ; "), ThemeSynonym))
 
 		writer.WriteNewLine()
 	}
@@ -140,14 +313,14 @@ func printThesaurusEntry(writer *defineio.PanicWriter, entry source.ThesaurusEnt
 	if 0 < len(entry.Antonyms()) {
 		writer.WritePaddedStringLine(antonymHeader, 1)
 
-		writer.WriteStringLine(strings.Join(entry.Antonyms(), " ; "))
+		writer.WriteStringLine(p.style(strings.Join(entry.Antonyms(), " ; "), ThemeSynonym))
 
 		writer.WriteNewLine()
 	}
 }
 
-func getHeader(result source.Result) string {
-	header := result.Headword()
+func getHeader(result source.Result, headwordCase HeadwordCase) string {
+	header := headwordCase.Apply(result.Headword())
 
 	firstEntry := result.Entries()[0]
 
@@ -158,14 +331,14 @@ func getHeader(result source.Result) string {
 	return header
 }
 
-func getEntryHeader(result source.Result, entry source.DictionaryEntry) string {
+func getEntryHeader(result source.Result, entry source.DictionaryEntry, headwordCase HeadwordCase) string {
 	var header string
 
 	if wordEntry, isWordEntry := entry.(source.WordEntry); isWordEntry && !isSameWord(result, entry) {
 		if "" != entry.Pronunciation() {
-			header = fmt.Sprintf("%s  /%s/", wordEntry.Word(), entry.Pronunciation())
+			header = fmt.Sprintf("%s  /%s/", headwordCase.Apply(wordEntry.Word()), entry.Pronunciation())
 		} else {
-			header = wordEntry.Word()
+			header = headwordCase.Apply(wordEntry.Word())
 		}
 	}
 
@@ -0,0 +1,145 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package printer
+
+import (
+	"strings"
+	"testing"
+
+	defineio "github.com/Rican7/define/internal/io"
+	"github.com/Rican7/define/source"
+)
+
+// testSource is a source that only has a name
+type testSource struct{}
+
+func (testSource) Name() string {
+	return "Test"
+}
+
+func (testSource) Define(word string) (source.Result, error) {
+	return nil, nil
+}
+
+// testResult is the result printed by the tests
+var testResult = source.ResultValue{
+	Head: "test",
+	Lang: "en",
+	EntryVals: []interface{}{
+		source.EntryValue{
+			WordEntryValue: source.WordEntryValue{WordVal: "test", CategoryVal: "noun"},
+			DictionaryEntryValue: source.DictionaryEntryValue{
+				SenseVals: []source.SenseValue{{DefinitionVals: []string{"a procedure"}, ExampleVals: []string{"a test"}}},
+			},
+		},
+	},
+}
+
+func TestResultPrinterOutput(t *testing.T) {
+	var out strings.Builder
+
+	resultPrinter := NewResultPrinter(defineio.NewPanicWriter(&out, 2))
+	resultPrinter.PrintResult(testResult)
+	resultPrinter.PrintSourceName(testSource{})
+
+	// No line ends in whitespace, and the output ends with a blank line
+	want := "" +
+		"\n" +
+		"  test\n" +
+		"\n" +
+		"\n" +
+		"    (noun)\n" +
+		"\n" +
+		"    1. a procedure\n" +
+		"       \"a test\"\n" +
+		"\n" +
+		"\n" +
+		"  ---------------------------\n" +
+		"  Results provided by: \"Test\"\n" +
+		"\n"
+
+	if want != out.String() {
+		t.Errorf("ResultPrinter printed %q, want %q", out.String(), want)
+	}
+}
+
+func TestPorcelainPrinterOutput(t *testing.T) {
+	var out strings.Builder
+
+	NewPorcelainPrinter(defineio.NewPanicWriter(&out, 2)).PrintResult(testResult)
+
+	// Each record (including the last) ends with exactly one line break
+	if want := "test\tnoun\ta procedure\n"; want != out.String() {
+		t.Errorf("PorcelainPrinter printed %q, want %q", out.String(), want)
+	}
+}
+
+func TestJSONPrinterOutput(t *testing.T) {
+	var out strings.Builder
+
+	jsonPrinter := NewJSONPrinter(defineio.NewPanicWriter(&out, 2))
+
+	for i := 0; i < 2; i++ {
+		if err := jsonPrinter.PrintResult(source.ResultValue{Head: "test"}); nil != err {
+			t.Fatalf("JSONPrinter returned error %q", err)
+		}
+	}
+
+	// Each document ends with exactly one line break
+	document := "{\n    \"headword\": \"test\",\n    \"entries\": []\n}\n"
+
+	if want := document + document; want != out.String() {
+		t.Errorf("JSONPrinter printed %q, want %q", out.String(), want)
+	}
+}
+
+func TestResultPrinterInflections(t *testing.T) {
+	result := testResult
+	entry := result.EntryVals[0].(source.EntryValue).WithInflections("tests", "tested", "testing")
+	result.EntryVals = []interface{}{entry}
+
+	testData := map[bool]string{
+		true:  "    (noun)\n    Inflections: tests, tested, testing\n\n    1. a procedure\n",
+		false: "    (noun)\n\n    1. a procedure\n",
+	}
+
+	for showInflections, want := range testData {
+		var out strings.Builder
+
+		resultPrinter := NewResultPrinter(defineio.NewPanicWriter(&out, 2))
+		resultPrinter.SetShowInflections(showInflections)
+		resultPrinter.PrintResult(result)
+
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("ResultPrinter with inflections shown %t printed %q, want it to contain %q", showInflections, out.String(), want)
+		}
+	}
+}
+
+func TestResultPrinterBullet(t *testing.T) {
+	testData := map[string]string{
+		"•": "    • a procedure\n      \"a test\"\n",
+		"-": "    - a procedure\n      \"a test\"\n",
+		"":  "    a procedure\n    \"a test\"\n",
+	}
+
+	var numbered strings.Builder
+
+	NewResultPrinter(defineio.NewPanicWriter(&numbered, 2)).PrintResult(testResult)
+
+	if want := "    1. a procedure\n       \"a test\"\n"; !strings.Contains(numbered.String(), want) {
+		t.Errorf("ResultPrinter without a bullet printed %q, want it to contain %q", numbered.String(), want)
+	}
+
+	for bullet, want := range testData {
+		var out strings.Builder
+
+		resultPrinter := NewResultPrinter(defineio.NewPanicWriter(&out, 2))
+		resultPrinter.SetBullet(bullet)
+		resultPrinter.PrintResult(testResult)
+
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("ResultPrinter with the bullet %q printed %q, want it to contain %q", bullet, out.String(), want)
+		}
+	}
+}
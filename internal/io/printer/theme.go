@@ -0,0 +1,196 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package printer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The elements of the output that a theme styles
+const (
+	ThemeHeadword     = "headword"
+	ThemePartOfSpeech = "partOfSpeech"
+	ThemeDefinition   = "definition"
+	ThemeExample      = "example"
+	ThemeSynonym      = "synonym"
+	ThemeSource       = "source"
+)
+
+// DefaultThemeName is the name of the built-in theme used by default
+const DefaultThemeName = "default"
+
+// themeElements is the list of the elements that a theme styles
+var themeElements = []string{ThemeHeadword, ThemePartOfSpeech, ThemeDefinition, ThemeExample, ThemeSynonym, ThemeSource}
+
+// styleCodes maps the names of styles and colors to their ANSI (SGR) codes
+var styleCodes = map[string]string{
+	"none":      "",
+	"bold":      "1",
+	"dim":       "2",
+	"italic":    "3",
+	"underline": "4",
+	"black":     "30",
+	"red":       "31",
+	"green":     "32",
+	"yellow":    "33",
+	"blue":      "34",
+	"magenta":   "35",
+	"cyan":      "36",
+	"white":     "37",
+	"gray":      "90",
+}
+
+// Theme defines the styles of the elements of the output, each given as a
+// space-separated list of style or color names (such as "bold blue") or ANSI
+// (SGR) codes (such as "38;5;208"), where "none" is unstyled
+type Theme map[string]string
+
+// builtinThemes are the themes that can be selected by name
+var builtinThemes = map[string]Theme{
+	DefaultThemeName: {
+		ThemeHeadword:     "bold",
+		ThemePartOfSpeech: "bold yellow",
+		ThemeDefinition:   "none",
+		ThemeExample:      "dim",
+		ThemeSynonym:      "green",
+		ThemeSource:       "gray",
+	},
+	"mono": {
+		ThemeHeadword:     "bold",
+		ThemePartOfSpeech: "underline",
+		ThemeDefinition:   "none",
+		ThemeExample:      "italic",
+		ThemeSynonym:      "none",
+		ThemeSource:       "dim",
+	},
+}
+
+// BuiltinTheme returns a copy of the built-in theme of the given name, where
+// an empty name is the default theme, with the given element styles applied
+// over it.
+func BuiltinTheme(name string, overrides map[string]string) (Theme, error) {
+	if "" == name {
+		name = DefaultThemeName
+	}
+
+	builtin, exists := builtinThemes[name]
+
+	if !exists {
+		return nil, fmt.Errorf("unknown theme %q (must be one of %q)", name, BuiltinThemeNames())
+	}
+
+	theme := make(Theme, len(builtin))
+
+	for element, style := range builtin {
+		theme[element] = style
+	}
+
+	for element, style := range overrides {
+		theme[element] = style
+	}
+
+	return theme, nil
+}
+
+// BuiltinThemeNames returns the sorted names of the built-in themes.
+func BuiltinThemeNames() []string {
+	names := make([]string, 0, len(builtinThemes))
+
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Validate returns the problems of the theme's element names and styles, in
+// the order of the elements.
+func (t Theme) Validate() []error {
+	var errs []error
+	var elements []string
+
+	for element := range t {
+		elements = append(elements, element)
+	}
+
+	sort.Strings(elements)
+
+	for _, element := range elements {
+		if !isThemeElement(element) {
+			errs = append(errs, fmt.Errorf("unknown theme element %q (must be one of %q)", element, themeElements))
+		} else if _, err := parseStyle(t[element]); nil != err {
+			errs = append(errs, fmt.Errorf("theme element %q: %s", element, err))
+		}
+	}
+
+	return errs
+}
+
+// escapes returns the ANSI escape sequences of the theme's elements, skipping
+// any that are invalid or unstyled
+func (t Theme) escapes() map[string]string {
+	escapes := make(map[string]string)
+
+	for element, style := range t {
+		if escape, err := parseStyle(style); nil == err && "" != escape {
+			escapes[element] = escape
+		}
+	}
+
+	return escapes
+}
+
+// isThemeElement returns whether a name is of an element that themes style
+func isThemeElement(name string) bool {
+	for _, element := range themeElements {
+		if name == element {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseStyle parses a style into its ANSI escape sequence, or an empty string
+// if it's unstyled
+func parseStyle(style string) (string, error) {
+	var codes []string
+
+	for _, part := range strings.Fields(style) {
+		if code, exists := styleCodes[strings.ToLower(part)]; exists {
+			if "" != code {
+				codes = append(codes, code)
+			}
+
+			continue
+		}
+
+		if !isSGRCode(part) {
+			return "", fmt.Errorf("invalid style %q (must be a color name, such as \"blue\", or an ANSI code, such as \"38;5;208\")", part)
+		}
+
+		codes = append(codes, part)
+	}
+
+	if len(codes) < 1 {
+		return "", nil
+	}
+
+	return "\x1b[" + strings.Join(codes, ";") + "m", nil
+}
+
+// isSGRCode returns whether a string is an ANSI SGR code, of numbers separated
+// by semicolons
+func isSGRCode(code string) bool {
+	for _, number := range strings.Split(code, ";") {
+		if "" == number || 3 < len(number) || "" != strings.Trim(number, "0123456789") {
+			return false
+		}
+	}
+
+	return true
+}
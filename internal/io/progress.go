@@ -0,0 +1,46 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package io
+
+import (
+	"fmt"
+	"os"
+)
+
+// clearLineSequence is the terminal control sequence that returns the cursor
+// to the start of the line and clears it
+const clearLineSequence = "\r\x1b[K"
+
+// Progress writes a transient progress line, such as "[3/10] word", to a
+// terminal. It writes nothing if disabled or if the file isn't a terminal.
+type Progress struct {
+	out     *os.File
+	enabled bool
+	shown   bool
+}
+
+// NewProgress returns a new Progress that writes to the given file, if enabled
+// and the file is a terminal.
+func NewProgress(out *os.File, enabled bool) *Progress {
+	return &Progress{out: out, enabled: enabled && IsTerminal(out)}
+}
+
+// Update replaces the progress line with the given progress and label.
+func (p *Progress) Update(current int, total int, label string) {
+	if !p.enabled {
+		return
+	}
+
+	fmt.Fprintf(p.out, "%s[%d/%d] %s", clearLineSequence, current, total, label)
+	p.shown = true
+}
+
+// Clear clears the progress line, if shown.
+func (p *Progress) Clear() {
+	if !p.shown {
+		return
+	}
+
+	fmt.Fprint(p.out, clearLineSequence)
+	p.shown = false
+}
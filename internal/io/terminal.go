@@ -0,0 +1,18 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package io
+
+import (
+	"os"
+)
+
+// IsTerminal returns whether the given file is attached to a terminal (TTY).
+func IsTerminal(file *os.File) bool {
+	info, err := file.Stat()
+
+	if nil != err {
+		return false
+	}
+
+	return 0 != (info.Mode() & os.ModeCharDevice)
+}
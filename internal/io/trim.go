@@ -0,0 +1,41 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package io
+
+import (
+	"bytes"
+	"io"
+)
+
+// TrailingNewlineTrimmer is a writer that holds back the line breaks written
+// to it until something else is written after them, so that the output never
+// ends with a line break (such as that of its last line).
+type TrailingNewlineTrimmer struct {
+	inner   io.Writer
+	pending []byte
+}
+
+// NewTrailingNewlineTrimmer returns a new TrailingNewlineTrimmer based on a
+// wrapped io.Writer.
+func NewTrailingNewlineTrimmer(writer io.Writer) *TrailingNewlineTrimmer {
+	return &TrailingNewlineTrimmer{inner: writer}
+}
+
+// Write satisfies the io.Writer interface.
+func (w *TrailingNewlineTrimmer) Write(p []byte) (int, error) {
+	content := bytes.TrimRight(p, "\r\n")
+
+	if 0 == len(content) {
+		w.pending = append(w.pending, p...)
+
+		return len(p), nil
+	}
+
+	if _, err := w.inner.Write(append(w.pending, content...)); nil != err {
+		return 0, err
+	}
+
+	w.pending = append([]byte(nil), p[len(content):]...)
+
+	return len(p), nil
+}
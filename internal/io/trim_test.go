@@ -0,0 +1,37 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package io
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTrailingNewlineTrimmer(t *testing.T) {
+	testData := []struct {
+		writes []string
+		want   string
+	}{
+		{[]string{}, ""},
+		{[]string{"test\n"}, "test"},
+		{[]string{"a\n", "\n", "b\n\n"}, "a\n\nb"},
+		{[]string{"a", "\r\n", "\n"}, "a"},
+		{[]string{"\n", "a\nb\n"}, "\na\nb"},
+		{[]string{"a  \n"}, "a  "},
+	}
+
+	for _, data := range testData {
+		w := &strings.Builder{}
+		trimmer := NewTrailingNewlineTrimmer(w)
+
+		for _, write := range data.writes {
+			if n, err := trimmer.Write([]byte(write)); nil != err || len(write) != n {
+				t.Errorf("Write(%q) returned %d and error %v", write, n, err)
+			}
+		}
+
+		if data.want != w.String() {
+			t.Errorf("writing %q wrote %q, want %q", data.writes, w.String(), data.want)
+		}
+	}
+}
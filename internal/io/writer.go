@@ -8,8 +8,20 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"os"
+	"strings"
+	"syscall"
+	"unicode/utf8"
 )
 
+// columnGap is the number of spaces between the columns written by
+// WriteColumns
+const columnGap = 2
+
+// exit is the function called to exit when writing to a closed pipe, which
+// tests can replace
+var exit = os.Exit
+
 // PanicWriter is a writer that panics if a write operation causes an error.
 type PanicWriter struct {
 	inner io.Writer
@@ -24,8 +36,12 @@ func NewPanicWriter(writer io.Writer, indentStepSize uint) *PanicWriter {
 }
 
 // Write satisfies the io.Writer interface.
+//
+// Writes are indented by the writer's number of contextual spaces, except for
+// those of only line breaks (such as of blank lines), so that no line ends in
+// whitespace.
 func (w *PanicWriter) Write(p []byte) (int, error) {
-	if 0 < w.spaces {
+	if 0 < w.spaces && 0 < len(bytes.TrimRight(p, "\r\n")) {
 		p = append(bytes.Repeat([]byte(" "), int(w.spaces)), p...)
 	}
 
@@ -33,10 +49,16 @@ func (w *PanicWriter) Write(p []byte) (int, error) {
 }
 
 // WriteBytes writes a given string to the writer, and returns the number of
-// bytes that were written. It'll panic if any error occurs during writing.
+// bytes that were written. It'll panic if any error occurs during writing,
+// except for the pipe being written to having been closed (such as by "head"),
+// in which case it exits cleanly, as is conventional.
 func (w *PanicWriter) WriteBytes(p []byte) int {
 	n, err := w.Write(p)
 
+	if isBrokenPipe(err) {
+		exit(0)
+	}
+
 	if nil != err {
 		panic(err)
 	}
@@ -44,6 +66,18 @@ func (w *PanicWriter) WriteBytes(p []byte) int {
 	return n
 }
 
+// isBrokenPipe returns whether an error is from writing to a closed pipe
+func isBrokenPipe(err error) bool {
+	switch typedErr := err.(type) {
+	case *os.PathError:
+		err = typedErr.Err
+	case *os.SyscallError:
+		err = typedErr.Err
+	}
+
+	return syscall.EPIPE == err || io.ErrClosedPipe == err
+}
+
 // WriteString writes a given string to the writer, and returns the number of
 // bytes that were written. It'll panic if any error occurs during writing.
 func (w *PanicWriter) WriteString(p string) int {
@@ -92,6 +126,43 @@ func (w *PanicWriter) WritePaddedStringLine(p string, padding uint) int {
 	return w.writeLines(padding) + w.WriteStringLine(p) + w.writeLines(padding)
 }
 
+// WriteColumns writes the given rows of cells to the writer, one row per line,
+// with the cells of each column padded to align, and returns the number of
+// bytes that were written. It'll panic if any error occurs during writing.
+func (w *PanicWriter) WriteColumns(rows [][]string) int {
+	var widths []int
+	var totalBytes int
+
+	for _, row := range rows {
+		for i, cell := range row {
+			if len(widths) <= i {
+				widths = append(widths, 0)
+			}
+
+			if width := utf8.RuneCountInString(cell); width > widths[i] {
+				widths[i] = width
+			}
+		}
+	}
+
+	for _, row := range rows {
+		var line string
+
+		for i, cell := range row {
+			// Don't pad the last cell, to avoid trailing spaces
+			if i < len(row)-1 {
+				cell += strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+columnGap)
+			}
+
+			line += cell
+		}
+
+		totalBytes += w.WriteStringLine(line)
+	}
+
+	return totalBytes
+}
+
 // IndentWrites takes a callback where all writes made in the callback are
 // indented by the writer's indentation number. If the current writer is already
 // indented, the number of spaces will be additive to the current number of
@@ -6,7 +6,9 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"os"
 	"strings"
+	"syscall"
 	"testing"
 )
 
@@ -15,6 +17,15 @@ var (
 	_ io.Writer = (*PanicWriter)(nil)
 )
 
+// erroringWriter is a writer that always returns its error
+type erroringWriter struct {
+	err error
+}
+
+func (w erroringWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
+
 type writerShouldError bool
 
 func (w writerShouldError) Write(p []byte) (int, error) {
@@ -79,6 +90,20 @@ func TestWriteWithSpaces(t *testing.T) {
 	}
 }
 
+func TestWriteWithSpacesOfLineBreaks(t *testing.T) {
+	w := &strings.Builder{}
+	pw := &PanicWriter{inner: w, spaces: 2}
+
+	pw.WriteStringLine("test")
+	pw.WriteNewLine()
+	pw.WriteStringLine("")
+	pw.WriteString("\r\n")
+
+	if want := "  test\n\n\n\r\n"; w.String() != want {
+		t.Errorf("Writer didn't write the expected string. Got %q. Want %q.", w.String(), want)
+	}
+}
+
 func TestWritePanicsOnError(t *testing.T) {
 	defer func() {
 		if nil == recover() {
@@ -91,6 +116,33 @@ func TestWritePanicsOnError(t *testing.T) {
 	pw.WriteBytes([]byte(""))
 }
 
+func TestWriteExitsOnBrokenPipe(t *testing.T) {
+	defer func() { exit = os.Exit }()
+
+	// The stubbed exit stops the write by panicking with the exit code
+	exit = func(code int) { panic(code) }
+
+	testData := []error{
+		syscall.EPIPE,
+		io.ErrClosedPipe,
+		&os.PathError{Op: "write", Path: "/dev/stdout", Err: syscall.EPIPE},
+	}
+
+	for _, writeErr := range testData {
+		func() {
+			defer func() {
+				if code := recover(); 0 != code {
+					t.Errorf("Write with the error %q didn't exit with code 0 (recovered %v)", writeErr, code)
+				}
+			}()
+
+			pw := &PanicWriter{inner: erroringWriter{writeErr}}
+
+			pw.WriteBytes([]byte("test"))
+		}()
+	}
+}
+
 func TestWriteString(t *testing.T) {
 	toWrite := "test"
 	want := len(toWrite)
@@ -281,6 +333,40 @@ func TestWritePaddedStringLine(t *testing.T) {
 	}
 }
 
+func TestWriteColumns(t *testing.T) {
+	rows := [][]string{
+		{"1.", "short", "first"},
+		{"10.", "a longer cell", "second"},
+		{"100.", "é"},
+	}
+	expectedString := "" +
+		"1.    short          first\n" +
+		"10.   a longer cell  second\n" +
+		"100.  é\n"
+	want := len(expectedString)
+
+	w := &strings.Builder{}
+	pw := &PanicWriter{inner: w}
+
+	got := pw.WriteColumns(rows)
+
+	if got != want || got != w.Len() {
+		t.Errorf(
+			"WriteColumns didn't write the expected number of bytes. Got %d. Want %d.",
+			got,
+			want,
+		)
+	}
+
+	if w.String() != expectedString {
+		t.Errorf(
+			"Writer didn't write the expected string. Got %q. Want %q.",
+			w.String(),
+			expectedString,
+		)
+	}
+}
+
 func TestIndentWrites(t *testing.T) {
 	indentSize := uint(2)
 
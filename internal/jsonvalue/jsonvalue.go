@@ -0,0 +1,242 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package jsonvalue bridges JSON and the generic values (of maps, slices, and
+// scalars) that the libraries of other formats, such as TOML and YAML, decode
+// documents into and encode documents from.
+package jsonvalue
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Object defines a JSON object, which preserves the order of its keys
+type Object struct {
+	Keys   []string
+	Values map[string]interface{}
+}
+
+// NewObject returns a new, empty object
+func NewObject() *Object {
+	return &Object{Values: make(map[string]interface{})}
+}
+
+// Set sets the value of a key, appending the key if it doesn't already exist
+func (o *Object) Set(key string, value interface{}) {
+	if _, exists := o.Values[key]; !exists {
+		o.Keys = append(o.Keys, key)
+	}
+
+	o.Values[key] = value
+}
+
+// MarshalJSON satisfies the json.Marshaler interface, encoding the object's
+// keys in order.
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("{")
+
+	for i, key := range o.Keys {
+		if 0 < i {
+			buffer.WriteString(",")
+		}
+
+		encodedKey, err := json.Marshal(key)
+
+		if nil != err {
+			return nil, err
+		}
+
+		encodedValue, err := json.Marshal(o.Values[key])
+
+		if nil != err {
+			return nil, err
+		}
+
+		buffer.Write(encodedKey)
+		buffer.WriteString(":")
+		buffer.Write(encodedValue)
+	}
+
+	buffer.WriteString("}")
+
+	return buffer.Bytes(), nil
+}
+
+// Marshal encodes a generic value as JSON. The keys of maps may be of any type
+// (as the keys of YAML mappings are), and are formatted as strings, while the
+// keys of objects keep their order. Values without a JSON equivalent, such as
+// date-times, are reported as errors.
+func Marshal(value interface{}) ([]byte, error) {
+	normalized, err := normalize(value)
+
+	if nil != err {
+		return nil, err
+	}
+
+	return json.Marshal(normalized)
+}
+
+// UnmarshalObject decodes a JSON object, preserving the order of its keys.
+// Nested objects are decoded as *Object values, arrays as []interface{}
+// values, and numbers as int64 values, unless they aren't integers, which are
+// decoded as float64 values.
+func UnmarshalObject(data []byte) (*Object, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if token, err := decoder.Token(); nil != err {
+		return nil, err
+	} else if json.Delim('{') != token {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+
+	return decodeObject(decoder)
+}
+
+// normalize converts a generic value into one that the JSON encoder encodes
+// as its equivalent
+func normalize(value interface{}) (interface{}, error) {
+	var err error
+
+	switch value := value.(type) {
+	case *Object:
+		object := NewObject()
+
+		for _, key := range value.Keys {
+			element, err := normalize(value.Values[key])
+
+			if nil != err {
+				return nil, err
+			}
+
+			object.Set(key, element)
+		}
+
+		return object, nil
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(value))
+
+		for key, element := range value {
+			if object[key], err = normalize(element); nil != err {
+				return nil, err
+			}
+		}
+
+		return object, nil
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(value))
+
+		for key, element := range value {
+			if object[fmt.Sprint(key)], err = normalize(element); nil != err {
+				return nil, err
+			}
+		}
+
+		return object, nil
+	case []map[string]interface{}:
+		values := make([]interface{}, len(value))
+
+		for i, element := range value {
+			if values[i], err = normalize(element); nil != err {
+				return nil, err
+			}
+		}
+
+		return values, nil
+	case []interface{}:
+		values := make([]interface{}, len(value))
+
+		for i, element := range value {
+			if values[i], err = normalize(element); nil != err {
+				return nil, err
+			}
+		}
+
+		return values, nil
+	case float64:
+		if math.IsInf(value, 0) || math.IsNaN(value) {
+			return nil, fmt.Errorf("the value %v can't be represented in JSON", value)
+		}
+	case time.Time:
+		return nil, fmt.Errorf("the date-time %s can't be represented in JSON", value.Format(time.RFC3339))
+	}
+
+	return value, nil
+}
+
+// decodeObject decodes the rest of a JSON object, after its opening brace
+func decodeObject(decoder *json.Decoder) (*Object, error) {
+	object := NewObject()
+
+	for decoder.More() {
+		token, err := decoder.Token()
+
+		if nil != err {
+			return nil, err
+		}
+
+		key := token.(string)
+		value, err := decodeValue(decoder)
+
+		if nil != err {
+			return nil, err
+		}
+
+		object.Set(key, value)
+	}
+
+	// Consume the closing brace
+	if _, err := decoder.Token(); nil != err {
+		return nil, err
+	}
+
+	return object, nil
+}
+
+// decodeValue decodes the next JSON value
+func decodeValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+
+	if nil != err {
+		return nil, err
+	}
+
+	switch token := token.(type) {
+	case json.Delim:
+		if json.Delim('{') == token {
+			return decodeObject(decoder)
+		}
+
+		values := []interface{}{}
+
+		for decoder.More() {
+			value, err := decodeValue(decoder)
+
+			if nil != err {
+				return nil, err
+			}
+
+			values = append(values, value)
+		}
+
+		// Consume the closing bracket
+		if _, err := decoder.Token(); nil != err {
+			return nil, err
+		}
+
+		return values, nil
+	case json.Number:
+		if integer, err := token.Int64(); nil == err {
+			return integer, nil
+		}
+
+		return token.Float64()
+	}
+
+	return token, nil
+}
@@ -0,0 +1,59 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package jsonvalue
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMarshal(t *testing.T) {
+	object := NewObject()
+	object.Set("z", 1)
+	object.Set("a", map[interface{}]interface{}{1: true, "k": []interface{}{"v", nil}})
+	object.Set("m", []map[string]interface{}{{"x": 1.5}})
+
+	got, err := Marshal(object)
+
+	if nil != err {
+		t.Fatalf("Marshal returned error %q", err)
+	}
+
+	if want := `{"z":1,"a":{"1":true,"k":["v",null]},"m":[{"x":1.5}]}`; want != string(got) {
+		t.Errorf("Marshal returned %s, want %s", got, want)
+	}
+
+	for _, value := range []interface{}{math.Inf(1), []interface{}{time.Now()}} {
+		if _, err := Marshal(value); nil == err {
+			t.Errorf("Marshal(%v) didn't return an error", value)
+		}
+	}
+}
+
+func TestUnmarshalObject(t *testing.T) {
+	got, err := UnmarshalObject([]byte(`{"z": 1, "a": {"b": [2.5, "c", null]}, "z": 3}`))
+
+	if nil != err {
+		t.Fatalf("UnmarshalObject returned error %q", err)
+	}
+
+	want := &Object{
+		Keys: []string{"z", "a"},
+		Values: map[string]interface{}{
+			"z": int64(3),
+			"a": &Object{Keys: []string{"b"}, Values: map[string]interface{}{"b": []interface{}{2.5, "c", nil}}},
+		},
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("UnmarshalObject returned %#v, want %#v", got, want)
+	}
+
+	for _, object := range []string{`[]`, `{"a": }`, ``} {
+		if _, err := UnmarshalObject([]byte(object)); nil == err {
+			t.Errorf("UnmarshalObject(%q) didn't return an error", object)
+		}
+	}
+}
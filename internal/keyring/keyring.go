@@ -0,0 +1,69 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package keyring provides access to secrets, such as API keys, stored in the
+// operating system's keyring (secret store).
+package keyring
+
+import (
+	"strings"
+
+	"github.com/Rican7/define/internal/version"
+	"github.com/zalando/go-keyring"
+)
+
+// Placeholder is the value displayed in place of a secret that's stored in the
+// keyring, so that the secret itself is never printed.
+//
+// Configurations containing this value should treat it as if the value wasn't
+// set, so that the keyring will continue to be used.
+const Placeholder = "(stored in keyring)"
+
+// service is the name of the service that secrets are stored under
+const service = version.AppName
+
+// referencePrefixes are the prefixes of config values that reference a secret
+// stored in the keyring by its name (such as "keyring:OxfordDictionary.AppKey"),
+// the first of which is used when writing references
+var referencePrefixes = []string{"keyring:", "keychain:"}
+
+// Reference returns the config value that references the secret stored in the
+// keyring under the given name.
+func Reference(name string) string {
+	return referencePrefixes[0] + name
+}
+
+// ParseReference returns the name of the secret that a config value
+// references, and whether the value is a reference at all.
+func ParseReference(value string) (string, bool) {
+	for _, prefix := range referencePrefixes {
+		if strings.HasPrefix(value, prefix) && len(prefix) < len(value) {
+			return value[len(prefix):], true
+		}
+	}
+
+	return "", false
+}
+
+// Get returns the secret stored in the keyring under the given name.
+func Get(name string) (string, error) {
+	return keyring.Get(service, name)
+}
+
+// Lookup returns the secret stored in the keyring under the given name and
+// whether it was found. Any error in accessing the keyring, such as the
+// keyring being unavailable on the system, is treated as the secret not being
+// found.
+func Lookup(name string) (string, bool) {
+	secret, err := Get(name)
+
+	if nil != err || "" == secret {
+		return "", false
+	}
+
+	return secret, true
+}
+
+// Set stores the given secret in the keyring under the given name.
+func Set(name string, secret string) error {
+	return keyring.Set(service, name, secret)
+}
@@ -0,0 +1,124 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package logger provides a small leveled logger, for debugging the app's
+// behavior without sprinkling prints throughout it.
+package logger
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+)
+
+// List of log levels, from the most to the least verbose.
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarning
+	LevelNone
+)
+
+// Level defines the severity of a log message.
+type Level uint
+
+// Logger is a leveled logger that writes each message, prefixed by its level,
+// as a line to the wrapped writer. It's safe for concurrent use.
+type Logger struct {
+	mutex sync.Mutex
+	out   io.Writer
+	level Level
+}
+
+// std is the default logger, used by the package-level functions. It's
+// disabled until replaced via SetDefault.
+var std = New(ioutil.Discard, LevelNone)
+
+// New returns a new Logger that writes messages of the given level, or any
+// less verbose level, to the given writer.
+func New(out io.Writer, level Level) *Logger {
+	return &Logger{out: out, level: level}
+}
+
+// String returns the level's name.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarning:
+		return "warning"
+	default:
+		return "none"
+	}
+}
+
+// Enabled returns whether messages of the given level are written.
+func (l *Logger) Enabled(level Level) bool {
+	return LevelNone != level && l.level <= level
+}
+
+// Logf writes a message of the given level, formatted like fmt.Sprintf(), if
+// the level is enabled.
+func (l *Logger) Logf(level Level, format string, args ...interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	message := strings.TrimSuffix(fmt.Sprintf(format, args...), "\n")
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	fmt.Fprintf(l.out, "[%s] %s\n", level, message)
+}
+
+// Debugf writes a debug message, formatted like fmt.Sprintf().
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.Logf(LevelDebug, format, args...)
+}
+
+// Infof writes an info message, formatted like fmt.Sprintf().
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.Logf(LevelInfo, format, args...)
+}
+
+// Warningf writes a warning message, formatted like fmt.Sprintf().
+func (l *Logger) Warningf(format string, args ...interface{}) {
+	l.Logf(LevelWarning, format, args...)
+}
+
+// Default returns the default logger.
+func Default() *Logger {
+	return std
+}
+
+// SetDefault replaces the default logger, used by the package-level functions.
+//
+// This is intended to be called once, before any logging.
+func SetDefault(l *Logger) {
+	std = l
+}
+
+// Enabled returns whether messages of the given level are written by the
+// default logger.
+func Enabled(level Level) bool {
+	return std.Enabled(level)
+}
+
+// Debugf writes a debug message with the default logger.
+func Debugf(format string, args ...interface{}) {
+	std.Debugf(format, args...)
+}
+
+// Infof writes an info message with the default logger.
+func Infof(format string, args ...interface{}) {
+	std.Infof(format, args...)
+}
+
+// Warningf writes a warning message with the default logger.
+func Warningf(format string, args ...interface{}) {
+	std.Warningf(format, args...)
+}
@@ -0,0 +1,88 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package logger
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Enforce interface contracts
+var (
+	_ http.RoundTripper = (*transport)(nil)
+)
+
+func TestLogf(t *testing.T) {
+	out := &bytes.Buffer{}
+	l := New(out, LevelInfo)
+
+	l.Debugf("not %s", "written")
+	l.Infof("loaded %q", "file")
+	l.Warningf("something failed\n")
+
+	want := "[info] loaded \"file\"\n[warning] something failed\n"
+
+	if got := out.String(); want != got {
+		t.Errorf("Logf wrote %q, want %q", got, want)
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	testData := []struct {
+		level   Level
+		message Level
+		want    bool
+	}{
+		{LevelDebug, LevelDebug, true},
+		{LevelDebug, LevelWarning, true},
+		{LevelWarning, LevelInfo, false},
+		{LevelNone, LevelWarning, false},
+		{LevelDebug, LevelNone, false},
+	}
+
+	for _, data := range testData {
+		if got := New(&bytes.Buffer{}, data.level).Enabled(data.message); data.want != got {
+			t.Errorf("Enabled(%s) at level %s returned %t, want %t", data.message, data.level, got, data.want)
+		}
+	}
+}
+
+func TestDefaultIsDisabled(t *testing.T) {
+	if Enabled(LevelWarning) {
+		t.Errorf("default logger is enabled before being set")
+	}
+}
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	out := &bytes.Buffer{}
+	client := http.Client{Transport: NewTransport(http.DefaultTransport, New(out, LevelDebug))}
+
+	response, err := client.Get(server.URL + "/entries/word?key=secret")
+
+	if nil != err {
+		t.Fatalf("request failed: %s", err)
+	}
+
+	response.Body.Close()
+
+	logged := out.String()
+
+	if strings.Contains(logged, "secret") {
+		t.Errorf("transport logged the query string: %q", logged)
+	}
+
+	for _, want := range []string{"[debug] http: GET " + server.URL + "/entries/word\n", "200 OK", "application/json", "2 bytes"} {
+		if !strings.Contains(logged, want) {
+			t.Errorf("transport logged %q, want it to contain %q", logged, want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package logger
+
+import (
+	"net/http"
+	"time"
+)
+
+// transport is an http.RoundTripper that logs summaries of the requests and
+// responses of a wrapped http.RoundTripper
+type transport struct {
+	inner  http.RoundTripper
+	logger *Logger
+}
+
+// NewTransport returns an http.RoundTripper that logs a debug summary of each
+// request and its response to the given logger, around the given inner
+// http.RoundTripper. The query strings of URLs are never logged, as they may
+// contain secrets (such as API keys).
+func NewTransport(inner http.RoundTripper, logger *Logger) http.RoundTripper {
+	return &transport{inner: inner, logger: logger}
+}
+
+// RoundTrip satisfies the http.RoundTripper interface.
+func (t *transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	location := request.URL.Scheme + "://" + request.URL.Host + request.URL.EscapedPath()
+	start := time.Now()
+
+	t.logger.Debugf("http: %s %s", request.Method, location)
+
+	response, err := t.inner.RoundTrip(request)
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	if nil != err {
+		t.logger.Debugf("http: %s %s failed after %s: %s", request.Method, location, elapsed, err)
+
+		return response, err
+	}
+
+	t.logger.Debugf(
+		"http: %s %s responded %q (%s, %d bytes) after %s",
+		request.Method,
+		location,
+		response.Status,
+		response.Header.Get("Content-Type"),
+		response.ContentLength,
+		elapsed,
+	)
+
+	return response, err
+}
@@ -0,0 +1,131 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package paths provides the expansion of the file system paths given to the
+// app, such as in its configuration.
+package paths
+
+import (
+	"os"
+	"os/user"
+	"strings"
+
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+// Expand expands the environment variables of a path, in any of the "$VAR",
+// "${VAR}", or (Windows) "%VAR%" forms, and then its leading "~" (the user's
+// home directory) or "~user" (the named user's home directory). Unset
+// variables, and home directories that can't be found, are left unexpanded.
+func Expand(path string) string {
+	return expandHome(expandVars(path))
+}
+
+// expandVars expands the references to set environment variables in a path
+func expandVars(path string) string {
+	if !strings.ContainsAny(path, "$%") {
+		return path
+	}
+
+	var expanded strings.Builder
+
+	for i := 0; i < len(path); i++ {
+		name, length := varReference(path[i:])
+
+		if value, isSet := os.LookupEnv(name); "" != name && isSet {
+			expanded.WriteString(value)
+			i += length - 1
+
+			continue
+		}
+
+		expanded.WriteByte(path[i])
+	}
+
+	return expanded.String()
+}
+
+// varReference returns the name of the environment variable referenced at the
+// start of the given string, and the length of the reference, if any
+func varReference(s string) (string, int) {
+	switch {
+	case strings.HasPrefix(s, "${"):
+		if end := strings.IndexByte(s, '}'); -1 != end && isVarName(s[2:end]) {
+			return s[2:end], end + 1
+		}
+	case strings.HasPrefix(s, "$"):
+		end := 1
+
+		for end < len(s) && isVarNameChar(s[end]) {
+			end++
+		}
+
+		return s[1:end], end
+	case strings.HasPrefix(s, "%"):
+		if end := strings.IndexByte(s[1:], '%') + 1; 0 < end && isVarName(s[1:end]) {
+			return s[1:end], end + 1
+		}
+	}
+
+	return "", 0
+}
+
+// isVarName returns whether a string is a valid environment variable name
+func isVarName(s string) bool {
+	if len(s) < 1 {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if !isVarNameChar(s[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isVarNameChar returns whether a character is valid in an environment
+// variable name
+func isVarNameChar(c byte) bool {
+	return '_' == c || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// expandHome expands a path's leading "~" or "~user" into the home directory
+// of the current or named user
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~") {
+		return path
+	}
+
+	end := 1
+
+	for end < len(path) && !os.IsPathSeparator(path[end]) {
+		end++
+	}
+
+	var home string
+
+	if 1 == end {
+		dir, err := homedir.Dir()
+
+		if nil != err {
+			return path
+		}
+
+		home = dir
+	} else {
+		named, err := user.Lookup(path[1:end])
+
+		if nil != err {
+			return path
+		}
+
+		home = named.HomeDir
+	}
+
+	if "" == home {
+		return path
+	}
+
+	return home + path[end:]
+}
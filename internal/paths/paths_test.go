@@ -0,0 +1,76 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package paths
+
+import (
+	"os"
+	"os/user"
+	"testing"
+
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+func setEnv(t *testing.T, name string, value string) func() {
+	previous, wasSet := os.LookupEnv(name)
+
+	if err := os.Setenv(name, value); nil != err {
+		t.Fatal(err)
+	}
+
+	return func() {
+		if wasSet {
+			os.Setenv(name, previous)
+		} else {
+			os.Unsetenv(name)
+		}
+	}
+}
+
+func TestExpandVars(t *testing.T) {
+	defer setEnv(t, "HOME", "/home/tester")()
+	defer setEnv(t, "USERPROFILE", `C:\Users\tester`)()
+	defer os.Unsetenv("DEFINE_TEST_UNSET")
+
+	testData := map[string]string{
+		"":                              "",
+		"/usr/share/dict":               "/usr/share/dict",
+		"$HOME/dict.txt":                "/home/tester/dict.txt",
+		"${HOME}dict.txt":               "/home/testerdict.txt",
+		`%USERPROFILE%\dict.txt`:        `C:\Users\tester\dict.txt`,
+		"$DEFINE_TEST_UNSET/dict.txt":   "$DEFINE_TEST_UNSET/dict.txt",
+		"%DEFINE_TEST_UNSET%/dict.txt":  "%DEFINE_TEST_UNSET%/dict.txt",
+		"${DEFINE_TEST_UNSET}/dict.txt": "${DEFINE_TEST_UNSET}/dict.txt",
+		"/cost/$5/100%/${}/%%":          "/cost/$5/100%/${}/%%",
+	}
+
+	for path, want := range testData {
+		if got := Expand(path); want != got {
+			t.Errorf("Expand(%q) returned %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestExpandHome(t *testing.T) {
+	home, err := homedir.Dir()
+
+	if nil != err {
+		t.Skipf("the home directory can't be found: %s", err)
+	}
+
+	testData := map[string]string{
+		"~":                      home,
+		"~/dict.txt":             home + "/dict.txt",
+		"/~/dict.txt":            "/~/dict.txt",
+		"~define-no-such-user/x": "~define-no-such-user/x",
+	}
+
+	if current, err := user.Current(); nil == err && "" != current.HomeDir {
+		testData["~"+current.Username+"/dict.txt"] = current.HomeDir + "/dict.txt"
+	}
+
+	for path, want := range testData {
+		if got := Expand(path); want != got {
+			t.Errorf("Expand(%q) returned %q, want %q", path, got, want)
+		}
+	}
+}
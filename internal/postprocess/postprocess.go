@@ -0,0 +1,50 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package postprocess provides a mechanism for transforming the application's
+// output through an external command.
+package postprocess
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+)
+
+// CommandError represents an error caused by a failed post-process command.
+type CommandError struct {
+	Command string
+	Err     error
+}
+
+// Run runs the given command string through the system's shell, passing the
+// given input on the command's stdin and relaying the command's stdout and
+// stderr to the given writers.
+func Run(command string, input []byte, stdout io.Writer, stderr io.Writer) error {
+	cmd := shellCommand(command)
+
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); nil != err {
+		return &CommandError{Command: command, Err: err}
+	}
+
+	return nil
+}
+
+// shellCommand returns an exec.Cmd that runs the given command string through
+// the system's shell, so that arguments, quoting, and pipes work as expected.
+func shellCommand(command string) *exec.Cmd {
+	if "windows" == runtime.GOOS {
+		return exec.Command("cmd", "/C", command)
+	}
+
+	return exec.Command("sh", "-c", command)
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("post-process command %q failed with error: %s", e.Command, e.Err)
+}
@@ -0,0 +1,61 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package postprocess
+
+import (
+	"bytes"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	if "windows" == runtime.GOOS {
+		t.Skip("the command needs a POSIX shell")
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	if err := Run("tr a-z A-Z; echo oops >&2", []byte("run\n"), &stdout, &stderr); nil != err {
+		t.Fatalf("Run returned error %q", err)
+	}
+
+	if "RUN\n" != stdout.String() || "oops\n" != stderr.String() {
+		t.Errorf("Run wrote %q to stdout and %q to stderr", stdout.String(), stderr.String())
+	}
+}
+
+func TestRunFailingCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	err := Run("exit 1", []byte("run\n"), &stdout, &stderr)
+	commandErr, ok := err.(*CommandError)
+
+	if !ok {
+		t.Fatalf("Run returned error %v, want a *CommandError", err)
+	}
+
+	if exitErr, ok := commandErr.Err.(*exec.ExitError); !ok || 1 != exitErr.ExitCode() {
+		t.Errorf("Run returned the command error %v, want an exit status of 1", commandErr.Err)
+	}
+
+	if "exit 1" != commandErr.Command || !strings.Contains(err.Error(), `"exit 1"`) {
+		t.Errorf("Run returned the command error %q", err)
+	}
+}
+
+func TestRunNonexistentCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	command := "/nonexistent/define-postprocess --flag"
+	err := Run(command, []byte("run\n"), &stdout, &stderr)
+
+	if commandErr, ok := err.(*CommandError); !ok || command != commandErr.Command {
+		t.Errorf("Run returned error %v, want a *CommandError of %q", err, command)
+	}
+
+	if 0 != stdout.Len() {
+		t.Errorf("Run wrote %q to stdout", stdout.String())
+	}
+}
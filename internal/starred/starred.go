@@ -0,0 +1,103 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package starred provides a persistent list of starred (favorite) words.
+package starred
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Word defines a starred word and its saved definition.
+type Word struct {
+	Word       string    `json:"word"`
+	Definition string    `json:"definition,omitempty"`
+	Source     string    `json:"source,omitempty"`
+	Note       string    `json:"note,omitempty"`
+	Starred    time.Time `json:"starred"`
+}
+
+// List defines a list of starred words, stored in a JSON file.
+type List struct {
+	path  string
+	Words []Word
+}
+
+// Load loads the list of starred words from the JSON file at the given path.
+// A file that doesn't exist yet is treated as an empty list.
+func Load(path string) (*List, error) {
+	list := &List{path: path, Words: make([]Word, 0)}
+
+	contents, err := ioutil.ReadFile(path)
+
+	if os.IsNotExist(err) {
+		return list, nil
+	} else if nil != err {
+		return nil, err
+	}
+
+	if len(contents) > 0 {
+		err = json.Unmarshal(contents, &list.Words)
+	}
+
+	return list, err
+}
+
+// Star adds a word to the list, replacing any existing entry for the same word.
+func (l *List) Star(word Word) {
+	l.Unstar(word.Word)
+
+	l.Words = append(l.Words, word)
+}
+
+// Unstar removes a word from the list, and returns whether it was found.
+func (l *List) Unstar(word string) bool {
+	for i, starredWord := range l.Words {
+		if strings.EqualFold(starredWord.Word, word) {
+			l.Words = append(l.Words[:i], l.Words[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// Save writes the list back to its JSON file, replacing the file atomically so
+// that an interrupted write can't corrupt the existing list.
+func (l *List) Save() error {
+	encoded, err := json.MarshalIndent(l.Words, "", "    ")
+
+	if nil != err {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(l.path), 0700); nil != err {
+		return err
+	}
+
+	tempFile, err := ioutil.TempFile(filepath.Dir(l.path), filepath.Base(l.path))
+
+	if nil != err {
+		return err
+	}
+
+	if _, err = tempFile.Write(append(encoded, '\n')); nil != err {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+
+		return err
+	}
+
+	if err = tempFile.Close(); nil != err {
+		os.Remove(tempFile.Name())
+
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), l.path)
+}
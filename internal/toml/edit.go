@@ -0,0 +1,510 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package toml
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/Rican7/define/internal/jsonvalue"
+)
+
+// ErrUnsupportedEdit is returned when a key can't be set by editing its line
+// of a document alone, such as a key of an inline table, or a table value
+var ErrUnsupportedEdit = errors.New("the key can't be set in place")
+
+// entry defines the layout of a key/value pair of a document, by the offsets
+// of its line and value
+type entry struct {
+	table      []string
+	key        []string
+	inArray    bool
+	lineStart  int
+	valueStart int
+	valueEnd   int
+}
+
+// header defines the layout of a table header of a document, by the offsets
+// of its line
+type header struct {
+	path    []string
+	isArray bool
+	lineEnd int
+}
+
+// SetValue sets the value of a key path in a TOML document to the given JSON
+// value, by editing (or adding) only the key's line, so that the rest of the
+// document, including its comments, is kept as it is.
+//
+// The key path is of a key of the root table, or of a key of one of its
+// tables. Keys are matched case-insensitively (preferring an exact match), as
+// they're matched to the fields of the configuration. ErrUnsupportedEdit is
+// returned if the key can't be set in place.
+func SetValue(document []byte, path []string, value []byte) ([]byte, error) {
+	if len(path) < 1 || 2 < len(path) {
+		return nil, ErrUnsupportedEdit
+	}
+
+	encoded, err := encodeInlineValue(value)
+
+	if nil != err {
+		return nil, err
+	}
+
+	entries, headers, err := scanLayout(document)
+
+	if nil != err {
+		return nil, err
+	}
+
+	if found := findEntry(entries, path); nil != found {
+		return splice(document, found.valueStart, found.valueEnd, encoded), nil
+	}
+
+	line := toml.Key{path[len(path)-1]}.String() + " = " + encoded
+
+	if 1 == len(path) {
+		return insertLine(document, entries, headers, nil, line), nil
+	}
+
+	// The table mustn't be defined any other way than by its header
+	for _, entry := range entries {
+		if !entry.inArray && 0 == len(entry.table) && strings.EqualFold(path[0], entry.key[0]) {
+			return nil, ErrUnsupportedEdit
+		}
+	}
+
+	for _, header := range headers {
+		if 1 == len(header.path) && strings.EqualFold(path[0], header.path[0]) {
+			if header.isArray {
+				return nil, ErrUnsupportedEdit
+			}
+
+			return insertLine(document, entries, headers, header.path, line), nil
+		}
+	}
+
+	table := "[" + toml.Key{path[0]}.String() + "]\n" + line
+
+	if 0 < len(bytes.TrimSpace(document)) {
+		table = "\n" + table
+	}
+
+	return append(terminateLine(document), table+lineBreak(document)...), nil
+}
+
+// HasComments returns whether a TOML document has any comments.
+func HasComments(document []byte) bool {
+	for i := 0; i < len(document); i++ {
+		switch {
+		case '#' == document[i]:
+			return true
+		case '"' == document[i], '\'' == document[i]:
+			end, err := scanString(document, i)
+
+			if nil != err {
+				return false
+			}
+
+			i = end - 1
+		}
+	}
+
+	return false
+}
+
+// encodeInlineValue encodes a JSON value as a TOML value that fits on a key's
+// line, being anything other than a table
+func encodeInlineValue(value []byte) (string, error) {
+	wrapper, err := jsonvalue.UnmarshalObject(append(append([]byte(`{"v":`), value...), '}'))
+
+	if nil != err {
+		return "", err
+	}
+
+	if !isInline(wrapper.Values["v"]) {
+		return "", ErrUnsupportedEdit
+	}
+
+	var buffer bytes.Buffer
+
+	if err = toml.NewEncoder(&buffer).Encode(map[string]interface{}{"v": toEncodable(wrapper.Values["v"])}); nil != err {
+		return "", err
+	}
+
+	return strings.TrimSuffix(strings.TrimPrefix(buffer.String(), "v = "), "\n"), nil
+}
+
+// isInline returns whether a decoded JSON value is encoded as an inline value,
+// rather than as a table (or array of tables), which null values aren't at all
+func isInline(value interface{}) bool {
+	switch value := value.(type) {
+	case nil, *jsonvalue.Object:
+		return false
+	case []interface{}:
+		for _, element := range value {
+			if !isInline(element) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// findEntry finds the entry of the key path, whether it's set within its
+// table or by a dotted key, preferring an exact match to a case-insensitive
+// one
+func findEntry(entries []entry, path []string) *entry {
+	for _, equal := range []func(string, string) bool{func(a, b string) bool { return a == b }, strings.EqualFold} {
+		for i := len(entries) - 1; 0 <= i; i-- {
+			fullPath := append(append([]string(nil), entries[i].table...), entries[i].key...)
+
+			if !entries[i].inArray && equalPaths(fullPath, path, equal) {
+				return &entries[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// equalPaths returns whether two key paths are equal, by the given comparison
+func equalPaths(a []string, b []string, equal func(string, string) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !equal(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// insertLine inserts a key's line into the given table (or the root table,
+// if nil), after the table's last entry (indented as it is), or else after
+// the table's header
+func insertLine(document []byte, entries []entry, headers []header, table []string, line string) []byte {
+	newline := lineBreak(document)
+	offset := -1
+
+	for _, entry := range entries {
+		if !entry.inArray && equalPaths(entry.table, table, strings.EqualFold) {
+			offset = lineEnd(document, entry.valueEnd)
+			line = string(document[entry.lineStart:skipSpace(document, entry.lineStart)]) + strings.TrimLeft(line, " \t")
+		}
+	}
+
+	if -1 == offset && nil != table {
+		for _, header := range headers {
+			if equalPaths(header.path, table, strings.EqualFold) {
+				offset = header.lineEnd
+			}
+		}
+	}
+
+	if -1 == offset && 0 < len(headers) {
+		// Keys of the root table must precede the first table header
+		start := lineStartOf(document, headers[0])
+
+		return splice(document, start, start, line+newline+newline)
+	}
+
+	if -1 == offset {
+		return append(terminateLine(document), line+newline...)
+	}
+
+	if 0 < offset && '\n' != document[offset-1] {
+		return splice(document, offset, offset, newline+line)
+	}
+
+	return splice(document, offset, offset, line+newline)
+}
+
+// lineStartOf returns the offset of the start of a header's line
+func lineStartOf(document []byte, header header) int {
+	end := header.lineEnd
+
+	if 0 < end && '\n' == document[end-1] {
+		end--
+	}
+
+	return bytes.LastIndexByte(document[:end], '\n') + 1
+}
+
+// scanLayout scans the layout of the entries and table headers of a document,
+// returning ErrUnsupportedEdit for any syntax that it doesn't understand
+func scanLayout(document []byte) ([]entry, []header, error) {
+	var entries []entry
+	var headers []header
+	var table []string
+
+	inArray := false
+
+	for i := 0; i < len(document); {
+		lineStart := i
+		i = skipSpace(document, i)
+
+		if len(document) <= i {
+			break
+		}
+
+		switch document[i] {
+		case '\r', '\n':
+			i++
+		case '#':
+			i = lineEnd(document, i)
+		case '[':
+			isArray := i+1 < len(document) && '[' == document[i+1]
+			start := i + 1
+
+			if isArray {
+				start++
+			}
+
+			path, end, err := scanKey(document, start)
+
+			if nil != err {
+				return nil, nil, err
+			}
+
+			closing := "]"
+
+			if isArray {
+				closing = "]]"
+			}
+
+			if !bytes.HasPrefix(document[end:], []byte(closing)) {
+				return nil, nil, ErrUnsupportedEdit
+			}
+
+			table, inArray = path, isArray
+			i = lineEnd(document, end)
+			headers = append(headers, header{path: path, isArray: isArray, lineEnd: i})
+		default:
+			key, end, err := scanKey(document, i)
+
+			if nil != err {
+				return nil, nil, err
+			}
+
+			if len(document) <= end || '=' != document[end] {
+				return nil, nil, ErrUnsupportedEdit
+			}
+
+			valueStart := skipSpace(document, end+1)
+			valueEnd, err := scanValue(document, valueStart)
+
+			if nil != err {
+				return nil, nil, err
+			}
+
+			entries = append(entries, entry{table: table, key: key, inArray: inArray, lineStart: lineStart, valueStart: valueStart, valueEnd: valueEnd})
+			i = lineEnd(document, valueEnd)
+		}
+	}
+
+	return entries, headers, nil
+}
+
+// scanKey scans a (possibly dotted) key, returning its parts and the offset
+// after it (and any whitespace that follows it)
+func scanKey(document []byte, offset int) ([]string, int, error) {
+	var parts []string
+
+	for i := skipSpace(document, offset); i < len(document); i = skipSpace(document, i+1) {
+		var part string
+
+		switch document[i] {
+		case '"':
+			end, err := scanString(document, i)
+
+			if nil != err {
+				return nil, 0, err
+			}
+
+			if part, err = strconv.Unquote(string(document[i:end])); nil != err {
+				return nil, 0, ErrUnsupportedEdit
+			}
+
+			i = end
+		case '\'':
+			end, err := scanString(document, i)
+
+			if nil != err {
+				return nil, 0, err
+			}
+
+			part = string(document[i+1 : end-1])
+			i = end
+		default:
+			start := i
+
+			for i < len(document) && isBareKeyChar(document[i]) {
+				i++
+			}
+
+			if start == i {
+				return nil, 0, ErrUnsupportedEdit
+			}
+
+			part = string(document[start:i])
+		}
+
+		parts = append(parts, part)
+
+		if i = skipSpace(document, i); len(document) <= i || '.' != document[i] {
+			return parts, i, nil
+		}
+	}
+
+	return nil, 0, ErrUnsupportedEdit
+}
+
+// scanValue scans a value, returning the offset after it
+func scanValue(document []byte, offset int) (int, error) {
+	if len(document) <= offset {
+		return 0, ErrUnsupportedEdit
+	}
+
+	switch document[offset] {
+	case '"', '\'':
+		return scanString(document, offset)
+	case '[', '{':
+		depth := 0
+
+		for i := offset; i < len(document); i++ {
+			switch document[i] {
+			case '"', '\'':
+				end, err := scanString(document, i)
+
+				if nil != err {
+					return 0, err
+				}
+
+				i = end - 1
+			case '#':
+				i = lineEnd(document, i) - 1
+			case '[', '{':
+				depth++
+			case ']', '}':
+				if depth--; 0 == depth {
+					return i + 1, nil
+				}
+			}
+		}
+
+		return 0, ErrUnsupportedEdit
+	}
+
+	i := offset
+
+	for i < len(document) && -1 == strings.IndexByte(" \t\r\n#,]}", document[i]) {
+		i++
+	}
+
+	if offset == i {
+		return 0, ErrUnsupportedEdit
+	}
+
+	return i, nil
+}
+
+// scanString scans a basic or literal string (either of which may be a
+// multi-line string), returning the offset after it
+func scanString(document []byte, offset int) (int, error) {
+	quote := document[offset : offset+1]
+
+	if bytes.HasPrefix(document[offset:], bytes.Repeat(quote, 3)) {
+		closing := bytes.Repeat(quote, 3)
+
+		for i := offset + 3; i < len(document); i++ {
+			if '\\' == document[i] && '"' == quote[0] {
+				i++
+			} else if bytes.HasPrefix(document[i:], closing) {
+				end := i + 3
+
+				// Up to two quotes may precede the closing ones
+				for j := 0; j < 2 && end < len(document) && quote[0] == document[end]; j++ {
+					end++
+				}
+
+				return end, nil
+			}
+		}
+
+		return 0, ErrUnsupportedEdit
+	}
+
+	for i := offset + 1; i < len(document) && '\n' != document[i]; i++ {
+		if '\\' == document[i] && '"' == quote[0] {
+			i++
+		} else if quote[0] == document[i] {
+			return i + 1, nil
+		}
+	}
+
+	return 0, ErrUnsupportedEdit
+}
+
+// isBareKeyChar returns whether a character may be part of a bare key
+func isBareKeyChar(char byte) bool {
+	return ('A' <= char && char <= 'Z') || ('a' <= char && char <= 'z') || ('0' <= char && char <= '9') || '_' == char || '-' == char
+}
+
+// skipSpace returns the offset of the first character from the given offset
+// that isn't a space or a tab
+func skipSpace(document []byte, offset int) int {
+	for offset < len(document) && (' ' == document[offset] || '\t' == document[offset]) {
+		offset++
+	}
+
+	return offset
+}
+
+// lineEnd returns the offset after the end of the line at the given offset,
+// including its line break
+func lineEnd(document []byte, offset int) int {
+	if end := bytes.IndexByte(document[offset:], '\n'); -1 != end {
+		return offset + end + 1
+	}
+
+	return len(document)
+}
+
+// lineBreak returns the line break that a document uses
+func lineBreak(document []byte) string {
+	if bytes.Contains(document, []byte("\r\n")) {
+		return "\r\n"
+	}
+
+	return "\n"
+}
+
+// terminateLine returns a copy of a document that ends with a line break (if
+// it isn't empty)
+func terminateLine(document []byte) []byte {
+	terminated := append([]byte(nil), document...)
+
+	if 0 < len(terminated) && '\n' != terminated[len(terminated)-1] {
+		terminated = append(terminated, lineBreak(document)...)
+	}
+
+	return terminated
+}
+
+// splice returns a copy of a document with the given range replaced by text
+func splice(document []byte, start int, end int, text string) []byte {
+	spliced := make([]byte, 0, len(document)-(end-start)+len(text))
+	spliced = append(spliced, document[:start]...)
+	spliced = append(spliced, text...)
+
+	return append(spliced, document[end:]...)
+}
@@ -0,0 +1,147 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package toml
+
+import "testing"
+
+func TestSetValue(t *testing.T) {
+	testData := []struct {
+		name     string
+		document string
+		path     []string
+		value    string
+		want     string
+	}{
+		{
+			"replace with comments",
+			"# My config\nIndentationSize = 2 # two spaces\nSource = \"x\"\n",
+			[]string{"IndentationSize"}, `4`,
+			"# My config\nIndentationSize = 4 # two spaces\nSource = \"x\"\n",
+		},
+		{
+			"replace case-insensitively",
+			"indentationsize = 2\n",
+			[]string{"IndentationSize"}, `4`,
+			"indentationsize = 4\n",
+		},
+		{
+			"replace in table",
+			"# Accounts\n[OxfordDictionary]\n# my work account\nAppID = \"old\" # id\nAppKey = \"k\"\n",
+			[]string{"OxfordDictionary", "AppID"}, `"new"`,
+			"# Accounts\n[OxfordDictionary]\n# my work account\nAppID = \"new\" # id\nAppKey = \"k\"\n",
+		},
+		{
+			"replace dotted key",
+			"OxfordDictionary.AppID = \"old\"\n",
+			[]string{"OxfordDictionary", "AppID"}, `"new"`,
+			"OxfordDictionary.AppID = \"new\"\n",
+		},
+		{
+			"replace multi-line array",
+			"Args = [\n  \"a\", # first\n  \"b\",\n]\nNext = 1\n",
+			[]string{"Args"}, `["c"]`,
+			"Args = [\"c\"]\nNext = 1\n",
+		},
+		{
+			"add root key before tables",
+			"# Top\nA = 1\n\n[OxfordDictionary]\nAppID = \"id\"\n",
+			[]string{"B"}, `true`,
+			"# Top\nA = 1\nB = true\n\n[OxfordDictionary]\nAppID = \"id\"\n",
+		},
+		{
+			"add root key without root keys",
+			"# Top\n[OxfordDictionary]\nAppID = \"id\"\n",
+			[]string{"B"}, `"x y"`,
+			"# Top\nB = \"x y\"\n\n[OxfordDictionary]\nAppID = \"id\"\n",
+		},
+		{
+			"add key to indented table",
+			"[OxfordDictionary] # keys\n  AppID = \"id\"\n\n[Other]\nx = 1\n",
+			[]string{"OxfordDictionary", "AppKey"}, `"key"`,
+			"[OxfordDictionary] # keys\n  AppID = \"id\"\n  AppKey = \"key\"\n\n[Other]\nx = 1\n",
+		},
+		{
+			"add key to empty table",
+			"[OxfordDictionary]",
+			[]string{"OxfordDictionary", "AppKey"}, `"key"`,
+			"[OxfordDictionary]\nAppKey = \"key\"",
+		},
+		{
+			"add table",
+			"A = 1 # one",
+			[]string{"OxfordDictionary", "AppKey"}, `"key"`,
+			"A = 1 # one\n\n[OxfordDictionary]\nAppKey = \"key\"\n",
+		},
+		{
+			"add to empty document",
+			"",
+			[]string{"A"}, `1`,
+			"A = 1\n",
+		},
+		{
+			"keep line breaks",
+			"# c\r\nA = 1\r\n",
+			[]string{"B"}, `2`,
+			"# c\r\nA = 1\r\nB = 2\r\n",
+		},
+		{
+			"keep strings that look like comments",
+			"A = \"# not a comment\" # a comment\n",
+			[]string{"A"}, `"x"`,
+			"A = \"x\" # a comment\n",
+		},
+	}
+
+	for _, data := range testData {
+		got, err := SetValue([]byte(data.document), data.path, []byte(data.value))
+
+		if nil != err {
+			t.Errorf("%s: SetValue returned error %q", data.name, err)
+			continue
+		}
+
+		if data.want != string(got) {
+			t.Errorf("%s: SetValue returned %q, want %q", data.name, got, data.want)
+		}
+	}
+}
+
+func TestSetValueUnsupported(t *testing.T) {
+	testData := []struct {
+		document string
+		path     []string
+		value    string
+	}{
+		{"OxfordDictionary = { AppID = \"id\" }\n", []string{"OxfordDictionary", "AppKey"}, `"key"`},
+		{"OxfordDictionary.AppID = \"id\"\n", []string{"OxfordDictionary", "AppKey"}, `"key"`},
+		{"[[ExecSources]]\nName = \"a\"\n", []string{"ExecSources", "Name"}, `"b"`},
+		{"A = 1\n", []string{"Theme"}, `{"headword": "bold"}`},
+		{"A = 1\n", []string{"A"}, `null`},
+	}
+
+	for _, data := range testData {
+		if _, err := SetValue([]byte(data.document), data.path, []byte(data.value)); ErrUnsupportedEdit != err {
+			t.Errorf("SetValue(%q, %q) returned error %v, want %v", data.document, data.path, err, ErrUnsupportedEdit)
+		}
+	}
+}
+
+func TestHasComments(t *testing.T) {
+	testData := map[string]bool{
+		"":                              false,
+		"A = 1\n":                       false,
+		"A = \"#1\"\nB = '#2'\n":        false,
+		"A = \"\"\"\n# text\n\"\"\"\n":  false,
+		"\"// Key\" = \"A note\"\n":     false,
+		"# A comment\nA = 1\n":          true,
+		"A = 1 # trailing\n":            true,
+		"A = [\n  1, # one\n]\n":        true,
+		"A = \"\\\"\" # after escape\n": true,
+	}
+
+	for document, want := range testData {
+		if got := HasComments([]byte(document)); want != got {
+			t.Errorf("HasComments(%q) returned %t, want %t", document, got, want)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package toml provides conversions between TOML documents and JSON objects,
+// so that TOML config files can be decoded and encoded through the same JSON
+// structures as JSON config files.
+//
+// Only the TOML values that have a JSON equivalent are supported, so date-time
+// values aren't. Comments aren't kept by the conversions, but a single key can
+// be set in place (see SetValue), keeping the rest of a document as it is.
+package toml
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/Rican7/define/internal/jsonvalue"
+)
+
+// SyntaxError represents an error in the syntax of a TOML document
+type SyntaxError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+// Error satisfies the error interface.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// ToJSON converts a TOML document into an equivalent JSON object
+func ToJSON(document []byte) ([]byte, error) {
+	root := make(map[string]interface{})
+
+	meta, err := toml.Decode(string(document), &root)
+
+	if nil != err {
+		if parseErr, ok := err.(toml.ParseError); ok {
+			return nil, newSyntaxError(document, parseErr)
+		}
+
+		return nil, err
+	}
+
+	// Keep the keys in the order of the document
+	positions := make(map[string]int)
+
+	for i, key := range meta.Keys() {
+		if _, exists := positions[key.String()]; !exists {
+			positions[key.String()] = i
+		}
+	}
+
+	return jsonvalue.Marshal(toObject(root, "", positions))
+}
+
+// FromJSON converts a JSON object into an equivalent TOML document.
+//
+// The keys of each table are written in order, with those of tables following
+// the others. JSON null values have no TOML equivalent, so their keys are
+// omitted.
+func FromJSON(object []byte) ([]byte, error) {
+	root, err := jsonvalue.UnmarshalObject(object)
+
+	if nil != err {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+
+	encoder := toml.NewEncoder(&buffer)
+	encoder.Indent = ""
+
+	if err = encoder.Encode(toEncodable(root)); nil != err {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// newSyntaxError creates a SyntaxError from the parse error of a document
+func newSyntaxError(document []byte, err toml.ParseError) *SyntaxError {
+	column := err.Position.Start + 1
+
+	if start := err.Position.Start; 0 <= start && start <= len(document) {
+		column = start - bytes.LastIndexByte(document[:start], '\n')
+	}
+
+	message := err.Message
+
+	if "" == message {
+		// Strip the position that the error's text begins with
+		prefix := fmt.Sprintf("toml: line %d: ", err.Position.Line)
+
+		if "" != err.LastKey {
+			prefix = fmt.Sprintf("toml: line %d (last key %q): ", err.Position.Line, err.LastKey)
+		}
+
+		message = strings.TrimPrefix(err.Error(), prefix)
+	}
+
+	return &SyntaxError{Line: err.Position.Line, Column: column, Message: message}
+}
+
+// toObject converts the tables of a decoded TOML value into objects, ordering
+// their keys by the given positions of their (dotted) key paths
+func toObject(value interface{}, path string, positions map[string]int) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		keyPaths := make(map[string]string, len(value))
+
+		for key := range value {
+			keys = append(keys, key)
+			keyPaths[key] = toml.Key{key}.String()
+
+			if "" != path {
+				keyPaths[key] = path + "." + keyPaths[key]
+			}
+		}
+
+		sort.Strings(keys)
+		sort.SliceStable(keys, func(i, j int) bool {
+			return positions[keyPaths[keys[i]]] < positions[keyPaths[keys[j]]]
+		})
+
+		object := jsonvalue.NewObject()
+
+		for _, key := range keys {
+			object.Set(key, toObject(value[key], keyPaths[key], positions))
+		}
+
+		return object
+	case []map[string]interface{}:
+		values := make([]interface{}, len(value))
+
+		for i, element := range value {
+			values[i] = toObject(element, path, positions)
+		}
+
+		return values
+	case []interface{}:
+		values := make([]interface{}, len(value))
+
+		for i, element := range value {
+			values[i] = toObject(element, path, positions)
+		}
+
+		return values
+	}
+
+	return value
+}
+
+// interfaceType is the type of the fields of the structs that objects are
+// encoded as
+var interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// toEncodable converts the objects of a decoded JSON value into values that
+// the TOML encoder encodes as tables, in the order of their keys.
+//
+// The encoder writes the keys of maps in alphabetical order, but the fields of
+// structs in order, so objects are converted into structs (of fields named by
+// their tags) unless they have keys that can't be written as tags.
+func toEncodable(value interface{}) interface{} {
+	switch value := value.(type) {
+	case *jsonvalue.Object:
+		fields := make([]reflect.StructField, len(value.Keys))
+
+		for i, key := range value.Keys {
+			if "-" == key || strings.Contains(key, ",") {
+				return toMap(value)
+			}
+
+			fields[i] = reflect.StructField{
+				Name: fmt.Sprintf("Field%d", i),
+				Type: interfaceType,
+				Tag:  reflect.StructTag(fmt.Sprintf("toml:%q", key)),
+			}
+		}
+
+		table := reflect.New(reflect.StructOf(fields)).Elem()
+
+		for i, key := range value.Keys {
+			if element := toEncodable(value.Values[key]); nil != element {
+				table.Field(i).Set(reflect.ValueOf(element))
+			}
+		}
+
+		return table.Interface()
+	case []interface{}:
+		values := make([]interface{}, len(value))
+
+		for i, element := range value {
+			values[i] = toEncodable(element)
+		}
+
+		return values
+	}
+
+	return value
+}
+
+// toMap converts an object into a map, of encodable values
+func toMap(object *jsonvalue.Object) map[string]interface{} {
+	table := make(map[string]interface{}, len(object.Keys))
+
+	for _, key := range object.Keys {
+		table[key] = toEncodable(object.Values[key])
+	}
+
+	return table
+}
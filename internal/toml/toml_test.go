@@ -0,0 +1,167 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package toml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToJSON(t *testing.T) {
+	testData := []struct {
+		document string
+		want     string
+	}{
+		{"", `{}`},
+		{"a = 1\nb = -2.5\nc = true\nd = \"x\\ty\"\ne = 'C:\\path'", `{"a":1,"b":-2.5,"c":true,"d":"x\ty","e":"C:\\path"}`},
+		{"n = 1_000\nh = 0xff\nf = 1e3", `{"n":1000,"h":255,"f":1000}`},
+		{"list = [\n  1,\n  2, # two\n]\nempty = []", `{"list":[1,2],"empty":[]}`},
+		{"s = \"\"\"\nline one\nline two\"\"\"", `{"s":"line one\nline two"}`},
+		{"inline = { a = 1, b.c = \"d\" }", `{"inline":{"a":1,"b":{"c":"d"}}}`},
+		{"[Table]\nKey = \"v\"\n\n[Table.Sub]\nx = 1", `{"Table":{"Key":"v","Sub":{"x":1}}}`},
+		{"[A.B]\nx = 1\n[A]\ny = 2", `{"A":{"B":{"x":1},"y":2}}`},
+		{"[[List]]\nName = \"a\"\n[[List]]\nName = \"b\"", `{"List":[{"Name":"a"},{"Name":"b"}]}`},
+		{"\"// Key\" = \"A note\"\n# A comment\nKey = 1 # trailing", `{"// Key":"A note","Key":1}`},
+		{"a = 1\r\nb = 2\r\n", `{"a":1,"b":2}`},
+	}
+
+	for _, data := range testData {
+		got, err := ToJSON([]byte(data.document))
+
+		if nil != err {
+			t.Errorf("ToJSON(%q) returned error %q", data.document, err)
+			continue
+		}
+
+		if data.want != string(got) {
+			t.Errorf("ToJSON(%q) returned %s, want %s", data.document, got, data.want)
+		}
+	}
+}
+
+func TestToJSONErrors(t *testing.T) {
+	testData := []struct {
+		document   string
+		wantLine   int
+		wantColumn int
+	}{
+		{"a = 1\nb 2", 2, 3},
+		{"a = 1\na = 2", 2, 1},
+		{"a = \"unterminated\nb = 1", 1, 18},
+		{"a = [1 2]", 1, 8},
+		{"a = yes", 1, 5},
+		{"a = \"\\q\"", 1, 6},
+	}
+
+	for _, data := range testData {
+		_, err := ToJSON([]byte(data.document))
+		syntaxErr, ok := err.(*SyntaxError)
+
+		if !ok {
+			t.Errorf("ToJSON(%q) returned error %v, want a *SyntaxError", data.document, err)
+			continue
+		}
+
+		if data.wantLine != syntaxErr.Line || data.wantColumn != syntaxErr.Column || "" == syntaxErr.Message {
+			t.Errorf(
+				"ToJSON(%q) returned an error at %d:%d (%s), want %d:%d",
+				data.document,
+				syntaxErr.Line,
+				syntaxErr.Column,
+				syntaxErr.Message,
+				data.wantLine,
+				data.wantColumn,
+			)
+		}
+
+		if strings.HasPrefix(syntaxErr.Message, "toml:") {
+			t.Errorf("ToJSON(%q) returned the message %q, want it without its position", data.document, syntaxErr.Message)
+		}
+	}
+
+	if _, err := ToJSON([]byte("a = 1979-05-27")); nil == err {
+		t.Errorf("ToJSON didn't return an error for a date-time value")
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	object := `{
+		"// IndentationSize": "The indentation",
+		"IndentationSize": 2,
+		"Source": "a \"quoted\" <value>",
+		"Nothing": null,
+		"Empty": [],
+		"Dictionary": {"App Key": "", "// App Key": "The key"},
+		"ExecSources": [{"Name": "a", "Args": ["-x", 1.5]}, {"Name": "b", "Env": {"K": true}}]
+	}`
+
+	want := `"// IndentationSize" = "The indentation"
+IndentationSize = 2
+Source = "a \"quoted\" <value>"
+Empty = []
+
+[Dictionary]
+"App Key" = ""
+"// App Key" = "The key"
+
+[[ExecSources]]
+Name = "a"
+Args = ["-x", 1.5]
+
+[[ExecSources]]
+Name = "b"
+[ExecSources.Env]
+K = true
+`
+
+	got, err := FromJSON([]byte(object))
+
+	if nil != err {
+		t.Fatalf("FromJSON returned error %q", err)
+	}
+
+	if want != string(got) {
+		t.Errorf("FromJSON returned:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFromJSONKeysWithCommas(t *testing.T) {
+	got, err := FromJSON([]byte(`{"b": 1, "a, c": 2}`))
+
+	if nil != err {
+		t.Fatalf("FromJSON returned error %q", err)
+	}
+
+	// Keys that can't be written in order are written in alphabetical order
+	if want := "\"a, c\" = 2\nb = 1\n"; want != string(got) {
+		t.Errorf("FromJSON returned %q, want %q", got, want)
+	}
+}
+
+func TestFromJSONErrors(t *testing.T) {
+	for _, object := range []string{`[]`, `{"a": [null]}`, `{"a": }`} {
+		if _, err := FromJSON([]byte(object)); nil == err {
+			t.Errorf("FromJSON(%q) didn't return an error", object)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	object := `{"// A":"The A","A":1,"B":{"// C":"The C","C":"c","D":[1,2]},"E":[{"F":false}]}`
+
+	document, err := FromJSON([]byte(object))
+
+	if nil != err {
+		t.Fatalf("FromJSON returned error %q", err)
+	}
+
+	got, err := ToJSON(document)
+
+	if nil != err {
+		t.Fatalf("ToJSON returned error %q for document:\n%s", err, document)
+	}
+
+	if object != string(got) {
+		t.Errorf("round trip returned %s, want %s", got, object)
+	}
+}
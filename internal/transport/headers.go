@@ -0,0 +1,49 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package transport
+
+import "net/http"
+
+// headerTransport is an HTTP transport that sets custom headers on each of
+// the requests that it sends through its inner transport
+type headerTransport struct {
+	inner   http.RoundTripper
+	headers map[string]string
+}
+
+// WithHeaders returns an HTTP transport that sets the given headers on each
+// request (replacing any of the same names) before sending it through the
+// inner transport, where a nil inner transport uses the http.DefaultTransport
+// at the time of each request. The inner transport is returned as is when
+// there are no headers to set.
+func WithHeaders(inner http.RoundTripper, headers map[string]string) http.RoundTripper {
+	if len(headers) < 1 {
+		return inner
+	}
+
+	return &headerTransport{inner: inner, headers: headers}
+}
+
+// RoundTrip sends the request, with its headers set, through the inner transport
+func (t *headerTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	inner := t.inner
+
+	if nil == inner {
+		inner = http.DefaultTransport
+	}
+
+	// A transport mustn't modify its request, so we set the headers on a copy
+	copied := new(http.Request)
+	*copied = *request
+	copied.Header = make(http.Header, len(request.Header)+len(t.headers))
+
+	for name, values := range request.Header {
+		copied.Header[name] = append([]string(nil), values...)
+	}
+
+	for name, value := range t.headers {
+		copied.Header.Set(name, value)
+	}
+
+	return inner.RoundTrip(copied)
+}
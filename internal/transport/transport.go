@@ -0,0 +1,98 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package transport provides the construction of the HTTP transport shared by
+// the app's sources, for customizing how they connect.
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+// TLSOptions defines the options for the TLS connections of the transport
+type TLSOptions struct {
+	// CACertFile is the location of a PEM encoded bundle of CA certificates
+	// to trust, in addition to the system's
+	CACertFile string
+
+	// Insecure disables the verification of server certificates entirely
+	Insecure bool
+}
+
+// IsDefault returns whether the options are all unset, and therefore the
+// default transport needs no customization.
+func (o TLSOptions) IsDefault() bool {
+	return "" == o.CACertFile && !o.Insecure
+}
+
+// New returns a new HTTP transport, with the same behavior as the default
+// http.DefaultTransport (including HTTP/2 support), but with its TLS
+// connections configured by the given options.
+func New(opts TLSOptions) (*http.Transport, error) {
+	tlsConfig, err := newTLSConfig(opts)
+
+	if nil != err {
+		return nil, err
+	}
+
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			DualStack: true,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       tlsConfig,
+	}
+
+	// A custom TLS config disables the automatic HTTP/2 support, so we have
+	// to explicitly configure it
+	if err = http2.ConfigureTransport(transport); nil != err {
+		return nil, err
+	}
+
+	return transport, nil
+}
+
+// newTLSConfig creates a TLS config from the given options
+func newTLSConfig(opts TLSOptions) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: opts.Insecure,
+	}
+
+	if "" == opts.CACertFile {
+		return tlsConfig, nil
+	}
+
+	pemCerts, err := ioutil.ReadFile(opts.CACertFile)
+
+	if nil != err {
+		return nil, fmt.Errorf("error reading CA certificate file %q with error: %s", opts.CACertFile, err)
+	}
+
+	certPool, err := x509.SystemCertPool()
+
+	// The system's pool isn't available on all platforms (such as Windows)
+	if nil != err || nil == certPool {
+		certPool = x509.NewCertPool()
+	}
+
+	if !certPool.AppendCertsFromPEM(pemCerts) {
+		return nil, fmt.Errorf("CA certificate file %q doesn't contain any valid PEM encoded certificates", opts.CACertFile)
+	}
+
+	tlsConfig.RootCAs = certPool
+
+	return tlsConfig, nil
+}
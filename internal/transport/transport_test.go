@@ -0,0 +1,135 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package transport
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTempFile(t *testing.T, contents []byte) string {
+	dir, err := ioutil.TempDir("", "define-transport")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "ca.pem")
+
+	if err = ioutil.WriteFile(path, contents, 0600); nil != err {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestIsDefault(t *testing.T) {
+	if !(TLSOptions{}).IsDefault() {
+		t.Errorf("IsDefault returned false for unset options")
+	}
+
+	if (TLSOptions{Insecure: true}).IsDefault() {
+		t.Errorf("IsDefault returned true for insecure options")
+	}
+}
+
+func TestNewTrustsCACertFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	certPath := writeTempFile(t, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	defer os.RemoveAll(filepath.Dir(certPath))
+
+	transport, err := New(TLSOptions{CACertFile: certPath})
+
+	if nil != err {
+		t.Fatalf("New returned error: %s", err)
+	}
+
+	response, err := (&http.Client{Transport: transport}).Get(server.URL)
+
+	if nil != err {
+		t.Fatalf("request with the trusted CA failed: %s", err)
+	}
+
+	response.Body.Close()
+
+	if _, err = (&http.Client{Transport: http.DefaultTransport}).Get(server.URL); nil == err {
+		t.Errorf("request without the trusted CA succeeded")
+	}
+}
+
+func TestNewInsecure(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	transport, err := New(TLSOptions{Insecure: true})
+
+	if nil != err {
+		t.Fatalf("New returned error: %s", err)
+	}
+
+	response, err := (&http.Client{Transport: transport}).Get(server.URL)
+
+	if nil != err {
+		t.Fatalf("insecure request failed: %s", err)
+	}
+
+	response.Body.Close()
+}
+
+func TestNewInvalidCACertFile(t *testing.T) {
+	certPath := writeTempFile(t, []byte("not a certificate"))
+	defer os.RemoveAll(filepath.Dir(certPath))
+
+	if _, err := New(TLSOptions{CACertFile: certPath}); nil == err {
+		t.Errorf("New didn't return an error for an invalid CA certificate file")
+	}
+
+	if _, err := New(TLSOptions{CACertFile: certPath + ".missing"}); nil == err {
+		t.Errorf("New didn't return an error for a missing CA certificate file")
+	}
+}
+
+func TestWithHeaders(t *testing.T) {
+	var received http.Header
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header
+	}))
+	defer server.Close()
+
+	if inner := WithHeaders(http.DefaultTransport, nil); http.DefaultTransport != inner {
+		t.Errorf("WithHeaders without headers returned %v, want the inner transport", inner)
+	}
+
+	client := &http.Client{Transport: WithHeaders(nil, map[string]string{"referer": "https://example.com", "X-Api-Version": "2"})}
+	request, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	request.Header.Set("Referer", "https://example.org")
+	request.Header.Set("Accept", "application/json")
+
+	response, err := client.Do(request)
+
+	if nil != err {
+		t.Fatalf("request with headers failed: %s", err)
+	}
+
+	response.Body.Close()
+
+	want := map[string]string{"Referer": "https://example.com", "X-Api-Version": "2", "Accept": "application/json"}
+
+	for name, value := range want {
+		if got := received.Get(name); value != got {
+			t.Errorf("request had header %q of %q, want %q", name, got, value)
+		}
+	}
+
+	if got := request.Header.Get("Referer"); "https://example.org" != got {
+		t.Errorf("WithHeaders modified the original request's header to %q", got)
+	}
+}
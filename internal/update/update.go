@@ -0,0 +1,391 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package update provides mechanisms for checking for, and updating to, newer
+// released versions of the application.
+package update
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/Rican7/define/internal/version"
+)
+
+const (
+	// latestReleaseURL is the URL of the GitHub API's latest release resource
+	latestReleaseURL = "https://api.github.com/repos/Rican7/define/releases/latest"
+
+	// checksumFileExtension is the extension of the checksum file published
+	// alongside each released binary
+	checksumFileExtension = ".sha256"
+
+	httpRequestAcceptHeaderName = "Accept"
+	githubJSONMIMEType          = "application/vnd.github.v3+json"
+)
+
+// managedInstallPathPrefixes is the list of path prefixes of executables that
+// were installed by a package manager, and therefore shouldn't be replaced
+var managedInstallPathPrefixes = []string{
+	"/usr/bin/",
+	"/usr/sbin/",
+	"/usr/share/",
+	"/usr/local/Cellar/",
+	"/opt/homebrew/",
+	"/home/linuxbrew/",
+	"/nix/store/",
+	"/snap/",
+}
+
+// Release defines a published release of the application
+type Release struct {
+	TagName string  `json:"tag_name"`
+	Assets  []Asset `json:"assets"`
+}
+
+// Asset defines a downloadable file of a release
+type Asset struct {
+	Name        string `json:"name"`
+	DownloadURL string `json:"browser_download_url"`
+}
+
+// DevelopmentVersionError represents an error that occurs when checking for an
+// update of a development (non-release) build
+type DevelopmentVersionError struct{}
+
+// ManagedInstallError represents an error that occurs when attempting to
+// replace an executable that was installed by a package manager
+type ManagedInstallError struct {
+	Path string
+}
+
+// ChecksumError represents an error that occurs when a downloaded binary
+// doesn't match its published checksum
+type ChecksumError struct {
+	Name     string
+	Expected string
+	Actual   string
+}
+
+// Error returns the error message as a string
+func (e *DevelopmentVersionError) Error() string {
+	return fmt.Sprintf("can't check for updates of a development build (%s)", version.Name())
+}
+
+// Error returns the error message as a string
+func (e *ManagedInstallError) Error() string {
+	return fmt.Sprintf(
+		"the executable at %q appears to be managed by a package manager; update it with that package manager instead",
+		e.Path,
+	)
+}
+
+// Error returns the error message as a string
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("checksum mismatch for %q: expected %s, got %s", e.Name, e.Expected, e.Actual)
+}
+
+// Latest fetches the latest published release.
+func Latest(httpClient *http.Client) (*Release, error) {
+	request, err := http.NewRequest(http.MethodGet, latestReleaseURL, nil)
+
+	if nil != err {
+		return nil, err
+	}
+
+	request.Header.Set(httpRequestAcceptHeaderName, githubJSONMIMEType)
+
+	response, err := httpClient.Do(request)
+
+	if nil != err {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if http.StatusOK != response.StatusCode {
+		return nil, fmt.Errorf("unable to fetch the latest release: %s", response.Status)
+	}
+
+	var release Release
+
+	if err = json.NewDecoder(response.Body).Decode(&release); nil != err {
+		return nil, err
+	}
+
+	return &release, nil
+}
+
+// IsNewer returns whether the release is newer than the running version.
+func (r *Release) IsNewer() (bool, error) {
+	current := version.Name()
+
+	if strings.HasPrefix(current, "dev") {
+		return false, &DevelopmentVersionError{}
+	}
+
+	return compareVersions(r.TagName, current) > 0, nil
+}
+
+// Asset returns the release's asset with the given name, or nil if the release
+// has no asset by that name.
+func (r *Release) Asset(name string) *Asset {
+	for i := range r.Assets {
+		if name == r.Assets[i].Name {
+			return &r.Assets[i]
+		}
+	}
+
+	return nil
+}
+
+// Install downloads the release's binary for the running platform, verifies it
+// against its published checksum, and atomically replaces the running
+// executable with it.
+func (r *Release) Install(httpClient *http.Client) error {
+	executable, err := executablePath()
+
+	if nil != err {
+		return err
+	}
+
+	name := binaryName()
+	binaryAsset := r.Asset(name)
+	checksumAsset := r.Asset(name + checksumFileExtension)
+
+	if nil == binaryAsset || nil == checksumAsset {
+		return fmt.Errorf("release %s has no binary for %s/%s", r.TagName, runtime.GOOS, runtime.GOARCH)
+	}
+
+	checksumFile, err := download(httpClient, checksumAsset.DownloadURL)
+
+	if nil != err {
+		return err
+	}
+
+	binary, err := download(httpClient, binaryAsset.DownloadURL)
+
+	if nil != err {
+		return err
+	}
+
+	// The checksum file is in the format of `sha256sum`: "<hash>  <file>"
+	fields := strings.Fields(string(checksumFile))
+
+	if len(fields) < 1 {
+		return fmt.Errorf("checksum file for %q is empty", name)
+	}
+
+	sum := sha256.Sum256(binary)
+	expected, actual := strings.ToLower(fields[0]), hex.EncodeToString(sum[:])
+
+	if expected != actual {
+		return &ChecksumError{Name: name, Expected: expected, Actual: actual}
+	}
+
+	return replaceExecutable(executable, binary)
+}
+
+// executablePath returns the resolved path of the running executable, making
+// sure that it's one that we're allowed to replace
+func executablePath() (string, error) {
+	path, err := os.Executable()
+
+	if nil != err {
+		return "", err
+	}
+
+	if path, err = filepath.EvalSymlinks(path); nil != err {
+		return "", err
+	}
+
+	for _, prefix := range managedInstallPathPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return "", &ManagedInstallError{Path: path}
+		}
+	}
+
+	return path, nil
+}
+
+// replaceExecutable atomically replaces the executable at the given path with
+// the given binary, by writing it to a temporary file in the same directory
+// and renaming it into place
+func replaceExecutable(path string, binary []byte) error {
+	info, err := os.Stat(path)
+
+	if nil != err {
+		return err
+	}
+
+	temp, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".")
+
+	if nil != err {
+		return err
+	}
+
+	tempPath := temp.Name()
+
+	if _, err = io.Copy(temp, bytes.NewReader(binary)); nil == err {
+		err = temp.Chmod(info.Mode().Perm())
+	}
+
+	if closeErr := temp.Close(); nil == err {
+		err = closeErr
+	}
+
+	if nil != err {
+		os.Remove(tempPath)
+
+		return err
+	}
+
+	// Windows doesn't allow replacing a running executable, but does allow
+	// renaming it out of the way
+	if "windows" == runtime.GOOS {
+		oldPath := path + ".old"
+		os.Remove(oldPath)
+
+		if err = os.Rename(path, oldPath); nil != err {
+			os.Remove(tempPath)
+
+			return err
+		}
+	}
+
+	if err = os.Rename(tempPath, path); nil != err {
+		os.Remove(tempPath)
+
+		return err
+	}
+
+	return nil
+}
+
+// download downloads the contents at the given URL
+func download(httpClient *http.Client, url string) ([]byte, error) {
+	response, err := httpClient.Get(url)
+
+	if nil != err {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if http.StatusOK != response.StatusCode {
+		return nil, fmt.Errorf("unable to download %q: %s", url, response.Status)
+	}
+
+	return ioutil.ReadAll(response.Body)
+}
+
+// binaryName returns the name of the released binary for the running platform
+func binaryName() string {
+	name := fmt.Sprintf("%s_%s_%s", version.AppName, runtime.GOOS, runtime.GOARCH)
+
+	if "windows" == runtime.GOOS {
+		name += ".exe"
+	}
+
+	return name
+}
+
+// compareVersions compares two version tags (such as "v1.2.3") by their
+// numeric components, and then by any pre-release suffix (such as "-rc.1"),
+// which is older than the version without one, returning a positive number if
+// a is newer than b, a negative number if b is newer than a, and 0 if they're
+// equal
+func compareVersions(a, b string) int {
+	aParts, aPreRelease := versionParts(a)
+	bParts, bPreRelease := versionParts(b)
+
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aPart, bPart int
+
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+
+		if aPart != bPart {
+			return aPart - bPart
+		}
+	}
+
+	switch {
+	case aPreRelease == bPreRelease:
+		return 0
+	case "" == aPreRelease:
+		return 1
+	case "" == bPreRelease:
+		return -1
+	}
+
+	return comparePreReleases(aPreRelease, bPreRelease)
+}
+
+// comparePreReleases compares two pre-release suffixes by their dot-separated
+// identifiers, comparing numeric identifiers numerically, as in semantic
+// versioning (so that "rc.10" is newer than "rc.9")
+func comparePreReleases(a, b string) int {
+	aIdentifiers, bIdentifiers := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := 0; i < len(aIdentifiers) && i < len(bIdentifiers); i++ {
+		aNumber, aErr := strconv.Atoi(aIdentifiers[i])
+		bNumber, bErr := strconv.Atoi(bIdentifiers[i])
+
+		switch {
+		case nil == aErr && nil == bErr:
+			if aNumber != bNumber {
+				return aNumber - bNumber
+			}
+		case nil == aErr:
+			return -1
+		case nil == bErr:
+			return 1
+		default:
+			if comparison := strings.Compare(aIdentifiers[i], bIdentifiers[i]); 0 != comparison {
+				return comparison
+			}
+		}
+	}
+
+	return len(aIdentifiers) - len(bIdentifiers)
+}
+
+// versionParts returns the numeric components of a version tag, and its
+// pre-release suffix (if any), ignoring any "v" prefix and build metadata
+func versionParts(tag string) ([]int, string) {
+	var parts []int
+	var preRelease string
+
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "v")
+
+	if i := strings.Index(tag, "+"); i >= 0 {
+		tag = tag[:i]
+	}
+
+	if i := strings.Index(tag, "-"); i >= 0 {
+		tag, preRelease = tag[:i], tag[i+1:]
+	}
+
+	for _, part := range strings.Split(tag, ".") {
+		number, _ := strconv.Atoi(part)
+		parts = append(parts, number)
+	}
+
+	return parts, preRelease
+}
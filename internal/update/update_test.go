@@ -0,0 +1,229 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package update
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	testData := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{"v1.2.3", "v1.2.3", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{" v1.2.3 ", "v1.2.3", 0},
+		{"v1.2.4", "v1.2.3", 1},
+		{"v1.10.0", "v1.9.0", 1},
+		{"v2.0.0", "v1.99.99", 1},
+		{"v1.2", "v1.2.0", 0},
+		{"v1.2", "v1.2.1", -1},
+		{"v1.2.0.1", "v1.2.0", 1},
+		{"v1.2.0", "v1.2.0-rc.1", 1},
+		{"v1.2.0-rc.1", "v1.2.0", -1},
+		{"v1.2.0-rc.1", "v1.1.9", 1},
+		{"v1.2.0-rc.10", "v1.2.0-rc.9", 1},
+		{"v1.2.0-beta", "v1.2.0-alpha", 1},
+		{"v1.2.0-alpha.1", "v1.2.0-alpha", 1},
+		{"v1.2.0-alpha.beta", "v1.2.0-alpha.1", 1},
+		{"v1.2.0+build.5", "v1.2.0", 0},
+	}
+
+	for _, data := range testData {
+		got := compareVersions(data.a, data.b)
+
+		if (0 < data.want) != (0 < got) || (0 > data.want) != (0 > got) {
+			t.Errorf("compareVersions(%q, %q) returned %d, want a result like %d", data.a, data.b, got, data.want)
+		}
+	}
+}
+
+func TestVersionParts(t *testing.T) {
+	testData := []struct {
+		tag            string
+		wantParts      []int
+		wantPreRelease string
+	}{
+		{"v1.2.3", []int{1, 2, 3}, ""},
+		{"1.2", []int{1, 2}, ""},
+		{"v1.2.3-rc.1+build", []int{1, 2, 3}, "rc.1"},
+		{"v1.2.3+build-5", []int{1, 2, 3}, ""},
+	}
+
+	for _, data := range testData {
+		parts, preRelease := versionParts(data.tag)
+
+		if !reflect.DeepEqual(data.wantParts, parts) || data.wantPreRelease != preRelease {
+			t.Errorf("versionParts(%q) returned %v and %q, want %v and %q", data.tag, parts, preRelease, data.wantParts, data.wantPreRelease)
+		}
+	}
+}
+
+// newAssetServer starts a server of the given assets' contents, by their names
+func newAssetServer(assets map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contents, ok := assets[strings.TrimPrefix(r.URL.Path, "/")]
+
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Write([]byte(contents))
+	}))
+}
+
+// newRelease creates a release of the given assets, downloaded from the server
+func newRelease(server *httptest.Server, names ...string) *Release {
+	release := &Release{TagName: "v99.0.0"}
+
+	for _, name := range names {
+		release.Assets = append(release.Assets, Asset{Name: name, DownloadURL: server.URL + "/" + name})
+	}
+
+	return release
+}
+
+func TestInstallChecksumMismatch(t *testing.T) {
+	name := binaryName()
+	server := newAssetServer(map[string]string{
+		name:                         "the new binary",
+		name + checksumFileExtension: strings.Repeat("0", 64) + "  " + name + "\n",
+	})
+
+	defer server.Close()
+
+	err := newRelease(server, name, name+checksumFileExtension).Install(server.Client())
+	checksumErr, ok := err.(*ChecksumError)
+
+	if !ok {
+		t.Fatalf("Install returned error %v, want a *ChecksumError", err)
+	}
+
+	sum := sha256.Sum256([]byte("the new binary"))
+
+	if strings.Repeat("0", 64) != checksumErr.Expected || hex.EncodeToString(sum[:]) != checksumErr.Actual {
+		t.Errorf("Install returned the checksum error %+v", checksumErr)
+	}
+}
+
+func TestInstallEmptyChecksum(t *testing.T) {
+	name := binaryName()
+	server := newAssetServer(map[string]string{
+		name:                         "the new binary",
+		name + checksumFileExtension: "\n",
+	})
+
+	defer server.Close()
+
+	err := newRelease(server, name, name+checksumFileExtension).Install(server.Client())
+
+	if nil == err || !strings.Contains(err.Error(), "empty") {
+		t.Errorf("Install returned error %v, want an error about the empty checksum file", err)
+	}
+}
+
+func TestInstallMissingChecksumAsset(t *testing.T) {
+	name := binaryName()
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		w.Write([]byte("the new binary"))
+	}))
+
+	defer server.Close()
+
+	err := newRelease(server, name).Install(server.Client())
+
+	if nil == err || !strings.Contains(err.Error(), "has no binary") {
+		t.Errorf("Install returned error %v, want an error about the missing binary", err)
+	}
+
+	if requested {
+		t.Error("Install downloaded a binary without a checksum")
+	}
+}
+
+func TestInstallManagedPath(t *testing.T) {
+	defer func(prefixes []string) { managedInstallPathPrefixes = prefixes }(managedInstallPathPrefixes)
+
+	executable, err := os.Executable()
+
+	if nil == err {
+		executable, err = filepath.EvalSymlinks(executable)
+	}
+
+	if nil != err {
+		t.Skipf("the test's executable isn't known: %s", err)
+	}
+
+	managedInstallPathPrefixes = []string{filepath.Dir(executable) + string(filepath.Separator)}
+
+	name := binaryName()
+	server := newAssetServer(map[string]string{name: "the new binary"})
+
+	defer server.Close()
+
+	err = newRelease(server, name, name+checksumFileExtension).Install(server.Client())
+
+	if managedErr, ok := err.(*ManagedInstallError); !ok || executable != managedErr.Path {
+		t.Errorf("Install returned error %v, want a *ManagedInstallError of %q", err, executable)
+	}
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	dir, err := ioutil.TempDir("", "define-update")
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "define")
+
+	if err := ioutil.WriteFile(path, []byte("the old binary"), 0751); nil != err {
+		t.Fatal(err)
+	}
+
+	if err := replaceExecutable(path, []byte("the new binary")); nil != err {
+		t.Fatalf("replaceExecutable returned error %q", err)
+	}
+
+	if contents, _ := ioutil.ReadFile(path); "the new binary" != string(contents) {
+		t.Errorf("replaceExecutable wrote %q", contents)
+	}
+
+	if info, err := os.Stat(path); nil != err || (0751 != info.Mode().Perm() && "windows" != runtime.GOOS) {
+		t.Errorf("replaceExecutable didn't keep the mode, with error %v", err)
+	}
+
+	entries, _ := ioutil.ReadDir(dir)
+	names := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	want := []string{"define"}
+
+	if "windows" == runtime.GOOS {
+		want = []string{"define", "define.old"}
+	}
+
+	if !reflect.DeepEqual(want, names) {
+		t.Errorf("replaceExecutable left the files %q, want %q", names, want)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+//go:build go1.18
+// +build go1.18
+
+package version
+
+import (
+	"runtime/debug"
+)
+
+// readBuildInfo returns the VCS revision and time embedded in the binary by
+// the Go toolchain, if any.
+func readBuildInfo() (revision string, time string) {
+	info, ok := debug.ReadBuildInfo()
+
+	if !ok {
+		return "", ""
+	}
+
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.time":
+			time = setting.Value
+		}
+	}
+
+	// Match the short hash format of the linker-provided commit hash
+	if len(revision) > 7 {
+		revision = revision[:7]
+	}
+
+	return revision, time
+}
@@ -0,0 +1,12 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+//go:build !go1.18
+// +build !go1.18
+
+package version
+
+// readBuildInfo returns the VCS revision and time embedded in the binary by
+// the Go toolchain, which isn't available before Go 1.18.
+func readBuildInfo() (revision string, time string) {
+	return "", ""
+}
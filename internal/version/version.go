@@ -21,8 +21,38 @@ var (
 
 	// commitHash is the VCS commit hash.
 	commitHash string
+
+	// buildDate is the date and time of the build, in RFC 3339 format.
+	buildDate string
 )
 
+// Info defines the complete version information of the application.
+type Info struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"buildDate"`
+	GoVersion string `json:"goVersion"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+}
+
+func init() {
+	// Fall back to the build information embedded by the Go toolchain, for
+	// builds that weren't made with the linker flags (such as `go install`)
+	if "" == commitHash || "" == buildDate {
+		revision, time := readBuildInfo()
+
+		if "" == commitHash {
+			commitHash = revision
+		}
+
+		if "" == buildDate {
+			buildDate = time
+		}
+	}
+}
+
 // Name returns the name of the version.
 func Name() string {
 	if devID == identifier && "" != commitHash {
@@ -36,3 +66,16 @@ func Name() string {
 func Printable() string {
 	return fmt.Sprintf("%s %s (%s/%s)", AppName, Name(), runtime.GOOS, runtime.GOARCH)
 }
+
+// GetInfo returns the complete version information of the application.
+func GetInfo() Info {
+	return Info{
+		Name:      AppName,
+		Version:   identifier,
+		Commit:    commitHash,
+		BuildDate: buildDate,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+}
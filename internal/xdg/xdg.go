@@ -0,0 +1,73 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package xdg provides the application's base directories, following the XDG
+// Base Directory Specification.
+package xdg
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/Rican7/define/internal/version"
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+// ConfigDir returns the directory for the application's user-specific
+// configuration files. Without an XDG_CONFIG_HOME, the platform's equivalent
+// is used on macOS and Windows.
+func ConfigDir() string {
+	return filepath.Join(platformBaseDir("XDG_CONFIG_HOME", ".config", "Library/Application Support", "APPDATA"), version.AppName)
+}
+
+// CacheDir returns the directory for the application's user-specific cache
+// files. Without an XDG_CACHE_HOME, the platform's equivalent is used on macOS
+// and Windows.
+func CacheDir() string {
+	return filepath.Join(platformBaseDir("XDG_CACHE_HOME", ".cache", "Library/Caches", "LOCALAPPDATA"), version.AppName)
+}
+
+// DataDir returns the directory for the application's user-specific data
+// files.
+func DataDir() string {
+	return filepath.Join(baseDir("XDG_DATA_HOME", ".local/share"), version.AppName)
+}
+
+// baseDir returns the base directory defined by the given environment
+// variable, falling back to the given path relative to the user's home
+// directory if the variable is unset or not an absolute path.
+func baseDir(envName string, homeRelativeFallback string) string {
+	if dir := os.Getenv(envName); filepath.IsAbs(dir) {
+		return dir
+	}
+
+	home, err := homedir.Dir()
+
+	if nil != err {
+		return filepath.FromSlash(homeRelativeFallback)
+	}
+
+	return filepath.Join(home, filepath.FromSlash(homeRelativeFallback))
+}
+
+// platformBaseDir returns the base directory defined by the given environment
+// variable, falling back to the platform's equivalent directory: the given
+// path relative to the user's home directory on macOS, the directory defined
+// by the given environment variable on Windows, or the given path relative to
+// the user's home directory on other platforms.
+func platformBaseDir(envName string, homeRelativeFallback string, macHomeRelativeFallback string, windowsEnvName string) string {
+	if dir := os.Getenv(envName); filepath.IsAbs(dir) {
+		return dir
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		homeRelativeFallback = macHomeRelativeFallback
+	case "windows":
+		if dir := os.Getenv(windowsEnvName); filepath.IsAbs(dir) {
+			return dir
+		}
+	}
+
+	return baseDir(envName, homeRelativeFallback)
+}
@@ -0,0 +1,177 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package yaml provides conversions between YAML documents and JSON objects,
+// so that YAML config files can be decoded and encoded through the same JSON
+// structures as JSON config files.
+//
+// Only the YAML values that have a JSON equivalent are supported, so date-time
+// values aren't. Comments aren't kept by the conversions, so a document with
+// comments (see HasComments) shouldn't be rewritten by them.
+package yaml
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/Rican7/define/internal/jsonvalue"
+)
+
+// SyntaxError represents an error in the syntax of a YAML document, on the
+// given line (or 0, if the line isn't known)
+type SyntaxError struct {
+	Line    int
+	Message string
+}
+
+// errorLinePattern matches the line number and message of the errors of the
+// YAML library, such as "yaml: line 2: found character that cannot start any
+// token"
+var errorLinePattern = regexp.MustCompile(`line (\d+): (.*)`)
+
+// Error satisfies the error interface.
+func (e *SyntaxError) Error() string {
+	if 0 == e.Line {
+		return e.Message
+	}
+
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// ToJSON converts a YAML document into an equivalent JSON value, keeping the
+// order of the keys of its mappings
+func ToJSON(document []byte) ([]byte, error) {
+	var root yaml.MapSlice
+
+	if err := yaml.Unmarshal(document, &root); nil != err {
+		return nil, newSyntaxError(err)
+	}
+
+	object, err := toObject(root)
+
+	if nil != err {
+		return nil, err
+	}
+
+	return jsonvalue.Marshal(object)
+}
+
+// FromJSON converts a JSON object into an equivalent YAML document, keeping
+// the order of its keys
+func FromJSON(object []byte) ([]byte, error) {
+	root, err := jsonvalue.UnmarshalObject(object)
+
+	if nil != err {
+		return nil, err
+	}
+
+	return yaml.Marshal(toMapSlice(root))
+}
+
+// HasComments returns whether a YAML document has any comments. A "#" begins a
+// comment at the start of a line, or after whitespace, outside of a quoted
+// scalar (which begins with a quote, unlike a plain scalar such as "it's"). Text that only looks like a comment, such as within a block scalar,
+// is also reported, so a document is never mistaken for one without comments.
+func HasComments(document []byte) bool {
+	for _, line := range strings.Split(string(document), "\n") {
+		var quote rune
+
+		for i, char := range line {
+			switch {
+			case 0 != quote:
+				if quote == char {
+					quote = 0
+				}
+			case ('"' == char || '\'' == char) && (0 == i || strings.ContainsRune(" \t[{,", rune(line[i-1]))):
+				quote = char
+			case '#' == char && (0 == i || ' ' == line[i-1] || '\t' == line[i-1]):
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// newSyntaxError creates a SyntaxError from an error of the YAML library,
+// with the line of the error if the library names it
+func newSyntaxError(err error) *SyntaxError {
+	match := errorLinePattern.FindStringSubmatch(err.Error())
+
+	if nil == match {
+		return &SyntaxError{Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+	}
+
+	line, _ := strconv.Atoi(match[1])
+
+	return &SyntaxError{Line: line, Message: match[2]}
+}
+
+// toObject converts the mappings of a decoded YAML value into objects,
+// reporting any keys that a mapping defines more than once
+func toObject(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case yaml.MapSlice:
+		object := jsonvalue.NewObject()
+
+		for _, item := range value {
+			key := fmt.Sprint(item.Key)
+
+			if _, exists := object.Values[key]; exists {
+				return nil, &SyntaxError{Message: fmt.Sprintf("key %q is defined more than once", key)}
+			}
+
+			element, err := toObject(item.Value)
+
+			if nil != err {
+				return nil, err
+			}
+
+			object.Set(key, element)
+		}
+
+		return object, nil
+	case []interface{}:
+		values := make([]interface{}, len(value))
+
+		for i, element := range value {
+			var err error
+
+			if values[i], err = toObject(element); nil != err {
+				return nil, err
+			}
+		}
+
+		return values, nil
+	}
+
+	return value, nil
+}
+
+// toMapSlice converts the objects of a decoded JSON value into the mappings
+// that the YAML encoder encodes in order
+func toMapSlice(value interface{}) interface{} {
+	switch value := value.(type) {
+	case *jsonvalue.Object:
+		mapping := make(yaml.MapSlice, len(value.Keys))
+
+		for i, key := range value.Keys {
+			mapping[i] = yaml.MapItem{Key: key, Value: toMapSlice(value.Values[key])}
+		}
+
+		return mapping
+	case []interface{}:
+		values := make([]interface{}, len(value))
+
+		for i, element := range value {
+			values[i] = toMapSlice(element)
+		}
+
+		return values
+	}
+
+	return value
+}
@@ -0,0 +1,153 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package yaml
+
+import (
+	"testing"
+)
+
+func TestToJSON(t *testing.T) {
+	testData := []struct {
+		document string
+		want     string
+	}{
+		{"", `{}`},
+		{"# Only a comment\n", `{}`},
+		{"---\na: 1\nb: -2.5\nc: true\nd: ~\ne: text here\n", `{"a":1,"b":-2.5,"c":true,"d":null,"e":"text here"}`},
+		{"a: \"x\\ty # not a comment\" # a comment\nb: 'it''s'\nc: it's", `{"a":"x\ty # not a comment","b":"it's","c":"it's"}`},
+		{"a: 0x1F\nb: 1e3\nc: 1.2.3\nd: \"1\"", `{"a":31,"b":1000,"c":"1.2.3","d":"1"}`},
+		{"list: [1, \"two\", {k: v}]\nmap: {a: 1, b: [x, z]}\nempty: []", `{"list":[1,"two",{"k":"v"}],"map":{"a":1,"b":["x","z"]},"empty":[]}`},
+		{"Table:\n  Key: v\n  Sub:\n    x: 1\nOther: 2", `{"Table":{"Key":"v","Sub":{"x":1}},"Other":2}`},
+		{"Items:\n  - Name: a\n    Args: [-x]\n  - Name: b\n", `{"Items":[{"Name":"a","Args":["-x"]},{"Name":"b"}]}`},
+		{"\"quoted key\": 1\n'other': 2\n3: three", `{"quoted key":1,"other":2,"3":"three"}`},
+		{"lit: |\n  one\n  two\n\nfold: >-\n  one\n  two\n\n  three\nnext: 1", `{"lit":"one\ntwo\n","fold":"one two\nthree","next":1}`},
+		{"\"// Key\": A note\n# A comment\nKey: 1 # trailing", `{"// Key":"A note","Key":1}`},
+		{"a: 1\r\nb: 2\r\n", `{"a":1,"b":2}`},
+	}
+
+	for _, data := range testData {
+		got, err := ToJSON([]byte(data.document))
+
+		if nil != err {
+			t.Errorf("ToJSON(%q) returned error %q", data.document, err)
+			continue
+		}
+
+		if data.want != string(got) {
+			t.Errorf("ToJSON(%q) returned %s, want %s", data.document, got, data.want)
+		}
+	}
+}
+
+func TestToJSONErrors(t *testing.T) {
+	testData := []struct {
+		document string
+		wantLine int
+	}{
+		{"a:\n  b: 1\n   c: 2", 3},
+		{"a: 1\n  b: 2", 2},
+		{"a:\n\tb: 1", 2},
+		{"a: [1, 2", 1},
+		{"- a\nb: 1", 1},
+		{"a: 1\nplain", 3},
+		{"- a\n- b", 1},
+		{"a: b: c", 0},
+		{"a: 1\na: 2", 0},
+		{"Table:\n  a: 1\n  a: 2", 0},
+	}
+
+	for _, data := range testData {
+		_, err := ToJSON([]byte(data.document))
+		syntaxErr, ok := err.(*SyntaxError)
+
+		if !ok {
+			t.Errorf("ToJSON(%q) returned error %v, want a *SyntaxError", data.document, err)
+			continue
+		}
+
+		if data.wantLine != syntaxErr.Line || "" == syntaxErr.Message {
+			t.Errorf("ToJSON(%q) returned an error on line %d (%s), want line %d", data.document, syntaxErr.Line, syntaxErr.Message, data.wantLine)
+		}
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	object := `{
+		"// IndentationSize": "The indentation",
+		"IndentationSize": 2,
+		"Source": "a \"quoted\" <value>: # not a comment",
+		"Nothing": null,
+		"Empty": [],
+		"// Dictionary": "The dictionary\nsource",
+		"Dictionary": {"App Key": ""},
+		"ExecSources": [{"Name": "a", "Args": ["-x", 1.5]}, {"Name": "b", "Env": {"K": true}}]
+	}`
+
+	want := `// IndentationSize: The indentation
+IndentationSize: 2
+Source: 'a "quoted" <value>: # not a comment'
+Nothing: null
+Empty: []
+// Dictionary: |-
+  The dictionary
+  source
+Dictionary:
+  App Key: ""
+ExecSources:
+- Name: a
+  Args:
+  - -x
+  - 1.5
+- Name: b
+  Env:
+    K: true
+`
+
+	got, err := FromJSON([]byte(object))
+
+	if nil != err {
+		t.Fatalf("FromJSON returned error %q", err)
+	}
+
+	if want != string(got) {
+		t.Errorf("FromJSON returned:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	object := `{"// A":"The A","A":1,"B":{"// C":"The C","C":"c: d","D":[1,[2,3],{}]},"E":[{"F":false,"G":null}],"H":"multi\nline","I":"true"}`
+
+	document, err := FromJSON([]byte(object))
+
+	if nil != err {
+		t.Fatalf("FromJSON returned error %q", err)
+	}
+
+	got, err := ToJSON(document)
+
+	if nil != err {
+		t.Fatalf("ToJSON returned error %q for document:\n%s", err, document)
+	}
+
+	if object != string(got) {
+		t.Errorf("round trip returned %s, want %s", got, object)
+	}
+}
+
+func TestHasComments(t *testing.T) {
+	testData := map[string]bool{
+		"":                              false,
+		"a: 1\n":                        false,
+		"url: http://x#y\n":             false,
+		"a: \"# quoted\"\nb: '# too'\n": false,
+		"# A comment\na: 1\n":           true,
+		"a: 1 # trailing\n":             true,
+		"list:\n  - 1 # one\n":          true,
+	}
+
+	for document, want := range testData {
+		if got := HasComments([]byte(document)); want != got {
+			t.Errorf("HasComments(%q) returned %t, want %t", document, got, want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package registry
+
+import (
+	"os"
+	"reflect"
+)
+
+// Capabilities of sources, used to describe what their results contain
+const (
+	CapabilityPronunciations = "pronunciations"
+	CapabilityExamples       = "examples"
+	CapabilityEtymologies    = "etymologies"
+	CapabilityThesaurus      = "thesaurus"
+	CapabilityInflections    = "inflections"
+	CapabilitySuggestions    = "suggestions"
+	CapabilityTranslations   = "translations"
+	CapabilityMultilingual   = "multilingual"
+	CapabilityOffline        = "offline"
+	CapabilityRegex          = "regex"
+	CapabilityRhymes         = "rhymes"
+	CapabilityFrequencies    = "frequencies"
+	CapabilityRelated        = "related"
+)
+
+// Metadata defines descriptive information about a SourceProvider.
+type Metadata struct {
+	// Description is a one-line description of the provided source.
+	Description string
+
+	// Homepage is the URL of the web page of the provided source (or of its
+	// API).
+	Homepage string
+
+	// SignupURL is the URL of where to obtain the values of the required
+	// configuration keys (such as to register for an API key), if any.
+	SignupURL string
+
+	// RequiredKeys is the list of configuration keys that are required to
+	// provide the source.
+	RequiredKeys []RequiredKey
+
+	// Capabilities is the list of capabilities of the provided source.
+	Capabilities []string
+
+	// EnvVars is the list of environment variables that the provider's
+	// configuration reads its values from.
+	EnvVars []EnvVar
+
+	// PathKeys is the list of configuration keys whose values are file system
+	// paths, which are expanded (such as a leading "~") once loaded.
+	PathKeys []string
+}
+
+// RequiredKey defines a configuration key that's required to provide a source.
+type RequiredKey struct {
+	// Name is the name of the key (the configuration's struct field name).
+	Name string
+
+	// FlagName is the name of the command line flag that sets the key.
+	FlagName string
+}
+
+// EnvVar defines an environment variable that a configuration key's value is
+// read from.
+type EnvVar struct {
+	// Name is the name of the environment variable.
+	Name string
+
+	// Key is the name of the key (the configuration's struct field name).
+	Key string
+
+	// Aliases are other (such as legacy) names of the environment variable,
+	// which are read in order if it isn't set.
+	Aliases []string
+}
+
+// Lookup returns the value of the environment variable, or else of the first
+// of its aliases that's set, along with the name of the variable that was set
+// and whether any was set.
+func (e EnvVar) Lookup() (value string, name string, isSet bool) {
+	for _, name := range append([]string{e.Name}, e.Aliases...) {
+		if value, isSet := os.LookupEnv(name); isSet {
+			return value, name, true
+		}
+	}
+
+	return "", e.Name, false
+}
+
+// DescribedProvider defines the interface for providers of sources that
+// describe themselves with Metadata.
+type DescribedProvider interface {
+	SourceProvider
+
+	// Metadata returns the descriptive information of the provider.
+	Metadata() Metadata
+}
+
+// ProviderMetadata returns the metadata of the provider of the given
+// configuration, or an empty Metadata if the provider doesn't describe itself.
+func ProviderMetadata(conf Configuration) Metadata {
+	if describedProvider, ok := providers[conf].(DescribedProvider); ok {
+		return describedProvider.Metadata()
+	}
+
+	return Metadata{}
+}
+
+// HasRequiredKeys returns whether the given configuration has a value set for
+// each of the keys that its provider requires.
+func HasRequiredKeys(conf Configuration) bool {
+	confValue := reflect.Indirect(reflect.ValueOf(conf))
+
+	for _, requiredKey := range ProviderMetadata(conf).RequiredKeys {
+		field := confValue.FieldByName(requiredKey.Name)
+
+		if !field.IsValid() || reflect.DeepEqual(field.Interface(), reflect.Zero(field.Type()).Interface()) {
+			return false
+		}
+	}
+
+	return true
+}
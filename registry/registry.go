@@ -8,10 +8,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 
 	flag "github.com/ogier/pflag"
 
+	"github.com/Rican7/define/internal/logger"
 	"github.com/Rican7/define/source"
 )
 
@@ -62,6 +64,10 @@ var (
 	registrations = make([]RegisterFunc, 0)
 
 	providers = make(map[Configuration]SourceProvider)
+
+	// providerOrder is the configurations of the providers, in the order that
+	// they were registered
+	providerOrder []Configuration
 )
 
 // Register makes a source provider available by the provided name.
@@ -86,12 +92,32 @@ func ConfigureProviders(flags *flag.FlagSet) map[string]Configuration {
 			}
 
 			providers[conf], confs[conf.JSONKey()] = provider, conf
+			providerOrder = append(providerOrder, conf)
 		}
 	})
 
 	return confs
 }
 
+// RegisterConfigured makes an already configured source provider available,
+// such as a provider defined by the app's configuration itself. An error is
+// returned if a provider with the same configuration JSON key is registered.
+//
+// This is intended to be called ONLY by the registry owner, after the
+// providers have been configured.
+func RegisterConfigured(provider SourceProvider, conf Configuration) error {
+	for existingConf := range providers {
+		if existingConf.JSONKey() == conf.JSONKey() {
+			return fmt.Errorf("a source provider with the key %q is already registered", conf.JSONKey())
+		}
+	}
+
+	providers[conf] = provider
+	providerOrder = append(providerOrder, conf)
+
+	return nil
+}
+
 // Finalize takes a number of configurations and marks them as loaded, if they
 // support a DynamicConfiguration signaling.
 //
@@ -100,6 +126,8 @@ func ConfigureProviders(flags *flag.FlagSet) map[string]Configuration {
 func Finalize(confs ...Configuration) {
 	finalized.Do(func() {
 		for _, conf := range confs {
+			logger.Debugf("registry: finalizing the configuration of the registered provider %q", conf.JSONKey())
+
 			if dynamicConf, ok := conf.(DynamicConfiguration); ok {
 				dynamicConf.Finalize()
 			}
@@ -116,6 +144,10 @@ func Provide(conf Configuration) (source.Source, error) {
 
 	if nil != err {
 		err = fmt.Errorf("source %q failed to initialize with error: %s", provider.Name(), err)
+
+		logger.Debugf("registry: %s", err)
+	} else {
+		logger.Debugf("registry: provided source %q", provider.Name())
 	}
 
 	return src, err
@@ -123,27 +155,35 @@ func Provide(conf Configuration) (source.Source, error) {
 
 // ProvidePreferred takes a preferred provider key (that aligns with the value
 // returned by the Configuration.JSONKey method) and a list of configurations,
-// and provides the matching source if possible, but will fall back to another
-// source if the preferred source returns an error when trying to provide it.
+// and provides the matching source if possible, but will fall back to the
+// other sources, in the order of the given list, if the preferred source
+// returns an error when trying to provide it.
 func ProvidePreferred(preferredProvider string, confs []Configuration) (source.Source, error) {
-	var src source.Source
-	var err error
-
 	if len(confs) < 1 {
 		return nil, errors.New("no configurations available to provide a source")
 	}
 
+	ordered := make([]Configuration, 0, len(confs))
+
 	for _, providerConf := range confs {
-		if src == nil || nil != err || preferredProvider == providerConf.JSONKey() {
-			iSrc, iErr := Provide(providerConf)
+		if preferredProvider == providerConf.JSONKey() {
+			ordered = append([]Configuration{providerConf}, ordered...)
+		} else {
+			ordered = append(ordered, providerConf)
+		}
+	}
 
-			if nil != iSrc && nil == iErr {
-				src, err = iSrc, iErr
-			}
+	var err error
+
+	for _, providerConf := range ordered {
+		var src source.Source
+
+		if src, err = Provide(providerConf); nil == err {
+			return src, nil
 		}
 	}
 
-	return src, err
+	return nil, err
 }
 
 // Providers returns a map of the source configurations as keys and their
@@ -157,3 +197,24 @@ func Providers() map[Configuration]SourceProvider {
 
 	return provs
 }
+
+// ProviderConfigurations returns the configurations of the registered
+// providers, in the order that they were registered, so that iterating over
+// the providers is reproducible (unlike iterating over Providers).
+func ProviderConfigurations() []Configuration {
+	return append([]Configuration(nil), providerOrder...)
+}
+
+// ProviderNames returns the names of the registered providers, sorted
+// alphabetically so that the order is stable.
+func ProviderNames() []string {
+	names := make([]string, 0, len(providerOrder))
+
+	for _, conf := range providerOrder {
+		names = append(names, providers[conf].Name())
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,80 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package registry
+
+import (
+	"reflect"
+	"testing"
+
+	flag "github.com/ogier/pflag"
+
+	"github.com/Rican7/define/source"
+)
+
+// fakeProvider is a provider, of no source, with only a name
+type fakeProvider struct {
+	name string
+}
+
+// fakeConfig is the configuration of a fakeProvider, with only a key
+type fakeConfig struct {
+	key string
+}
+
+func (p *fakeProvider) Name() string {
+	return p.name
+}
+
+func (p *fakeProvider) Provide(Configuration) (source.Source, error) {
+	return nil, nil
+}
+
+func (c *fakeConfig) JSONKey() string {
+	return c.key
+}
+
+func TestProviderOrderIsStable(t *testing.T) {
+	fakes := []struct {
+		key  string
+		name string
+	}{
+		{"Zulu", "Zulu Dictionary"},
+		{"Alpha", "Alpha Dictionary"},
+		{"Mike", "Mike Dictionary"},
+		{"Bravo", "Bravo Dictionary"},
+	}
+
+	for _, fake := range fakes[:3] {
+		fake := fake
+
+		Register(RegisterFunc(func(*flag.FlagSet) (SourceProvider, Configuration) {
+			return &fakeProvider{fake.name}, &fakeConfig{fake.key}
+		}))
+	}
+
+	ConfigureProviders(flag.NewFlagSet("test", flag.ContinueOnError))
+
+	if err := RegisterConfigured(&fakeProvider{fakes[3].name}, &fakeConfig{fakes[3].key}); nil != err {
+		t.Fatalf("RegisterConfigured returned error %q", err)
+	}
+
+	wantKeys := []string{"Zulu", "Alpha", "Mike", "Bravo"}
+	wantNames := []string{"Alpha Dictionary", "Bravo Dictionary", "Mike Dictionary", "Zulu Dictionary"}
+
+	// Repeat, as the iteration order of maps differs between iterations
+	for i := 0; i < 10; i++ {
+		var keys []string
+
+		for _, conf := range ProviderConfigurations() {
+			keys = append(keys, conf.JSONKey())
+		}
+
+		if !reflect.DeepEqual(wantKeys, keys) {
+			t.Fatalf("ProviderConfigurations returned the keys %q, want %q", keys, wantKeys)
+		}
+
+		if names := ProviderNames(); !reflect.DeepEqual(wantNames, names) {
+			t.Fatalf("ProviderNames returned %q, want %q", names, wantNames)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxPeriodlessAbbreviationLetters is the most letters of a word that's
+// considered to be an abbreviation missing its trailing period (such as "etc")
+const maxPeriodlessAbbreviationLetters = 5
+
+// IsAbbreviation returns whether a word is likely an abbreviation or an
+// acronym: a single word ending in a period (such as "etc." or "e.g."), or of
+// more than one letter that are all capitals (such as "NASA" or "AT&T").
+func IsAbbreviation(word string) bool {
+	if "" == word || -1 != strings.IndexFunc(word, unicode.IsSpace) {
+		return false
+	}
+
+	if strings.HasSuffix(word, ".") && 1 < utf8.RuneCountInString(word) {
+		return true
+	}
+
+	letters := 0
+
+	for _, r := range word {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+
+		if !unicode.IsUpper(r) {
+			return false
+		}
+
+		letters++
+	}
+
+	return 1 < letters
+}
+
+// AbbreviationVariants returns the other spellings of a word that a source may
+// list it under, if it's an abbreviation, differing only by its trailing
+// period: "etc" for "etc.", and "etc." for "etc" (for short, lowercase words
+// of only letters and periods, as acronyms are usually written without them).
+func AbbreviationVariants(word string) []string {
+	if trimmed := strings.TrimSuffix(word, "."); trimmed != word {
+		if "" == trimmed || strings.HasSuffix(trimmed, ".") {
+			return nil
+		}
+
+		return []string{trimmed}
+	}
+
+	letters := 0
+
+	for _, r := range word {
+		if '.' == r {
+			continue
+		}
+
+		if !unicode.IsLetter(r) || unicode.IsUpper(r) {
+			return nil
+		}
+
+		letters++
+	}
+
+	if letters < 1 || maxPeriodlessAbbreviationLetters < letters {
+		return nil
+	}
+
+	return []string{word + "."}
+}
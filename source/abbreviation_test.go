@@ -0,0 +1,54 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsAbbreviation(t *testing.T) {
+	testData := map[string]bool{
+		"etc.":      true,
+		"e.g.":      true,
+		"U.S.":      true,
+		"NASA":      true,
+		"AT&T":      true,
+		"etc":       false,
+		"Apple":     false,
+		"I":         false,
+		".":         false,
+		"":          false,
+		"Mr. Smith": false,
+	}
+
+	for word, want := range testData {
+		if got := IsAbbreviation(word); want != got {
+			t.Errorf("IsAbbreviation(%q) returned %t, want %t", word, got, want)
+		}
+	}
+}
+
+func TestAbbreviationVariants(t *testing.T) {
+	testData := map[string][]string{
+		"etc.":        {"etc"},
+		"etc":         {"etc."},
+		"e.g":         {"e.g."},
+		"e.g.":        {"e.g"},
+		"NASA":        nil,
+		"Etc":         nil,
+		"dictionary":  nil,
+		"ice cream":   nil,
+		"42":          nil,
+		"..":          nil,
+		".":           nil,
+		"":            nil,
+		"approximate": nil,
+	}
+
+	for word, want := range testData {
+		if got := AbbreviationVariants(word); !reflect.DeepEqual(want, got) {
+			t.Errorf("AbbreviationVariants(%q) returned %q, want %q", word, got, want)
+		}
+	}
+}
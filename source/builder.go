@@ -0,0 +1,124 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+// NewResult returns a result of the given headword and language, containing
+// the given entries (typically built with NewEntry).
+func NewResult(headword string, language string, entries ...DictionaryEntry) ResultValue {
+	entryVals := make([]interface{}, len(entries))
+
+	for i, entry := range entries {
+		entryVals[i] = entry
+	}
+
+	return ResultValue{
+		Head:      headword,
+		Lang:      language,
+		EntryVals: entryVals,
+	}
+}
+
+// NewEntry returns an entry of the given word and lexical category (such as
+// "noun"), containing the given senses (typically built with NewSense).
+//
+// The entry's other attributes can be set with its With* methods, such as:
+//
+//	source.NewEntry("run", "verb", source.NewSense("to move swiftly")).
+//		WithPronunciation("/ɹʌn/").
+//		WithSynonyms("sprint", "dash")
+func NewEntry(word string, category string, senses ...SenseValue) EntryValue {
+	return EntryValue{
+		WordEntryValue: WordEntryValue{
+			WordVal:     word,
+			CategoryVal: category,
+		},
+		DictionaryEntryValue: DictionaryEntryValue{
+			SenseVals: senses,
+		},
+	}
+}
+
+// WithPronunciation returns a copy of the entry with the given pronunciation
+func (e EntryValue) WithPronunciation(pronunciation string) EntryValue {
+	e.PronunciationVal = pronunciation
+
+	return e
+}
+
+// WithPronunciations returns a copy of the entry with the given regional
+// pronunciations
+func (e EntryValue) WithPronunciations(pronunciations ...RegionalPronunciation) EntryValue {
+	e.PronunciationVals = pronunciations
+
+	return e
+}
+
+// WithEtymologies returns a copy of the entry with the given etymologies
+func (e EntryValue) WithEtymologies(etymologies ...string) EntryValue {
+	e.EtymologyVals = etymologies
+
+	return e
+}
+
+// WithSynonyms returns a copy of the entry with the given synonyms
+func (e EntryValue) WithSynonyms(synonyms ...string) EntryValue {
+	e.SynonymVals = synonyms
+
+	return e
+}
+
+// WithAntonyms returns a copy of the entry with the given antonyms
+func (e EntryValue) WithAntonyms(antonyms ...string) EntryValue {
+	e.AntonymVals = antonyms
+
+	return e
+}
+
+// WithInflections returns a copy of the entry with the given inflected forms
+func (e EntryValue) WithInflections(inflections ...string) EntryValue {
+	e.InflectionVals = inflections
+
+	return e
+}
+
+// NewSense returns a sense with the given definitions.
+//
+// The sense's other attributes can be set with its With* methods, such as:
+//
+//	source.NewSense("to move swiftly on foot").
+//		WithExamples("she ran to the door").
+//		WithSubsenses(source.NewSense("to compete in a race"))
+func NewSense(definitions ...string) SenseValue {
+	return SenseValue{
+		DefinitionVals: definitions,
+	}
+}
+
+// WithExamples returns a copy of the sense with the given examples
+func (s SenseValue) WithExamples(examples ...string) SenseValue {
+	s.ExampleVals = examples
+
+	return s
+}
+
+// WithNotes returns a copy of the sense with the given notes
+func (s SenseValue) WithNotes(notes ...string) SenseValue {
+	s.NoteVals = notes
+
+	return s
+}
+
+// WithPronunciations returns a copy of the sense with the given pronunciations,
+// for those specific to the sense
+func (s SenseValue) WithPronunciations(pronunciations ...RegionalPronunciation) SenseValue {
+	s.PronunciationVals = pronunciations
+
+	return s
+}
+
+// WithSubsenses returns a copy of the sense with the given subsenses
+func (s SenseValue) WithSubsenses(subsenses ...SenseValue) SenseValue {
+	s.SubsenseVals = subsenses
+
+	return s
+}
@@ -0,0 +1,167 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+import (
+	"context"
+)
+
+// ContextSource defines an interface for sources that directly support the
+// cancellation and deadlines of a context when defining words
+type ContextSource interface {
+	Source
+
+	DefineContext(ctx context.Context, word string) (Result, error)
+}
+
+// ContextTranslator defines an interface for translators that directly support
+// the cancellation and deadlines of a context when translating text
+type ContextTranslator interface {
+	Translator
+
+	TranslateContext(ctx context.Context, text string, from string, to string) (string, error)
+}
+
+// ContextRhymer defines an interface for rhymers that directly support the
+// cancellation and deadlines of a context when finding rhymes
+type ContextRhymer interface {
+	Rhymer
+
+	RhymesContext(ctx context.Context, word string, near bool, limit uint) ([]Rhyme, error)
+}
+
+// ContextAssociator defines an interface for associators that directly
+// support the cancellation and deadlines of a context when finding related
+// words
+type ContextAssociator interface {
+	Associator
+
+	RelatedContext(ctx context.Context, word string, limit uint) ([]string, error)
+}
+
+// defineResult is the return values of a source's definition of a word
+type defineResult struct {
+	result Result
+	err    error
+}
+
+// DefineContext defines a word with the given source, honoring the given
+// context's cancellation and deadline. Once the context is done, its error is
+// returned, rather than the error of the source's cancelled lookup (such as of
+// its aborted HTTP request).
+//
+// If the source doesn't implement ContextSource, it's left to finish in the
+// background once the context is done, while the context's error is returned
+// immediately.
+func DefineContext(ctx context.Context, src Source, word string) (Result, error) {
+	if err := ctx.Err(); nil != err {
+		return nil, err
+	}
+
+	if contextSource, ok := src.(ContextSource); ok {
+		result, err := contextSource.DefineContext(ctx, word)
+
+		if nil != err && nil != ctx.Err() {
+			return nil, ctx.Err()
+		}
+
+		return result, err
+	}
+
+	// Buffered, so the goroutine can finish even if we're no longer receiving
+	done := make(chan defineResult, 1)
+
+	go func() {
+		result, err := src.Define(word)
+
+		done <- defineResult{result, err}
+	}()
+
+	select {
+	case defined := <-done:
+		return defined.result, defined.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// TranslateContext translates text with the given translator, honoring the
+// given context's cancellation and deadline. Once the context is done, its
+// error is returned, rather than the error of the translator's cancelled
+// translation.
+//
+// If the translator doesn't implement ContextTranslator, the context is only
+// checked before the translation starts.
+func TranslateContext(ctx context.Context, translator Translator, text string, from string, to string) (string, error) {
+	if err := ctx.Err(); nil != err {
+		return "", err
+	}
+
+	contextTranslator, ok := translator.(ContextTranslator)
+
+	if !ok {
+		return translator.Translate(text, from, to)
+	}
+
+	translation, err := contextTranslator.TranslateContext(ctx, text, from, to)
+
+	if nil != err && nil != ctx.Err() {
+		return "", ctx.Err()
+	}
+
+	return translation, err
+}
+
+// RhymesContext finds the words rhyming with a word with the given rhymer,
+// honoring the given context's cancellation and deadline. Once the context is
+// done, its error is returned, rather than the error of the rhymer's cancelled
+// request.
+//
+// If the rhymer doesn't implement ContextRhymer, the context is only checked
+// before the request starts.
+func RhymesContext(ctx context.Context, rhymer Rhymer, word string, near bool, limit uint) ([]Rhyme, error) {
+	if err := ctx.Err(); nil != err {
+		return nil, err
+	}
+
+	contextRhymer, ok := rhymer.(ContextRhymer)
+
+	if !ok {
+		return rhymer.Rhymes(word, near, limit)
+	}
+
+	rhymes, err := contextRhymer.RhymesContext(ctx, word, near, limit)
+
+	if nil != err && nil != ctx.Err() {
+		return nil, ctx.Err()
+	}
+
+	return rhymes, err
+}
+
+// RelatedContext finds the words related to a word with the given associator,
+// honoring the given context's cancellation and deadline. Once the context is
+// done, its error is returned, rather than the error of the associator's
+// cancelled request.
+//
+// If the associator doesn't implement ContextAssociator, the context is only
+// checked before the request starts.
+func RelatedContext(ctx context.Context, associator Associator, word string, limit uint) ([]string, error) {
+	if err := ctx.Err(); nil != err {
+		return nil, err
+	}
+
+	contextAssociator, ok := associator.(ContextAssociator)
+
+	if !ok {
+		return associator.Related(word, limit)
+	}
+
+	related, err := contextAssociator.RelatedContext(ctx, word, limit)
+
+	if nil != err && nil != ctx.Err() {
+		return nil, ctx.Err()
+	}
+
+	return related, err
+}
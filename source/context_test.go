@@ -0,0 +1,166 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type delayedSource struct {
+	delay time.Duration
+}
+
+func (s delayedSource) Name() string {
+	return "delayed"
+}
+
+func (s delayedSource) Define(word string) (Result, error) {
+	time.Sleep(s.delay)
+
+	return ResultValue{Head: word}, nil
+}
+
+// blockingSource is a source that blocks until its context is done, recording
+// that it was, and then fails with an error of its own
+type blockingSource struct {
+	stopped chan struct{}
+}
+
+func (s blockingSource) Name() string {
+	return "blocking"
+}
+
+func (s blockingSource) Define(word string) (Result, error) {
+	return s.DefineContext(context.Background(), word)
+}
+
+func (s blockingSource) DefineContext(ctx context.Context, word string) (Result, error) {
+	<-ctx.Done()
+	close(s.stopped)
+
+	return nil, errors.New("request aborted")
+}
+
+func TestDefineContext(t *testing.T) {
+	result, err := DefineContext(context.Background(), delayedSource{}, "test")
+
+	if nil != err {
+		t.Fatalf("DefineContext returned an unexpected error: %v", err)
+	}
+
+	if got, want := result.Headword(), "test"; got != want {
+		t.Errorf("DefineContext returned wrong headword. Got %v. Want %v.", got, want)
+	}
+}
+
+func TestDefineContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	result, err := DefineContext(ctx, delayedSource{delay: time.Second}, "test")
+
+	if context.DeadlineExceeded != err {
+		t.Errorf("DefineContext returned wrong error. Got %v. Want %v.", err, context.DeadlineExceeded)
+	}
+
+	if nil != result {
+		t.Errorf("DefineContext returned a result after its deadline: %v", result)
+	}
+}
+
+func TestDefineContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := DefineContext(ctx, delayedSource{}, "test"); context.Canceled != err {
+		t.Errorf("DefineContext returned wrong error. Got %v. Want %v.", err, context.Canceled)
+	}
+}
+
+func TestDefineContextStopsContextSource(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	src := blockingSource{stopped: make(chan struct{})}
+
+	if _, err := DefineContext(ctx, src, "test"); context.DeadlineExceeded != err {
+		t.Errorf("DefineContext returned wrong error. Got %v. Want %v.", err, context.DeadlineExceeded)
+	}
+
+	select {
+	case <-src.stopped:
+	default:
+		t.Error("DefineContext returned before the source was stopped")
+	}
+}
+
+// blockingAssociator is an associator that blocks until its context is done,
+// and then fails with an error of its own
+type blockingAssociator struct{}
+
+func (a blockingAssociator) Name() string {
+	return "blocking"
+}
+
+func (a blockingAssociator) Related(word string, limit uint) ([]string, error) {
+	return a.RelatedContext(context.Background(), word, limit)
+}
+
+func (a blockingAssociator) RelatedContext(ctx context.Context, word string, limit uint) ([]string, error) {
+	<-ctx.Done()
+
+	return nil, errors.New("request aborted")
+}
+
+func TestRelatedContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := RelatedContext(ctx, blockingAssociator{}, "test", 0); context.DeadlineExceeded != err {
+		t.Errorf("RelatedContext returned wrong error. Got %v. Want %v.", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRelatedContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := RelatedContext(ctx, blockingAssociator{}, "test", 0); context.Canceled != err {
+		t.Errorf("RelatedContext returned wrong error. Got %v. Want %v.", err, context.Canceled)
+	}
+}
+
+// blockingRhymer is a rhymer that blocks until its context is done, and then
+// fails with an error of its own
+type blockingRhymer struct{}
+
+func (r blockingRhymer) Rhymes(word string, near bool, limit uint) ([]Rhyme, error) {
+	return r.RhymesContext(context.Background(), word, near, limit)
+}
+
+func (r blockingRhymer) RhymesContext(ctx context.Context, word string, near bool, limit uint) ([]Rhyme, error) {
+	<-ctx.Done()
+
+	return nil, errors.New("request aborted")
+}
+
+func TestRhymesContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := RhymesContext(ctx, blockingRhymer{}, "test", false, 0); context.DeadlineExceeded != err {
+		t.Errorf("RhymesContext returned wrong error. Got %v. Want %v.", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRhymesContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := RhymesContext(ctx, blockingRhymer{}, "test", false, 0); context.Canceled != err {
+		t.Errorf("RhymesContext returned wrong error. Got %v. Want %v.", err, context.Canceled)
+	}
+}
@@ -16,6 +16,8 @@ type EntryValue struct {
 	DictionaryEntryValue
 	EtymologyEntryValue
 	ThesaurusEntryValue
+	InflectionEntryValue
+	PronunciationEntryValue
 }
 
 // A WordEntryValue is a specific word entry representation
@@ -44,12 +46,38 @@ type ThesaurusEntryValue struct {
 	AntonymVals []string
 }
 
+// An InflectionEntryValue contains the inflected forms of a word
+type InflectionEntryValue struct {
+	InflectionVals []string
+}
+
+// A RegionalPronunciation is a pronunciation of a word, labeled by the region
+// or dialect that it's used in (if known)
+type RegionalPronunciation struct {
+	Transcription string
+	Region        string
+}
+
+// A PronunciationEntryValue contains the regional pronunciations of a word
+type PronunciationEntryValue struct {
+	PronunciationVals []RegionalPronunciation
+}
+
+// A LanguageEntryValue contains the language of an entry of a word
+type LanguageEntryValue struct {
+	LanguageVal string
+}
+
 // A SenseValue contains the common attributes of a word's meanings
 type SenseValue struct {
 	DefinitionVals []string
 	ExampleVals    []string
 	NoteVals       []string
 
+	// PronunciationVals are the pronunciations specific to the sense, if the
+	// source links them to it (rather than to the whole entry)
+	PronunciationVals []RegionalPronunciation
+
 	SubsenseVals []SenseValue
 }
 
@@ -119,6 +147,21 @@ func (e ThesaurusEntryValue) Antonyms() []string {
 	return e.AntonymVals
 }
 
+// Inflections returns the entry's inflected forms
+func (e InflectionEntryValue) Inflections() []string {
+	return e.InflectionVals
+}
+
+// Pronunciations returns the entry's regional pronunciations
+func (e PronunciationEntryValue) Pronunciations() []RegionalPronunciation {
+	return e.PronunciationVals
+}
+
+// Language returns the entry's language
+func (e LanguageEntryValue) Language() string {
+	return e.LanguageVal
+}
+
 // Definitions returns the sense's definitions
 func (s SenseValue) Definitions() []string {
 	return s.DefinitionVals
@@ -134,6 +177,11 @@ func (s SenseValue) Notes() []string {
 	return s.NoteVals
 }
 
+// Pronunciations returns the sense's own pronunciations
+func (s SenseValue) Pronunciations() []RegionalPronunciation {
+	return s.PronunciationVals
+}
+
 // Subsenses returns the sense's subsenses
 func (s SenseValue) Subsenses() []Sense {
 	senses := make([]Sense, len(s.SubsenseVals))
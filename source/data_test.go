@@ -7,13 +7,17 @@ import "testing"
 
 // Enforce interface contracts
 var (
-	_ Result          = (*ResultValue)(nil)
-	_ Entry           = (*EntryValue)(nil)
-	_ WordEntry       = (*WordEntryValue)(nil)
-	_ DictionaryEntry = (*DictionaryEntryValue)(nil)
-	_ EtymologyEntry  = (*EtymologyEntryValue)(nil)
-	_ ThesaurusEntry  = (*ThesaurusEntryValue)(nil)
-	_ Sense           = (*SenseValue)(nil)
+	_ Result             = (*ResultValue)(nil)
+	_ Entry              = (*EntryValue)(nil)
+	_ WordEntry          = (*WordEntryValue)(nil)
+	_ DictionaryEntry    = (*DictionaryEntryValue)(nil)
+	_ EtymologyEntry     = (*EtymologyEntryValue)(nil)
+	_ ThesaurusEntry     = (*ThesaurusEntryValue)(nil)
+	_ InflectionEntry    = (*InflectionEntryValue)(nil)
+	_ PronunciationEntry = (*PronunciationEntryValue)(nil)
+	_ LanguageEntry      = (*LanguageEntryValue)(nil)
+	_ Sense              = (*SenseValue)(nil)
+	_ PronouncedSense    = (*SenseValue)(nil)
 )
 
 func TestHeadword(t *testing.T) {
@@ -165,6 +169,44 @@ func TestAntonyms(t *testing.T) {
 	}
 }
 
+func TestInflections(t *testing.T) {
+	inflections := []string{
+		"test",
+	}
+	e := InflectionEntryValue{InflectionVals: inflections}
+
+	for i, inflection := range e.Inflections() {
+		got := inflection
+		want := inflections[i]
+
+		if got != want {
+			t.Errorf("Inflections returned wrong value. Got %v. Want %v.", got, want)
+		}
+	}
+}
+
+func TestPronunciations(t *testing.T) {
+	pronunciations := []RegionalPronunciation{
+		{Transcription: "tɛst", Region: "British English"},
+	}
+	e := PronunciationEntryValue{PronunciationVals: pronunciations}
+
+	if got := e.Pronunciations(); !reflect.DeepEqual(pronunciations, got) {
+		t.Errorf("Pronunciations returned wrong value. Got %v. Want %v.", got, pronunciations)
+	}
+}
+
+func TestLanguageEntry(t *testing.T) {
+	e := LanguageEntryValue{LanguageVal: "test"}
+
+	got := e.Language()
+	want := e.LanguageVal
+
+	if got != want {
+		t.Errorf("Language returned wrong value. Got %v. Want %v.", got, want)
+	}
+}
+
 func TestDefinitions(t *testing.T) {
 	definitions := []string{
 		"test",
@@ -228,3 +270,36 @@ func TestSubsenses(t *testing.T) {
 		}
 	}
 }
+
+func TestNewResult(t *testing.T) {
+	sense := NewSense("to move swiftly").WithExamples("she ran").WithNotes("intransitive").WithSubsenses(NewSense("to flee"))
+	entry := NewEntry("run", "verb", sense).
+		WithPronunciation("/ɹʌn/").
+		WithPronunciations(RegionalPronunciation{Transcription: "/ɹʌn/", Region: "US"}).
+		WithEtymologies("Old English").
+		WithSynonyms("sprint").
+		WithAntonyms("walk").
+		WithInflections("ran")
+
+	got := NewResult("run", "en", entry)
+	want := ResultValue{
+		Head: "run",
+		Lang: "en",
+		EntryVals: []interface{}{EntryValue{
+			WordEntryValue:          WordEntryValue{WordVal: "run", CategoryVal: "verb"},
+			DictionaryEntryValue:    DictionaryEntryValue{PronunciationVal: "/ɹʌn/", SenseVals: []SenseValue{{DefinitionVals: []string{"to move swiftly"}, ExampleVals: []string{"she ran"}, NoteVals: []string{"intransitive"}, SubsenseVals: []SenseValue{{DefinitionVals: []string{"to flee"}}}}}},
+			EtymologyEntryValue:     EtymologyEntryValue{EtymologyVals: []string{"Old English"}},
+			ThesaurusEntryValue:     ThesaurusEntryValue{SynonymVals: []string{"sprint"}, AntonymVals: []string{"walk"}},
+			InflectionEntryValue:    InflectionEntryValue{InflectionVals: []string{"ran"}},
+			PronunciationEntryValue: PronunciationEntryValue{PronunciationVals: []RegionalPronunciation{{Transcription: "/ɹʌn/", Region: "US"}}},
+		}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewResult returned wrong value. Got %#v. Want %#v.", got, want)
+	}
+
+	if entries := got.Entries(); 1 != len(entries) || "run" != entries[0].(WordEntry).Word() {
+		t.Errorf("NewResult returned unusable entries %#v", entries)
+	}
+}
@@ -0,0 +1,337 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package datamuse provides a dictionary and rhyming source via the Datamuse
+// API
+package datamuse
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+// Name defines the name of the source
+const Name = "Datamuse API"
+
+const (
+	// baseURLString is the base URL for all Datamuse API interactions
+	baseURLString = "https://api.datamuse.com/words"
+
+	// spellingParameter defines the HTTP parameter for the word to define
+	spellingParameter = "sp"
+
+	// metadataParameter defines the HTTP parameter for the metadata to return
+	// with each word, where "d" is the word's definitions and "f" is its
+	// frequency
+	metadataParameter = "md"
+	definitionsFlag   = "d"
+	frequencyFlag     = "f"
+
+	// frequencyTagPrefix is the prefix of the tag containing a word's number
+	// of occurrences per million words
+	frequencyTagPrefix = "f:"
+
+	// rhymeParameter and nearRhymeParameter define the HTTP parameters for
+	// the word to find the perfect or near rhymes of
+	rhymeParameter     = "rel_rhy"
+	nearRhymeParameter = "rel_nry"
+
+	// triggerParameter defines the HTTP parameter for the word to find the
+	// "triggers" of (the words commonly used with it)
+	triggerParameter = "rel_trg"
+
+	// maxParameter defines the HTTP parameter for the maximum number of words
+	maxParameter = "max"
+
+	// definitionDelimiter is the delimiter between the part of speech and the
+	// text of each definition
+	definitionDelimiter = "\t"
+
+	httpRequestAcceptHeaderName = "Accept"
+	jsonMIMEType                = "application/json"
+)
+
+// validMIMETypes is the list of valid response MIME types
+var validMIMETypes = []string{jsonMIMEType}
+
+// categories maps the API's part of speech abbreviations to their names
+var categories = map[string]string{
+	"n":   "noun",
+	"v":   "verb",
+	"adj": "adjective",
+	"adv": "adverb",
+}
+
+// api is a struct containing a configured HTTP client for Datamuse API
+// operations
+type api struct {
+	httpClient *http.Client
+}
+
+// apiWord is a struct that defines the data structure for each word of the
+// Datamuse API results
+type apiWord struct {
+	Word  string
+	Score int
+	Defs  []string
+	Tags  []string
+}
+
+// datamuseEntry is a struct that contains the entry types for this API
+type datamuseEntry struct {
+	source.WordEntryValue
+	source.DictionaryEntryValue
+}
+
+// New returns a new Datamuse API dictionary source
+func New(httpClient http.Client) source.Source {
+	return &api{&httpClient}
+}
+
+// Name returns the name of the source
+func (g *api) Name() string {
+	return Name
+}
+
+// newRequest returns the HTTP request for the API's words with the given query
+// parameters
+func newRequest(queryParams url.Values) (*http.Request, error) {
+	requestURL, err := url.Parse(baseURLString)
+
+	if nil != err {
+		return nil, err
+	}
+
+	requestURL.RawQuery = queryParams.Encode()
+
+	httpRequest, err := http.NewRequest(http.MethodGet, requestURL.String(), nil)
+
+	if nil != err {
+		return nil, err
+	}
+
+	httpRequest.Header.Set(httpRequestAcceptHeaderName, jsonMIMEType)
+
+	return httpRequest, nil
+}
+
+// Request returns the HTTP request used to define the given word
+func (g *api) Request(word string) (*http.Request, error) {
+	return newRequest(url.Values{
+		spellingParameter: {word},
+		metadataParameter: {definitionsFlag},
+		maxParameter:      {"1"},
+	})
+}
+
+// fetch sends the given HTTP request and returns the words of its results
+func (g *api) fetch(httpRequest *http.Request) ([]apiWord, error) {
+	httpResponse, err := g.httpClient.Do(httpRequest)
+
+	if nil != err {
+		return nil, err
+	}
+
+	defer httpResponse.Body.Close()
+
+	if err = source.ValidateHTTPResponse(httpResponse, validMIMETypes, nil); nil != err {
+		return nil, err
+	}
+
+	var words []apiWord
+
+	if err = source.ReadJSONResponse(Name, httpResponse, &words); nil != err {
+		return nil, err
+	}
+
+	return words, nil
+}
+
+// Define takes a word string and returns a dictionary source.Result
+func (g *api) Define(word string) (source.Result, error) {
+	return g.DefineContext(context.Background(), word)
+}
+
+// DefineContext takes a word string and returns a dictionary source.Result,
+// cancelling its request if the context is done before it finishes
+func (g *api) DefineContext(ctx context.Context, word string) (source.Result, error) {
+	httpRequest, err := g.Request(word)
+
+	if nil != err {
+		return nil, err
+	}
+
+	words, err := g.fetch(httpRequest.WithContext(ctx))
+
+	if nil != err {
+		return nil, err
+	}
+
+	// The API returns the closest spelling, which may be a different word
+	if len(words) < 1 || !strings.EqualFold(word, words[0].Word) || len(words[0].Defs) < 1 {
+		return nil, &source.EmptyResultError{Word: word}
+	}
+
+	return source.ValidateAndReturnResult(words[0].toResult())
+}
+
+// Rhymes returns the words rhyming with the given word (or that nearly rhyme,
+// if near is true), up to the given limit (0 for the API's default limit)
+func (g *api) Rhymes(word string, near bool, limit uint) ([]source.Rhyme, error) {
+	return g.RhymesContext(context.Background(), word, near, limit)
+}
+
+// RhymesContext returns the words rhyming with the given word (or that nearly
+// rhyme, if near is true), up to the given limit (0 for the API's default
+// limit), cancelling its request if the context is done before it finishes
+func (g *api) RhymesContext(ctx context.Context, word string, near bool, limit uint) ([]source.Rhyme, error) {
+	queryParams := url.Values{}
+
+	if near {
+		queryParams.Set(nearRhymeParameter, word)
+	} else {
+		queryParams.Set(rhymeParameter, word)
+	}
+
+	if 0 < limit {
+		queryParams.Set(maxParameter, strconv.FormatUint(uint64(limit), 10))
+	}
+
+	httpRequest, err := newRequest(queryParams)
+
+	if nil != err {
+		return nil, err
+	}
+
+	words, err := g.fetch(httpRequest.WithContext(ctx))
+
+	if nil != err {
+		return nil, err
+	}
+
+	rhymes := make([]source.Rhyme, 0, len(words))
+
+	for _, rhyme := range words {
+		rhymes = append(rhymes, source.Rhyme{Word: rhyme.Word, Score: rhyme.Score})
+	}
+
+	return rhymes, nil
+}
+
+// Related returns the words that are commonly used with the given word, up to
+// the given limit (0 for the API's default limit)
+func (g *api) Related(word string, limit uint) ([]string, error) {
+	return g.RelatedContext(context.Background(), word, limit)
+}
+
+// RelatedContext returns the words that are commonly used with the given word,
+// up to the given limit (0 for the API's default limit), cancelling its request
+// if the context is done before it finishes
+func (g *api) RelatedContext(ctx context.Context, word string, limit uint) ([]string, error) {
+	queryParams := url.Values{triggerParameter: {word}}
+
+	if 0 < limit {
+		queryParams.Set(maxParameter, strconv.FormatUint(uint64(limit), 10))
+	}
+
+	httpRequest, err := newRequest(queryParams)
+
+	if nil != err {
+		return nil, err
+	}
+
+	words, err := g.fetch(httpRequest.WithContext(ctx))
+
+	if nil != err {
+		return nil, err
+	}
+
+	related := make([]string, 0, len(words))
+
+	for _, relatedWord := range words {
+		related = append(related, relatedWord.Word)
+	}
+
+	return related, nil
+}
+
+// Frequency returns the number of times that the word occurs per million
+// words of the API's corpus
+func (g *api) Frequency(word string) (float64, error) {
+	httpRequest, err := newRequest(url.Values{
+		spellingParameter: {word},
+		metadataParameter: {frequencyFlag},
+		maxParameter:      {"1"},
+	})
+
+	if nil != err {
+		return 0, err
+	}
+
+	words, err := g.fetch(httpRequest)
+
+	if nil != err {
+		return 0, err
+	}
+
+	// The API returns the closest spelling, which may be a different word
+	if len(words) < 1 || !strings.EqualFold(word, words[0].Word) {
+		return 0, &source.EmptyResultError{Word: word}
+	}
+
+	for _, tag := range words[0].Tags {
+		if strings.HasPrefix(tag, frequencyTagPrefix) {
+			return strconv.ParseFloat(strings.TrimPrefix(tag, frequencyTagPrefix), 64)
+		}
+	}
+
+	return 0, &source.EmptyResultError{Word: word}
+}
+
+// toResult converts the proprietary API result to a generic source.Result,
+// with an entry for each part of speech in the order they're first defined
+func (w apiWord) toResult() source.Result {
+	var entries []interface{}
+
+	entryIndexes := make(map[string]int)
+
+	for _, def := range w.Defs {
+		parts := strings.SplitN(def, definitionDelimiter, 2)
+
+		if len(parts) < 2 {
+			continue
+		}
+
+		category, known := categories[parts[0]]
+
+		if !known {
+			category = ""
+		}
+
+		index, exists := entryIndexes[category]
+
+		if !exists {
+			entry := datamuseEntry{}
+			entry.WordVal = w.Word
+			entry.CategoryVal = category
+
+			index = len(entries)
+			entryIndexes[category] = index
+			entries = append(entries, entry)
+		}
+
+		entry := entries[index].(datamuseEntry)
+		entry.SenseVals = append(entry.SenseVals, source.SenseValue{DefinitionVals: []string{strings.TrimSpace(parts[1])}})
+		entries[index] = entry
+	}
+
+	return source.ResultValue{
+		Head:      w.Word,
+		Lang:      "en",
+		EntryVals: entries,
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package datamuse
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+// stubTransport is an http.RoundTripper that responds to every request with
+// the same canned response, recording the last request
+type stubTransport struct {
+	statusCode int
+	body       string
+
+	request *http.Request
+}
+
+func (t *stubTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	t.request = request
+
+	return &http.Response{
+		StatusCode:    t.statusCode,
+		Header:        http.Header{"Content-Type": []string{jsonMIMEType}},
+		Body:          ioutil.NopCloser(strings.NewReader(t.body)),
+		ContentLength: int64(len(t.body)),
+		Request:       request,
+	}, nil
+}
+
+func TestRelated(t *testing.T) {
+	transport := &stubTransport{statusCode: http.StatusOK, body: `[{"word":"cow","score":1500},{"word":"goat","score":900}]`}
+	src := New(http.Client{Transport: transport}).(*api)
+
+	related, err := src.Related("milk", 2)
+
+	if nil != err {
+		t.Fatalf("Related returned error %q", err)
+	}
+
+	if want := []string{"cow", "goat"}; !reflect.DeepEqual(want, related) {
+		t.Errorf("Related returned %q, want %q", related, want)
+	}
+
+	if query := transport.request.URL.Query(); "milk" != query.Get(triggerParameter) || "2" != query.Get(maxParameter) {
+		t.Errorf("Related sent the query %q", transport.request.URL.RawQuery)
+	}
+
+	transport.statusCode = http.StatusInternalServerError
+
+	if _, err := src.Related("milk", 0); nil == err {
+		t.Error("Related didn't return an error for an HTTP error")
+	}
+}
+
+func TestRelatedContext(t *testing.T) {
+	transport := &stubTransport{statusCode: http.StatusOK, body: `[{"word":"cow","score":1500}]`}
+	src := New(http.Client{Transport: transport}).(*api)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := src.RelatedContext(ctx, "milk", 1); nil != err {
+		t.Fatalf("RelatedContext returned error %q", err)
+	}
+
+	if ctx != transport.request.Context() {
+		t.Error("RelatedContext didn't send its request with the context")
+	}
+}
+
+func TestRhymesContext(t *testing.T) {
+	transport := &stubTransport{statusCode: http.StatusOK, body: `[{"word":"bake","score":3000},{"word":"lake","score":2500}]`}
+	src := New(http.Client{Transport: transport}).(*api)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rhymes, err := src.RhymesContext(ctx, "cake", true, 2)
+
+	if nil != err {
+		t.Fatalf("RhymesContext returned error %q", err)
+	}
+
+	if want := []source.Rhyme{{Word: "bake", Score: 3000}, {Word: "lake", Score: 2500}}; !reflect.DeepEqual(want, rhymes) {
+		t.Errorf("RhymesContext returned %+v, want %+v", rhymes, want)
+	}
+
+	if query := transport.request.URL.Query(); "cake" != query.Get(nearRhymeParameter) || "2" != query.Get(maxParameter) {
+		t.Errorf("RhymesContext sent the query %q", transport.request.URL.RawQuery)
+	}
+
+	if ctx != transport.request.Context() {
+		t.Error("RhymesContext didn't send its request with the context")
+	}
+}
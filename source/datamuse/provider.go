@@ -0,0 +1,77 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package datamuse
+
+import (
+	"encoding/json"
+	"net/http"
+
+	flag "github.com/ogier/pflag"
+
+	"github.com/Rican7/define/internal/transport"
+	"github.com/Rican7/define/registry"
+	"github.com/Rican7/define/source"
+)
+
+type config struct {
+	Headers map[string]string
+}
+
+type provider struct{}
+
+// JSONKey defines the JSON key used for the provider
+const JSONKey = "Datamuse"
+
+// httpTransport is the transport of the HTTP clients of the provided sources,
+// which tests can replace with a stub (nil uses http.DefaultTransport)
+var httpTransport http.RoundTripper
+
+func init() {
+	registry.Register(registry.RegisterFunc(register))
+}
+
+func register(*flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
+	return &provider{}, &config{}
+}
+
+func (c *config) JSONKey() string {
+	return JSONKey
+}
+
+// UnmarshalJSON defines how the configuration should be JSON unmarshalled.
+func (c *config) UnmarshalJSON(data []byte) error {
+	// Alias our type so that we can unmarshal as usual
+	type alias config
+	copy := &alias{}
+
+	// Unmarshal into our copy
+	err := json.Unmarshal(data, copy)
+
+	if nil != err {
+		return err
+	}
+
+	if nil == c.Headers {
+		c.Headers = copy.Headers
+	}
+
+	return nil
+}
+
+func (p *provider) Name() string {
+	return Name
+}
+
+func (p *provider) Metadata() registry.Metadata {
+	return registry.Metadata{
+		Description:  "A word-finding engine of rhymes, word frequencies, and related words",
+		Homepage:     "https://www.datamuse.com/api/",
+		Capabilities: []string{registry.CapabilityRhymes, registry.CapabilityFrequencies, registry.CapabilityRelated},
+	}
+}
+
+func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
+	config := conf.(*config)
+
+	return New(http.Client{Transport: transport.WithHeaders(httpTransport, config.Headers)}), nil
+}
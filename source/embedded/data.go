@@ -0,0 +1,20 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+//go:build go1.16
+// +build go1.16
+
+package embedded
+
+import (
+	_ "embed" // Required for the go:embed directive
+)
+
+// data is the contents of the embedded dictionary file
+//
+//go:embed dictionary.tsv
+var data string
+
+// frequencyData is the contents of the embedded word frequencies file
+//
+//go:embed frequencies.tsv
+var frequencyData string
@@ -0,0 +1,13 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+//go:build !go1.16
+// +build !go1.16
+
+package embedded
+
+// data and frequencyData are the contents of the embedded dictionary and word
+// frequencies files, which can't be embedded before Go 1.16.
+var (
+	data          string
+	frequencyData string
+)
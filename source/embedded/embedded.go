@@ -0,0 +1,175 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package embedded provides a small dictionary of common words, compiled into
+// the binary, as a source of last resort
+package embedded
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Rican7/define/source"
+)
+
+// Name defines the name of the source
+const Name = "Embedded Dictionary"
+
+const (
+	// fieldDelimiter is the delimiter between the fields of each line
+	fieldDelimiter = "\t"
+
+	// commentLinePrefix is the prefix of lines to be ignored entirely
+	commentLinePrefix = "#"
+)
+
+// dictionary is a struct containing the lazily parsed embedded dictionary
+type dictionary struct {
+	load  sync.Once
+	index map[string]*indexEntry
+
+	loadFrequencies sync.Once
+	frequencies     map[string]float64
+}
+
+// indexEntry is a struct that defines an entry in the dictionary's index
+type indexEntry struct {
+	word       string
+	categories []string
+	senses     map[string][]string
+}
+
+// embeddedEntry is a struct that contains the entry types for this source
+type embeddedEntry struct {
+	source.WordEntryValue
+	source.DictionaryEntryValue
+}
+
+// shared is the dictionary shared by all of the returned sources, so that it's
+// only parsed once
+var shared = &dictionary{}
+
+// New returns a new embedded dictionary source
+func New() source.Source {
+	return shared
+}
+
+// Available returns whether the dictionary was embedded in the binary
+func Available() bool {
+	return "" != data
+}
+
+// Name returns the name of the source
+func (d *dictionary) Name() string {
+	return Name
+}
+
+// Define takes a word string and returns a dictionary source.Result
+func (d *dictionary) Define(word string) (source.Result, error) {
+	d.load.Do(d.parse)
+
+	entry, ok := d.index[strings.ToLower(strings.TrimSpace(word))]
+
+	if !ok {
+		return nil, &source.EmptyResultError{Word: word}
+	}
+
+	return entry.toResult(), nil
+}
+
+// Frequency returns the number of times that the word occurs per million
+// words of general text, according to the embedded frequency list
+func (d *dictionary) Frequency(word string) (float64, error) {
+	d.loadFrequencies.Do(d.parseFrequencies)
+
+	frequency, ok := d.frequencies[strings.ToLower(strings.TrimSpace(word))]
+
+	if !ok {
+		return 0, &source.EmptyResultError{Word: word}
+	}
+
+	return frequency, nil
+}
+
+// parseFrequencies parses the embedded frequency data
+func (d *dictionary) parseFrequencies() {
+	d.frequencies = make(map[string]float64)
+
+	scanner := bufio.NewScanner(strings.NewReader(frequencyData))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if "" == line || strings.HasPrefix(line, commentLinePrefix) {
+			continue
+		}
+
+		fields := strings.SplitN(line, fieldDelimiter, 2)
+
+		if len(fields) < 2 {
+			continue
+		}
+
+		if frequency, err := strconv.ParseFloat(fields[1], 64); nil == err {
+			d.frequencies[strings.ToLower(fields[0])] = frequency
+		}
+	}
+}
+
+// parse parses the embedded data into the dictionary's index
+func (d *dictionary) parse() {
+	d.index = make(map[string]*indexEntry)
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if "" == line || strings.HasPrefix(line, commentLinePrefix) {
+			continue
+		}
+
+		fields := strings.SplitN(line, fieldDelimiter, 3)
+
+		if len(fields) < 3 {
+			continue
+		}
+
+		word, category, definition := fields[0], fields[1], fields[2]
+		key := strings.ToLower(word)
+
+		entry, exists := d.index[key]
+
+		if !exists {
+			entry = &indexEntry{word: word, senses: make(map[string][]string)}
+			d.index[key] = entry
+		}
+
+		if _, exists := entry.senses[category]; !exists {
+			entry.categories = append(entry.categories, category)
+		}
+
+		entry.senses[category] = append(entry.senses[category], definition)
+	}
+}
+
+// toResult converts the index entry to a source.Result, with an entry for each
+// of its categories
+func (e *indexEntry) toResult() source.Result {
+	result := source.ResultValue{Head: e.word, Lang: "en"}
+
+	for _, category := range e.categories {
+		entry := embeddedEntry{}
+		entry.WordVal = e.word
+		entry.CategoryVal = category
+
+		for _, definition := range e.senses[category] {
+			entry.SenseVals = append(entry.SenseVals, source.SenseValue{DefinitionVals: []string{definition}})
+		}
+
+		result.EntryVals = append(result.EntryVals, entry)
+	}
+
+	return result
+}
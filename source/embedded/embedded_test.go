@@ -0,0 +1,70 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+//go:build go1.16
+// +build go1.16
+
+package embedded
+
+import (
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestDefine(t *testing.T) {
+	if !Available() {
+		t.Fatal("Available returned false, want the dictionary to be embedded")
+	}
+
+	result, err := New().Define("Run")
+
+	if nil != err {
+		t.Fatalf("Define returned an error: %s", err)
+	}
+
+	entries := result.Entries()
+
+	if "run" != result.Headword() || 2 != len(entries) {
+		t.Fatalf("Define returned the headword %q with %d entries, want \"run\" with 2", result.Headword(), len(entries))
+	}
+
+	if got := entries[0].(source.WordEntry).Category(); "verb" != got {
+		t.Errorf("Define returned the first category %q, want \"verb\"", got)
+	}
+
+	if got := len(entries[0].Senses()); 2 != got {
+		t.Errorf("Define returned %d verb senses, want 2", got)
+	}
+}
+
+func TestDefineUnknown(t *testing.T) {
+	if _, err := New().Define("zzyzx"); nil == err {
+		t.Error("Define didn't return an error for an unknown word")
+	} else if _, ok := err.(*source.EmptyResultError); !ok {
+		t.Errorf("Define returned the error %#v, want an EmptyResultError", err)
+	}
+}
+
+func TestFrequency(t *testing.T) {
+	frequencySource := New().(source.FrequencySource)
+
+	common, err := frequencySource.Frequency("House")
+
+	if nil != err {
+		t.Fatalf("Frequency returned an error: %s", err)
+	}
+
+	rare, err := frequencySource.Frequency("serendipity")
+
+	if nil != err {
+		t.Fatalf("Frequency returned an error: %s", err)
+	}
+
+	if common <= rare {
+		t.Errorf("Frequency returned %v for \"house\" and %v for \"serendipity\", want the former to be higher", common, rare)
+	}
+
+	if _, err := frequencySource.Frequency("zzyzx"); nil == err {
+		t.Error("Frequency didn't return an error for an unknown word")
+	}
+}
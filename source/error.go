@@ -22,6 +22,10 @@ var acceptableStatusCodes = []int{http.StatusOK}
 // EmptyResultError represents an error caused by an empty result
 type EmptyResultError struct {
 	Word string
+
+	// Suggestions holds any alternative words that the source suggested, such
+	// as when the word was likely misspelled
+	Suggestions []string
 }
 
 // AuthenticationError represents an error caused by an authentication problem
@@ -33,12 +37,19 @@ type InvalidResponseError struct {
 	httpResponse *http.Response
 }
 
+// IncompleteResponseError represents an error caused by a response that ended
+// before it was complete, such as when the connection dropped mid-response
+type IncompleteResponseError struct {
+	Source string
+	Err    error
+}
+
 // ValidateResult validates the result and returns an error if invalid
 func ValidateResult(result Result) error {
 	if nil == result {
 		return &EmptyResultError{}
 	} else if len(result.Entries()) < 1 || "" == result.Headword() {
-		return &EmptyResultError{result.Headword()}
+		return &EmptyResultError{Word: result.Headword()}
 	}
 
 	return nil
@@ -112,3 +123,7 @@ func (e *AuthenticationError) Error() string {
 func (e *InvalidResponseError) Error() string {
 	return invalidResponseErrorMessage
 }
+
+func (e *IncompleteResponseError) Error() string {
+	return fmt.Sprintf("incomplete response from %q, please retry", e.Source)
+}
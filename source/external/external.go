@@ -0,0 +1,121 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package external provides dictionary sources via external commands, which
+// are passed a word and print its definition as a JSON result (in the same
+// format as the app's JSON output) to stdout
+package external
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+// command is a struct containing the configuration of an external command
+type command struct {
+	name  string
+	path  string
+	args  []string
+	stdin bool
+}
+
+// CommandError represents an error when an external command fails
+type CommandError struct {
+	Name   string
+	Err    error
+	Stderr string
+}
+
+// New returns a new external command dictionary source of the given name. The
+// command at the given path is run with the given arguments, followed by the
+// word to define, unless stdin is true, in which case the word is written to
+// the command's stdin instead.
+func New(name string, path string, args []string, stdin bool) source.Source {
+	return &command{name: name, path: path, args: args, stdin: stdin}
+}
+
+// Error satisfies the error interface.
+func (e *CommandError) Error() string {
+	if "" != e.Stderr {
+		return fmt.Sprintf("command of source %q failed with error: %s: %s", e.Name, e.Err, e.Stderr)
+	}
+
+	return fmt.Sprintf("command of source %q failed with error: %s", e.Name, e.Err)
+}
+
+// Name returns the name of the source
+func (c *command) Name() string {
+	return c.name
+}
+
+// Define takes a word string and returns a dictionary source.Result
+func (c *command) Define(word string) (source.Result, error) {
+	return c.DefineContext(context.Background(), word)
+}
+
+// DefineContext takes a word string and returns a dictionary source.Result,
+// killing the command if the context is done before it finishes
+func (c *command) DefineContext(ctx context.Context, word string) (source.Result, error) {
+	args := c.args
+
+	if !c.stdin {
+		args = append(append([]string{}, c.args...), word)
+	}
+
+	cmd := exec.CommandContext(ctx, c.path, args...)
+
+	var stdout, stderr bytes.Buffer
+
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if c.stdin {
+		cmd.Stdin = strings.NewReader(word + "\n")
+	}
+
+	if err := cmd.Start(); nil != err {
+		return nil, &CommandError{Name: c.name, Err: err}
+	}
+
+	// Buffered, so the wait can finish even if we're no longer receiving
+	done := make(chan error, 1)
+
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	// The command is killed once the context is done, but waiting for it can
+	// still be held up by any of its own subprocesses keeping its output open
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case err := <-done:
+		if nil != err {
+			if nil != ctx.Err() {
+				return nil, ctx.Err()
+			}
+
+			return nil, &CommandError{Name: c.name, Err: err, Stderr: strings.TrimSpace(stderr.String())}
+		}
+	}
+
+	if 0 == len(bytes.TrimSpace(stdout.Bytes())) {
+		return nil, &source.EmptyResultError{Word: word}
+	}
+
+	result, err := source.UnmarshalResultJSON(stdout.Bytes())
+
+	if nil != err {
+		return nil, fmt.Errorf("command of source %q printed an invalid JSON result: %s", c.name, err)
+	}
+
+	if err = source.ValidateResult(result); nil != err {
+		return nil, &source.EmptyResultError{Word: word}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,152 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package external
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rican7/define/source"
+)
+
+// helperEnvKey is the environment variable that makes the test binary act as
+// an external command, rather than running the tests
+const helperEnvKey = "DEFINE_TEST_EXTERNAL_HELPER"
+
+func TestMain(m *testing.M) {
+	if "" != os.Getenv(helperEnvKey) {
+		os.Exit(runHelper(os.Args[1:]))
+	}
+
+	// Commands run by the tests inherit the environment
+	os.Setenv(helperEnvKey, "1")
+
+	os.Exit(m.Run())
+}
+
+// runHelper acts as an external command, behaving according to the mode given
+// as its first argument, and returns its exit code
+func runHelper(args []string) int {
+	mode, word := args[0], ""
+
+	if 1 < len(args) {
+		word = args[len(args)-1]
+	}
+
+	switch mode {
+	case "json":
+		fmt.Printf(`{
+			"headword": %q,
+			"language": "en",
+			"entries": [{
+				"word": %[1]q,
+				"category": "noun",
+				"pronunciation": "tɛst",
+				"senses": [{"definitions": ["a procedure"], "examples": ["a test"]}]
+			}]
+		}`, word)
+	case "stdin":
+		word, _ = bufio.NewReader(os.Stdin).ReadString('\n')
+
+		fmt.Printf(`{"headword": %q, "entries": [{"senses": [{"definitions": ["from stdin"]}]}]}`, strings.TrimSpace(word))
+	case "invalid":
+		fmt.Print("not a JSON result")
+	case "empty":
+		fmt.Print(`{"headword": "test", "entries": []}`)
+	case "fail":
+		fmt.Fprintln(os.Stderr, "no such word")
+
+		return 3
+	case "sleep":
+		time.Sleep(time.Minute)
+	}
+
+	return 0
+}
+
+// newHelper returns a source that runs the test binary as a command of the
+// given mode
+func newHelper(mode string, stdin bool) source.Source {
+	return New("helper", os.Args[0], []string{mode}, stdin)
+}
+
+func TestDefine(t *testing.T) {
+	result, err := newHelper("json", false).Define("test")
+
+	if nil != err {
+		t.Fatalf("Define returned error %q", err)
+	}
+
+	if "test" != result.Headword() || "en" != result.Language() || 1 != len(result.Entries()) {
+		t.Fatalf("Define returned the headword %q, language %q, and %d entries", result.Headword(), result.Language(), len(result.Entries()))
+	}
+
+	entry := result.Entries()[0]
+
+	if "test" != entry.(source.WordEntry).Word() || "noun" != entry.(source.WordEntry).Category() || "tɛst" != entry.Pronunciation() {
+		t.Errorf("Define returned the entry %+v", entry)
+	}
+
+	senses := entry.Senses()
+
+	if 1 != len(senses) || !reflect.DeepEqual([]string{"a procedure"}, senses[0].Definitions()) || !reflect.DeepEqual([]string{"a test"}, senses[0].Examples()) {
+		t.Errorf("Define returned the senses %+v", senses)
+	}
+}
+
+func TestDefineStdin(t *testing.T) {
+	result, err := newHelper("stdin", true).Define("piped")
+
+	if nil != err {
+		t.Fatalf("Define returned error %q", err)
+	}
+
+	if "piped" != result.Headword() {
+		t.Errorf("Define returned the headword %q, want %q", result.Headword(), "piped")
+	}
+}
+
+func TestDefineInvalidOutput(t *testing.T) {
+	if _, err := newHelper("invalid", false).Define("test"); nil == err || !strings.Contains(err.Error(), "invalid JSON") {
+		t.Errorf("Define returned error %v, want an error about the invalid JSON", err)
+	}
+
+	if _, err := newHelper("empty", false).Define("test"); !reflect.DeepEqual(&source.EmptyResultError{Word: "test"}, err) {
+		t.Errorf("Define returned error %v, want a *source.EmptyResultError", err)
+	}
+}
+
+func TestDefineNonZeroExit(t *testing.T) {
+	_, err := newHelper("fail", false).Define("test")
+	commandErr, ok := err.(*CommandError)
+
+	if !ok {
+		t.Fatalf("Define returned error %v, want a *CommandError", err)
+	}
+
+	if "helper" != commandErr.Name || "no such word" != commandErr.Stderr || !strings.Contains(commandErr.Err.Error(), "exit status 3") {
+		t.Errorf("Define returned the command error %+v", commandErr)
+	}
+}
+
+func TestDefineTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := source.DefineContext(ctx, newHelper("sleep", false), "test")
+
+	if context.DeadlineExceeded != err {
+		t.Errorf("DefineContext returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if elapsed := time.Since(start); 10*time.Second < elapsed {
+		t.Errorf("DefineContext took %s to return", elapsed)
+	}
+}
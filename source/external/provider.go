@@ -0,0 +1,55 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package external
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/Rican7/define/registry"
+	"github.com/Rican7/define/source"
+)
+
+// config is the configuration of an external command source, which is only
+// defined by the app's configuration (rather than by flags or a section of
+// the config file)
+type config struct {
+	name string
+}
+
+type provider struct {
+	name  string
+	path  string
+	args  []string
+	stdin bool
+}
+
+// NewProvider returns a source provider, and its configuration, for an
+// external command source of the given name (see New). An error is returned
+// if the command can't be found.
+func NewProvider(name string, commandPath string, args []string, stdin bool) (registry.SourceProvider, registry.Configuration, error) {
+	if "" == name {
+		return nil, nil, errors.New("external command sources must have a name")
+	}
+
+	path, err := exec.LookPath(commandPath)
+
+	if nil != err {
+		return nil, nil, fmt.Errorf("command %q of source %q can't be found: %s", commandPath, name, err)
+	}
+
+	return &provider{name: name, path: path, args: args, stdin: stdin}, &config{name: name}, nil
+}
+
+func (c *config) JSONKey() string {
+	return c.name
+}
+
+func (p *provider) Name() string {
+	return p.name
+}
+
+func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
+	return New(p.name, p.path, p.args, p.stdin), nil
+}
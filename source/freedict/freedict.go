@@ -0,0 +1,291 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package freedict provides a dictionary source via the FreeDict TEI
+// formatted dictionaries
+package freedict
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Rican7/define/source"
+)
+
+// Name defines the name of the source
+const Name = "FreeDict Dictionaries"
+
+const (
+	// teiURLFormat is the URL format for the TEI file of a language pair
+	teiURLFormat = "https://raw.githubusercontent.com/freedict/fd-dictionaries/master/%[1]s/%[1]s.tei"
+
+	httpRequestAcceptHeaderName = "Accept"
+
+	xmlMIMEType     = "application/xml"
+	xmlTextMIMEType = "text/xml"
+	plainMIMEType   = "text/plain"
+
+	entryTagName = "entry"
+
+	citationTypeTranslation = "trans"
+	citationTypeExample     = "example"
+
+	languagePairDelimiter = "-"
+)
+
+// validMIMETypes is the list of valid response MIME types
+var validMIMETypes = []string{xmlMIMEType, xmlTextMIMEType, plainMIMEType}
+
+// dictionary is a struct containing a lazily loaded FreeDict dictionary
+type dictionary struct {
+	httpClient *http.Client
+	pair       string
+
+	loadMutex sync.Mutex
+	loaded    bool
+	loadErr   error
+	index     map[string][]teiEntry
+}
+
+// teiEntry defines the data structure for TEI dictionary entries
+type teiEntry struct {
+	Forms []struct {
+		Orths []string `xml:"orth"`
+		Prons []string `xml:"pron"`
+	} `xml:"form"`
+	PartsOfSpeech []string   `xml:"gramGrp>pos"`
+	Senses        []teiSense `xml:"sense"`
+}
+
+// teiSense defines the data structure for TEI dictionary senses
+type teiSense struct {
+	Definitions []string      `xml:"def"`
+	Citations   []teiCitation `xml:"cit"`
+	Notes       []string      `xml:"note"`
+	Subsenses   []teiSense    `xml:"sense"`
+}
+
+// teiCitation defines the data structure for TEI citations, such as
+// translations and examples
+type teiCitation struct {
+	Type   string   `xml:"type,attr"`
+	Quotes []string `xml:"quote"`
+}
+
+// freedictEntry is a struct that contains the entry types for this source
+type freedictEntry struct {
+	source.WordEntryValue
+	source.DictionaryEntryValue
+}
+
+// New returns a new FreeDict dictionary source for the given language pair
+// (such as "eng-fra")
+func New(httpClient http.Client, pair string) source.Source {
+	return &dictionary{httpClient: &httpClient, pair: pair}
+}
+
+// Name returns the name of the source
+func (d *dictionary) Name() string {
+	return Name
+}
+
+// Options returns the options that the source's results depend on
+func (d *dictionary) Options() source.Options {
+	return source.Options{Language: d.pair}
+}
+
+// Define takes a word string and returns a dictionary source.Result
+func (d *dictionary) Define(word string) (source.Result, error) {
+	return d.DefineContext(context.Background(), word)
+}
+
+// DefineContext takes a word string and returns a dictionary source.Result,
+// cancelling the download of the dictionary if the context is done before it
+// finishes
+func (d *dictionary) DefineContext(ctx context.Context, word string) (source.Result, error) {
+	if err := d.loadIndex(ctx); nil != err {
+		return nil, err
+	}
+
+	entries, ok := d.index[normalizeWord(word)]
+
+	if !ok {
+		return nil, &source.EmptyResultError{Word: word}
+	}
+
+	return source.ValidateAndReturnResult(d.toResult(word, entries))
+}
+
+// Request returns the HTTP request used to download the dictionary, which is
+// the same for every word (as the whole dictionary is downloaded only once)
+func (d *dictionary) Request(word string) (*http.Request, error) {
+	return http.NewRequest(http.MethodGet, fmt.Sprintf(teiURLFormat, d.pair), nil)
+}
+
+// loadIndex downloads and parses the dictionary into the in-memory index,
+// only once. A download that's cancelled by the context isn't remembered, so
+// that it's tried again by the next lookup.
+func (d *dictionary) loadIndex(ctx context.Context) error {
+	d.loadMutex.Lock()
+	defer d.loadMutex.Unlock()
+
+	if d.loaded {
+		return d.loadErr
+	}
+
+	index, err := d.downloadIndex(ctx)
+
+	if nil != err && nil != ctx.Err() {
+		return ctx.Err()
+	}
+
+	d.index, d.loadErr, d.loaded = index, err, true
+
+	return d.loadErr
+}
+
+// downloadIndex downloads and parses the dictionary into an index
+func (d *dictionary) downloadIndex(ctx context.Context) (map[string][]teiEntry, error) {
+	httpRequest, err := d.Request("")
+
+	if nil != err {
+		return nil, err
+	}
+
+	httpResponse, err := d.httpClient.Do(httpRequest.WithContext(ctx))
+
+	if nil != err {
+		return nil, err
+	}
+
+	defer httpResponse.Body.Close()
+
+	if err = source.ValidateHTTPResponse(httpResponse, validMIMETypes, nil); nil != err {
+		return nil, err
+	}
+
+	return parse(httpResponse.Body)
+}
+
+// parse parses TEI formatted dictionary data into an index of normalized
+// headwords to their entries
+func parse(reader io.Reader) (map[string][]teiEntry, error) {
+	index := make(map[string][]teiEntry)
+	decoder := xml.NewDecoder(reader)
+
+	for {
+		token, err := decoder.Token()
+
+		if io.EOF == err {
+			break
+		} else if nil != err {
+			return nil, err
+		}
+
+		start, ok := token.(xml.StartElement)
+
+		if !ok || entryTagName != start.Name.Local {
+			continue
+		}
+
+		var entry teiEntry
+
+		if err = decoder.DecodeElement(&entry, &start); nil != err {
+			return nil, err
+		}
+
+		for _, form := range entry.Forms {
+			for _, orth := range form.Orths {
+				key := normalizeWord(orth)
+
+				index[key] = append(index[key], entry)
+			}
+		}
+	}
+
+	return index, nil
+}
+
+// normalizeWord normalizes a word for use as an index key
+func normalizeWord(word string) string {
+	return strings.ToLower(strings.TrimSpace(word))
+}
+
+// toResult converts the TEI entries to a generic source.Result
+func (d *dictionary) toResult(word string, teiEntries []teiEntry) source.Result {
+	entries := make([]interface{}, 0, len(teiEntries))
+	headword := word
+
+	for _, teiEntry := range teiEntries {
+		entry := freedictEntry{}
+
+		for _, form := range teiEntry.Forms {
+			for _, orth := range form.Orths {
+				if strings.EqualFold(orth, word) {
+					entry.WordVal = strings.TrimSpace(orth)
+				}
+			}
+
+			if "" == entry.PronunciationVal && len(form.Prons) > 0 {
+				entry.PronunciationVal = strings.TrimSpace(form.Prons[0])
+			}
+		}
+
+		if len(teiEntry.PartsOfSpeech) > 0 {
+			entry.CategoryVal = strings.TrimSpace(teiEntry.PartsOfSpeech[0])
+		}
+
+		for _, sense := range teiEntry.Senses {
+			senseVal := sense.toSenseValue()
+
+			for _, subSense := range sense.Subsenses {
+				senseVal.SubsenseVals = append(senseVal.SubsenseVals, subSense.toSenseValue())
+			}
+
+			entry.SenseVals = append(entry.SenseVals, senseVal)
+		}
+
+		headword = entry.WordVal
+		entries = append(entries, entry)
+	}
+
+	return source.ResultValue{
+		Head:      headword,
+		Lang:      strings.SplitN(d.pair, languagePairDelimiter, 2)[0],
+		EntryVals: entries,
+	}
+}
+
+// toSenseValue converts the TEI sense to a source.SenseValue
+func (s teiSense) toSenseValue() source.SenseValue {
+	var definitions, examples, notes []string
+
+	for _, definition := range s.Definitions {
+		definitions = append(definitions, strings.TrimSpace(definition))
+	}
+
+	for _, citation := range s.Citations {
+		for _, quote := range citation.Quotes {
+			switch citation.Type {
+			case citationTypeTranslation:
+				definitions = append(definitions, strings.TrimSpace(quote))
+			case citationTypeExample:
+				examples = append(examples, strings.TrimSpace(quote))
+			}
+		}
+	}
+
+	for _, note := range s.Notes {
+		notes = append(notes, strings.TrimSpace(note))
+	}
+
+	return source.SenseValue{
+		DefinitionVals: definitions,
+		ExampleVals:    examples,
+		NoteVals:       notes,
+	}
+}
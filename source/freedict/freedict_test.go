@@ -0,0 +1,148 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package freedict
+
+import (
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+// teiFixture is a small TEI dictionary, of an entry with a pronunciation,
+// notes, several translations, an example, and a sub-sense, an entry of two
+// spellings, and an entry sharing the headword of the first
+const teiFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<TEI xmlns="http://www.tei-c.org/ns/1.0">
+  <teiHeader><fileDesc><titleStmt><title>English-French FreeDict Dictionary</title></titleStmt></fileDesc></teiHeader>
+  <text><body>
+    <entry>
+      <form><orth>Bank</orth><pron>bæŋk</pron></form>
+      <gramGrp><pos>n</pos></gramGrp>
+      <sense>
+        <note> finance </note>
+        <cit type="trans"><quote>banque</quote><quote>établissement</quote></cit>
+        <cit type="example"><quote> the bank is closed </quote></cit>
+        <sense><cit type="trans"><quote>caisse</quote></cit></sense>
+      </sense>
+      <sense>
+        <def>the land alongside a river</def>
+        <cit type="trans"><quote>rive</quote></cit>
+      </sense>
+    </entry>
+    <entry>
+      <form><orth>colour</orth><orth>color</orth></form>
+      <sense><cit type="trans"><quote>couleur</quote></cit></sense>
+    </entry>
+    <entry>
+      <form><orth>bank</orth></form>
+      <gramGrp><pos>v</pos></gramGrp>
+      <sense><cit type="trans"><quote>virer sur l'aile</quote></cit></sense>
+    </entry>
+  </body></text>
+</TEI>
+`
+
+// stubTransport is an http.RoundTripper that responds to every request with
+// the same canned response
+type stubTransport struct {
+	statusCode int
+	body       string
+}
+
+func (t *stubTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode:    t.statusCode,
+		Header:        http.Header{"Content-Type": []string{xmlMIMEType}},
+		Body:          ioutil.NopCloser(strings.NewReader(t.body)),
+		ContentLength: int64(len(t.body)),
+		Request:       request,
+	}, nil
+}
+
+func TestParse(t *testing.T) {
+	index, err := parse(strings.NewReader(teiFixture))
+
+	if nil != err {
+		t.Fatalf("parse returned error %q", err)
+	}
+
+	counts := make(map[string]int, len(index))
+
+	for key, entries := range index {
+		counts[key] = len(entries)
+	}
+
+	if want := map[string]int{"bank": 2, "colour": 1, "color": 1}; !reflect.DeepEqual(want, counts) {
+		t.Errorf("parse indexed %v, want %v", counts, want)
+	}
+
+	if _, err := parse(strings.NewReader("<TEI><entry><form><orth>x</orth></entry></TEI>")); nil == err {
+		t.Error("parse of malformed XML didn't return an error")
+	}
+}
+
+func TestDefine(t *testing.T) {
+	src := New(http.Client{Transport: &stubTransport{statusCode: http.StatusOK, body: teiFixture}}, "eng-fra")
+
+	result, err := src.Define("bank")
+
+	if nil != err {
+		t.Fatalf("Define returned error %q", err)
+	}
+
+	if "bank" != result.Headword() || "eng" != result.Language() || 2 != len(result.Entries()) {
+		t.Fatalf("Define returned the headword %q, language %q, and %d entries", result.Headword(), result.Language(), len(result.Entries()))
+	}
+
+	noun := result.Entries()[0]
+
+	if "Bank" != noun.(source.WordEntry).Word() || "n" != noun.(source.WordEntry).Category() || "bæŋk" != noun.Pronunciation() {
+		t.Errorf("Define returned the entry %+v", noun)
+	}
+
+	senses := noun.Senses()
+
+	if 2 != len(senses) {
+		t.Fatalf("Define returned %d senses, want 2", len(senses))
+	}
+
+	if want := []string{"banque", "établissement"}; !reflect.DeepEqual(want, senses[0].Definitions()) {
+		t.Errorf("Define returned the translations %q, want %q", senses[0].Definitions(), want)
+	}
+
+	if want := []string{"finance"}; !reflect.DeepEqual(want, senses[0].Notes()) {
+		t.Errorf("Define returned the notes %q, want %q", senses[0].Notes(), want)
+	}
+
+	if want := []string{"the bank is closed"}; !reflect.DeepEqual(want, senses[0].Examples()) {
+		t.Errorf("Define returned the examples %q, want %q", senses[0].Examples(), want)
+	}
+
+	if subsenses := senses[0].Subsenses(); 1 != len(subsenses) || !reflect.DeepEqual([]string{"caisse"}, subsenses[0].Definitions()) {
+		t.Errorf("Define returned the sub-senses %+v", subsenses)
+	}
+
+	if want := []string{"the land alongside a river", "rive"}; !reflect.DeepEqual(want, senses[1].Definitions()) {
+		t.Errorf("Define returned the definitions %q, want %q", senses[1].Definitions(), want)
+	}
+
+	if result, err := src.Define("Color"); nil != err || "color" != result.Headword() {
+		t.Errorf("Define of an alternative spelling returned %v, with error %v", result, err)
+	}
+
+	if _, err := src.Define("river"); nil == err {
+		t.Error("Define of an unknown word didn't return an error")
+	}
+}
+
+func TestDefineHTTPError(t *testing.T) {
+	src := New(http.Client{Transport: &stubTransport{statusCode: http.StatusNotFound, body: "Not Found"}}, "eng-xxx")
+
+	if _, err := src.Define("bank"); nil == err {
+		t.Error("Define didn't return an error for an HTTP error")
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package freedict
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	flag "github.com/ogier/pflag"
+
+	"github.com/Rican7/define/internal/transport"
+	"github.com/Rican7/define/registry"
+	"github.com/Rican7/define/source"
+)
+
+// RequiredConfigError represents an error when a required configuration key is
+// missing or invalid.
+type RequiredConfigError struct {
+	Key string
+}
+
+type config struct {
+	Pair    string
+	Headers map[string]string
+}
+
+type provider struct{}
+
+// JSONKey defines the JSON key used for the provider
+const JSONKey = "FreeDict"
+
+// httpTransport is the transport of the HTTP clients of the provided sources,
+// which tests can replace with a stub (nil uses http.DefaultTransport)
+var httpTransport http.RoundTripper
+
+// pairFlagName is the name of the flag for the dictionary's language pair
+const pairFlagName = "freedict-pair"
+
+// envVars is the list of environment variables that the configuration reads
+// its values from
+var envVars = []registry.EnvVar{
+	{Name: "DEFINE_FREEDICT_PAIR", Key: "Pair", Aliases: []string{"FREEDICT_PAIR"}},
+}
+
+func init() {
+	registry.Register(registry.RegisterFunc(register))
+}
+
+func register(flags *flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
+	return &provider{}, initConfig(flags)
+}
+
+func initConfig(flags *flag.FlagSet) *config {
+	conf := &config{}
+
+	// Define our flags
+	flags.StringVar(&conf.Pair, pairFlagName, "", fmt.Sprintf("The language pair (such as \"eng-fra\") of the dictionary for the %s source", Name))
+
+	return conf
+}
+
+func (e *RequiredConfigError) Error() string {
+	return fmt.Sprintf("required configuration key %q is missing", e.Key)
+}
+
+func (c *config) JSONKey() string {
+	return JSONKey
+}
+
+// UnmarshalJSON defines how the configuration should be JSON unmarshalled.
+func (c *config) UnmarshalJSON(data []byte) error {
+	// Alias our type so that we can unmarshal as usual
+	type alias config
+	copy := &alias{}
+
+	// Unmarshal into our copy
+	err := json.Unmarshal(data, copy)
+
+	if nil != err {
+		return err
+	}
+
+	if "" == c.Pair {
+		c.Pair = copy.Pair
+	}
+
+	if nil == c.Headers {
+		c.Headers = copy.Headers
+	}
+
+	return nil
+}
+
+func (p *provider) Name() string {
+	return Name
+}
+
+func (p *provider) Metadata() registry.Metadata {
+	return registry.Metadata{
+		Description: "Free bilingual dictionaries between many pairs of languages",
+		Homepage:    "https://freedict.org/",
+		RequiredKeys: []registry.RequiredKey{
+			{Name: "Pair", FlagName: pairFlagName},
+		},
+		Capabilities: []string{registry.CapabilityTranslations, registry.CapabilityPronunciations, registry.CapabilityExamples},
+		EnvVars:      envVars,
+	}
+}
+
+func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
+	config := conf.(*config)
+
+	if "" == config.Pair {
+		return nil, &RequiredConfigError{Key: "Pair"}
+	}
+
+	return New(http.Client{Transport: transport.WithHeaders(httpTransport, config.Headers)}, config.Pair), nil
+}
@@ -0,0 +1,253 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package freelang provides a dictionary source via a local FreeLang
+// dictionary file
+package freelang
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Rican7/define/source"
+)
+
+// Name defines the name of the source
+const Name = "FreeLang Dictionary File"
+
+const (
+	// tabDelimiter is the delimiter between words in the tab-delimited format
+	tabDelimiter = "\t"
+
+	// equalsDelimiter is the delimiter between the source word and its
+	// equivalents in the bracket-commented format
+	equalsDelimiter = "="
+
+	// equivalentsDelimiter is the delimiter between multiple equivalents
+	equivalentsDelimiter = ","
+
+	// commentLinePrefixes are the prefixes of lines to be ignored entirely
+	commentLinePrefixes = "#;"
+)
+
+// bracketCommentRegex is a regular expression for matching bracketed comments
+var bracketCommentRegex = regexp.MustCompile(`\[([^\]]*)\]`)
+
+// dictionary is a struct containing a lazily loaded FreeLang dictionary
+type dictionary struct {
+	filePath string
+
+	load    sync.Once
+	loadErr error
+	index   map[string]*indexEntry
+}
+
+// indexEntry is a struct that defines an entry in the dictionary's index
+type indexEntry struct {
+	word        string
+	equivalents []equivalent
+}
+
+// equivalent is a struct that defines a target language equivalent of a word
+type equivalent struct {
+	text  string
+	notes []string
+}
+
+// freelangEntry is a struct that contains the entry types for this source
+type freelangEntry struct {
+	source.WordEntryValue
+	source.DictionaryEntryValue
+}
+
+// New returns a new FreeLang dictionary source for the file at the given path
+func New(filePath string) source.Source {
+	return &dictionary{filePath: filePath}
+}
+
+// Name returns the name of the source
+func (d *dictionary) Name() string {
+	return Name
+}
+
+// Define takes a word string and returns a dictionary source.Result
+func (d *dictionary) Define(word string) (source.Result, error) {
+	if err := d.loadIndex(); nil != err {
+		return nil, err
+	}
+
+	entry, ok := d.index[normalizeWord(word)]
+
+	if !ok {
+		return nil, &source.EmptyResultError{Word: word}
+	}
+
+	return source.ValidateAndReturnResult(entry.toResult())
+}
+
+// Match takes a regular expression pattern and returns the results of every
+// word in the dictionary that matches it, sorted by word
+func (d *dictionary) Match(pattern string) ([]source.Result, error) {
+	regex, err := regexp.Compile(pattern)
+
+	if nil != err {
+		return nil, err
+	}
+
+	if err = d.loadIndex(); nil != err {
+		return nil, err
+	}
+
+	var matches []*indexEntry
+
+	for _, entry := range d.index {
+		if regex.MatchString(entry.word) {
+			matches = append(matches, entry)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].word < matches[j].word
+	})
+
+	results := make([]source.Result, 0, len(matches))
+
+	for _, entry := range matches {
+		results = append(results, entry.toResult())
+	}
+
+	return results, nil
+}
+
+// loadIndex loads the dictionary file into the in-memory index, only once
+func (d *dictionary) loadIndex() error {
+	d.load.Do(func() {
+		file, err := os.Open(d.filePath)
+
+		if nil != err {
+			d.loadErr = err
+			return
+		}
+
+		defer file.Close()
+
+		d.index, d.loadErr = parse(file)
+	})
+
+	return d.loadErr
+}
+
+// parse parses FreeLang dictionary data into an index of normalized source
+// words to their entries.
+//
+// Two line formats are supported, and may be mixed:
+//
+//   - Tab-delimited: "<word>\t<equivalent>[\t<equivalent>...]"
+//   - Bracket-commented: "<word> [<comment>] = <equivalent> [<comment>], ..."
+//
+// Bracketed comments are kept as notes on the equivalent they follow. Blank
+// lines and lines starting with "#" or ";" are ignored.
+func parse(reader io.Reader) (map[string]*indexEntry, error) {
+	index := make(map[string]*indexEntry)
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if "" == line || strings.ContainsAny(line[:1], commentLinePrefixes) {
+			continue
+		}
+
+		var word string
+		var equivalentStrings []string
+
+		if strings.Contains(line, tabDelimiter) {
+			parts := strings.Split(line, tabDelimiter)
+
+			word, equivalentStrings = parts[0], parts[1:]
+		} else if strings.Contains(line, equalsDelimiter) {
+			parts := strings.SplitN(line, equalsDelimiter, 2)
+
+			word, equivalentStrings = parts[0], strings.Split(parts[1], equivalentsDelimiter)
+		} else {
+			// Not a recognizable entry line
+			continue
+		}
+
+		// Comments on the source word itself aren't useful to keep
+		word = strings.TrimSpace(bracketCommentRegex.ReplaceAllString(word, ""))
+
+		if "" == word {
+			continue
+		}
+
+		var equivalents []equivalent
+
+		for _, equivalentString := range equivalentStrings {
+			if equiv := parseEquivalent(equivalentString); "" != equiv.text {
+				equivalents = append(equivalents, equiv)
+			}
+		}
+
+		// Lines without any equivalents aren't entries
+		if len(equivalents) < 1 {
+			continue
+		}
+
+		key := normalizeWord(word)
+		entry, exists := index[key]
+
+		if !exists {
+			entry = &indexEntry{word: word}
+			index[key] = entry
+		}
+
+		entry.equivalents = append(entry.equivalents, equivalents...)
+	}
+
+	return index, scanner.Err()
+}
+
+// parseEquivalent parses a raw equivalent string, separating its comments
+func parseEquivalent(raw string) equivalent {
+	var notes []string
+
+	for _, match := range bracketCommentRegex.FindAllStringSubmatch(raw, -1) {
+		if note := strings.TrimSpace(match[1]); "" != note {
+			notes = append(notes, note)
+		}
+	}
+
+	return equivalent{
+		text:  strings.TrimSpace(bracketCommentRegex.ReplaceAllString(raw, "")),
+		notes: notes,
+	}
+}
+
+// normalizeWord normalizes a word for use as an index key
+func normalizeWord(word string) string {
+	return strings.ToLower(strings.TrimSpace(word))
+}
+
+// toResult converts the index entry to a generic source.Result
+func (e *indexEntry) toResult() source.Result {
+	entry := freelangEntry{}
+
+	entry.WordVal = e.word
+
+	for _, equiv := range e.equivalents {
+		entry.SenseVals = append(entry.SenseVals, source.SenseValue{
+			DefinitionVals: []string{equiv.text},
+			NoteVals:       equiv.notes,
+		})
+	}
+
+	return source.ResultValue{
+		Head:      e.word,
+		EntryVals: []interface{}{entry},
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package freelang
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+func TestParseTabDelimited(t *testing.T) {
+	index, err := parse(strings.NewReader("hello\tbonjour\tsalut\nCat\tchat [m]\n"))
+
+	if nil != err {
+		t.Fatalf("parse returned error %q", err)
+	}
+
+	want := map[string]*indexEntry{
+		"hello": {word: "hello", equivalents: []equivalent{{text: "bonjour"}, {text: "salut"}}},
+		"cat":   {word: "Cat", equivalents: []equivalent{{text: "chat", notes: []string{"m"}}}},
+	}
+
+	if !reflect.DeepEqual(want, index) {
+		t.Errorf("parse returned %+v, want %+v", index, want)
+	}
+}
+
+func TestParseBracketCommented(t *testing.T) {
+	index, err := parse(strings.NewReader("bank [finance] = banque [f], rive [of a river] [f]\nhouse = maison\n"))
+
+	if nil != err {
+		t.Fatalf("parse returned error %q", err)
+	}
+
+	want := map[string]*indexEntry{
+		"bank": {word: "bank", equivalents: []equivalent{
+			{text: "banque", notes: []string{"f"}},
+			{text: "rive", notes: []string{"of a river", "f"}},
+		}},
+		"house": {word: "house", equivalents: []equivalent{{text: "maison"}}},
+	}
+
+	if !reflect.DeepEqual(want, index) {
+		t.Errorf("parse returned %+v, want %+v", index, want)
+	}
+}
+
+func TestParseMixedAndMalformedLines(t *testing.T) {
+	data := strings.Join([]string{
+		"# A comment",
+		"; Another comment",
+		"",
+		"   ",
+		"no delimiter at all",
+		" = no word",
+		"[only a comment] = nothing",
+		"\tno word either",
+		"empty =  , [note only] ,",
+		"dog\tchien",
+		"Dog = toutou [informal]",
+	}, "\n")
+
+	index, err := parse(strings.NewReader(data))
+
+	if nil != err {
+		t.Fatalf("parse returned error %q", err)
+	}
+
+	want := map[string]*indexEntry{
+		"dog": {word: "dog", equivalents: []equivalent{
+			{text: "chien"},
+			{text: "toutou", notes: []string{"informal"}},
+		}},
+	}
+
+	if !reflect.DeepEqual(want, index) {
+		t.Errorf("parse returned %+v, want %+v", index, want)
+	}
+}
+
+func TestDefine(t *testing.T) {
+	src := &dictionary{}
+	src.index, _ = parse(strings.NewReader("hello\tbonjour [informal]\n"))
+	src.load.Do(func() {})
+
+	result, err := src.Define("Hello")
+
+	if nil != err {
+		t.Fatalf("Define returned error %q", err)
+	}
+
+	senses := result.Entries()[0].(source.DictionaryEntry).Senses()
+
+	if 1 != len(senses) || !reflect.DeepEqual([]string{"bonjour"}, senses[0].Definitions()) || !reflect.DeepEqual([]string{"informal"}, senses[0].Notes()) {
+		t.Errorf("Define returned the senses %+v", senses)
+	}
+
+	if _, err := src.Define("goodbye"); nil == err {
+		t.Error("Define of an unknown word didn't return an error")
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package freelang
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	flag "github.com/ogier/pflag"
+
+	"github.com/Rican7/define/registry"
+	"github.com/Rican7/define/source"
+)
+
+// RequiredConfigError represents an error when a required configuration key is
+// missing or invalid.
+type RequiredConfigError struct {
+	Key string
+}
+
+type config struct {
+	FilePath string
+}
+
+type provider struct{}
+
+// JSONKey defines the JSON key used for the provider
+const JSONKey = "FreeLangDictionary"
+
+// fileFlagName is the name of the flag for the dictionary file's path
+const fileFlagName = "freelang-file"
+
+// envVars is the list of environment variables that the configuration reads
+// its values from
+var envVars = []registry.EnvVar{
+	{Name: "DEFINE_FREELANG_FILE", Key: "FilePath", Aliases: []string{"FREELANG_DICTIONARY_FILE"}},
+}
+
+func init() {
+	registry.Register(registry.RegisterFunc(register))
+}
+
+func register(flags *flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
+	return &provider{}, initConfig(flags)
+}
+
+func initConfig(flags *flag.FlagSet) *config {
+	conf := &config{}
+
+	// Define our flags
+	flags.StringVar(&conf.FilePath, fileFlagName, "", fmt.Sprintf("The path of the dictionary file for the %s source", Name))
+
+	return conf
+}
+
+func (e *RequiredConfigError) Error() string {
+	return fmt.Sprintf("required configuration key %q is missing", e.Key)
+}
+
+func (c *config) JSONKey() string {
+	return JSONKey
+}
+
+// UnmarshalJSON defines how the configuration should be JSON unmarshalled.
+func (c *config) UnmarshalJSON(data []byte) error {
+	// Alias our type so that we can unmarshal as usual
+	type alias config
+	copy := &alias{}
+
+	// Unmarshal into our copy
+	err := json.Unmarshal(data, copy)
+
+	if nil != err {
+		return err
+	}
+
+	if "" == c.FilePath {
+		c.FilePath = copy.FilePath
+	}
+
+	return nil
+}
+
+func (p *provider) Name() string {
+	return Name
+}
+
+func (p *provider) Metadata() registry.Metadata {
+	return registry.Metadata{
+		Description: "Offline bilingual dictionaries, read from a downloaded dictionary file",
+		Homepage:    "https://www.freelang.net/dictionary/",
+		RequiredKeys: []registry.RequiredKey{
+			{Name: "FilePath", FlagName: fileFlagName},
+		},
+		Capabilities: []string{registry.CapabilityTranslations, registry.CapabilityOffline, registry.CapabilityRegex},
+		EnvVars:      envVars,
+		PathKeys:     []string{"FilePath"},
+	}
+}
+
+func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
+	config := conf.(*config)
+
+	if "" == config.FilePath {
+		return nil, &RequiredConfigError{Key: "FilePath"}
+	}
+
+	if _, err := os.Stat(config.FilePath); nil != err {
+		return nil, err
+	}
+
+	return New(config.FilePath), nil
+}
@@ -0,0 +1,42 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+// FrequencySource defines an interface for sources that know how frequently
+// words are used
+type FrequencySource interface {
+	Name() string
+
+	// Frequency returns the number of times that the word occurs per million
+	// words of the source's corpus, or an EmptyResultError if it's unknown
+	Frequency(word string) (float64, error)
+}
+
+// FrequencyBand defines a coarse band of how frequently a word is used
+type FrequencyBand string
+
+// Frequency bands, from the most to the least frequent
+const (
+	FrequencyCommon   FrequencyBand = "common"
+	FrequencyUncommon FrequencyBand = "uncommon"
+	FrequencyRare     FrequencyBand = "rare"
+)
+
+// Thresholds of the frequency bands, in occurrences per million words
+const (
+	commonThreshold   = 50
+	uncommonThreshold = 1
+)
+
+// BandOf returns the frequency band of the given number of occurrences per
+// million words
+func BandOf(perMillion float64) FrequencyBand {
+	switch {
+	case perMillion >= commonThreshold:
+		return FrequencyCommon
+	case perMillion >= uncommonThreshold:
+		return FrequencyUncommon
+	default:
+		return FrequencyRare
+	}
+}
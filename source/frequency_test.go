@@ -0,0 +1,24 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+import (
+	"testing"
+)
+
+func TestBandOf(t *testing.T) {
+	testData := map[float64]FrequencyBand{
+		5000: FrequencyCommon,
+		50:   FrequencyCommon,
+		49.9: FrequencyUncommon,
+		1:    FrequencyUncommon,
+		0.5:  FrequencyRare,
+		0:    FrequencyRare,
+	}
+
+	for perMillion, want := range testData {
+		if got := BandOf(perMillion); want != got {
+			t.Errorf("BandOf(%v) returned %q, want %q", perMillion, got, want)
+		}
+	}
+}
@@ -4,14 +4,12 @@
 package glosbe
 
 import (
-	"encoding/json"
-	"io/ioutil"
+	"context"
 	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/Rican7/define/source"
-	"github.com/microcosm-cc/bluemonday"
 )
 
 // Name defines the name of the source
@@ -21,6 +19,9 @@ const (
 	// baseURLString is the base URL for all Glosbe API interactions
 	baseURLString = "https://glosbe.com/gapi/translate?format=json&from=en&dest=en"
 
+	// webURLString is the base URL of the Glosbe web dictionary's entries
+	webURLString = "https://glosbe.com/en/en/"
+
 	// wordParameter defines the HTTP parameter for the word to define
 	wordParameter = "phrase"
 
@@ -34,9 +35,6 @@ var apiURL *url.URL
 // validMIMETypes is the list of valid response MIME types
 var validMIMETypes = []string{jsonMIMEType}
 
-// htmlCleaner is used to clean the strings returned from the API
-var htmlCleaner = bluemonday.StrictPolicy()
-
 // stringCleaner is used to clean the strings returned from the API
 var stringCleaner *strings.Replacer
 
@@ -50,6 +48,7 @@ var itemsToClean = []string{
 // api is a struct containing a configured HTTP client for Glosbe API operations
 type api struct {
 	httpClient *http.Client
+	keepHTML   bool
 }
 
 // apiResult is a struct that defines the data structure for Glosbe API results
@@ -95,9 +94,10 @@ func init() {
 	stringCleaner = strings.NewReplacer(stringCleanerPairs...)
 }
 
-// New returns a new Glosbe API dictionary source
-func New(httpClient http.Client) source.Source {
-	return &api{&httpClient}
+// New returns a new Glosbe API dictionary source. The HTML markup that the API
+// embeds in its text is converted to plain text, unless keepHTML is true.
+func New(httpClient http.Client, keepHTML bool) source.Source {
+	return &api{&httpClient, keepHTML}
 }
 
 // Name returns the name of the source
@@ -105,14 +105,29 @@ func (g *api) Name() string {
 	return Name
 }
 
-// Define takes a word string and returns a dictionary source.Result
-func (g *api) Define(word string) (source.Result, error) {
+// Options returns the options that the source's results depend on
+func (g *api) Options() source.Options {
+	if g.keepHTML {
+		return source.Options{Mode: "html"}
+	}
+
+	return source.Options{}
+}
+
+// WebURL returns the URL of the web page for the given word
+func (g *api) WebURL(word string) string {
+	return webURLString + url.PathEscape(word)
+}
+
+// Request returns the HTTP request used to define the given word
+func (g *api) Request(word string) (*http.Request, error) {
 	// Prepare our URL
-	queryParams := apiURL.Query()
+	requestURL := *apiURL
+	queryParams := requestURL.Query()
 	queryParams.Set(wordParameter, word)
-	apiURL.RawQuery = queryParams.Encode()
+	requestURL.RawQuery = queryParams.Encode()
 
-	httpRequest, err := http.NewRequest(http.MethodGet, apiURL.String(), nil)
+	httpRequest, err := http.NewRequest(http.MethodGet, requestURL.String(), nil)
 
 	if nil != err {
 		return nil, err
@@ -120,25 +135,36 @@ func (g *api) Define(word string) (source.Result, error) {
 
 	httpRequest.Header.Set(httpRequestAcceptHeaderName, jsonMIMEType)
 
-	httpResponse, err := g.httpClient.Do(httpRequest)
+	return httpRequest, nil
+}
+
+// Define takes a word string and returns a dictionary source.Result
+func (g *api) Define(word string) (source.Result, error) {
+	return g.DefineContext(context.Background(), word)
+}
+
+// DefineContext takes a word string and returns a dictionary source.Result,
+// cancelling its request if the context is done before it finishes
+func (g *api) DefineContext(ctx context.Context, word string) (source.Result, error) {
+	httpRequest, err := g.Request(word)
 
 	if nil != err {
 		return nil, err
 	}
 
-	if err = source.ValidateHTTPResponse(httpResponse, validMIMETypes, nil); nil != err {
+	httpResponse, err := g.httpClient.Do(httpRequest.WithContext(ctx))
+
+	if nil != err {
 		return nil, err
 	}
 
-	body, err := ioutil.ReadAll(httpResponse.Body)
-
-	if nil != err {
+	if err = source.ValidateHTTPResponse(httpResponse, validMIMETypes, nil); nil != err {
 		return nil, err
 	}
 
 	var result apiResult
 
-	if err = json.Unmarshal(body, &result); nil != err {
+	if err = source.ReadJSONResponse(Name, httpResponse, &result); nil != err {
 		return nil, err
 	}
 
@@ -146,11 +172,12 @@ func (g *api) Define(word string) (source.Result, error) {
 		return nil, &source.EmptyResultError{Word: word}
 	}
 
-	return source.ValidateAndReturnResult(result.toResult())
+	return source.ValidateAndReturnResult(result.toResult(g.keepHTML))
 }
 
-// toResult converts the proprietary API result to a generic source.Result
-func (r apiResult) toResult() source.Result {
+// toResult converts the proprietary API result to a generic source.Result,
+// keeping the HTML markup of its text if keepHTML is true
+func (r apiResult) toResult(keepHTML bool) source.Result {
 	entry := glosbeEntry{
 		source.DictionaryEntryValue{},
 		source.ThesaurusEntryValue{},
@@ -163,7 +190,7 @@ func (r apiResult) toResult() source.Result {
 		// phrase, or their phrase matches the looked-up phrase
 		if nil == item.Phrase || strings.EqualFold(item.Phrase.Text, r.Phrase) {
 			for _, meaning := range item.Meanings {
-				definition := sanitize(meaning.Text)
+				definition := sanitize(meaning.Text, keepHTML)
 
 				sense := source.SenseValue{DefinitionVals: []string{definition}}
 
@@ -183,9 +210,13 @@ func (r apiResult) toResult() source.Result {
 	}
 }
 
-// sanitize cleans a string of any formatting identifiers or markup
-func sanitize(str string) string {
-	str = htmlCleaner.Sanitize(str)
+// sanitize cleans a string of any formatting identifiers, and of any HTML
+// markup unless keepHTML is true
+func sanitize(str string, keepHTML bool) string {
+	if !keepHTML {
+		str = htmlToText(str)
+	}
+
 	str = stringCleaner.Replace(str)
 
 	return str
@@ -0,0 +1,120 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package glosbe
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+// stubTransport is an http.RoundTripper that responds to every request with
+// the same canned response
+type stubTransport struct {
+	statusCode  int
+	contentType string
+	body        string
+}
+
+func (t *stubTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode:    t.statusCode,
+		Header:        http.Header{"Content-Type": []string{t.contentType}},
+		Body:          ioutil.NopCloser(strings.NewReader(t.body)),
+		ContentLength: int64(len(t.body)),
+		Request:       request,
+	}, nil
+}
+
+func TestDefine(t *testing.T) {
+	testData := []struct {
+		name            string
+		transport       *stubTransport
+		wantDefinitions []string
+		wantSynonyms    []string
+		wantErr         interface{}
+	}{
+		{
+			name: "success",
+			transport: &stubTransport{http.StatusOK, "application/json", `{
+				"result": "ok",
+				"phrase": "hello",
+				"dest": "en",
+				"tuc": [
+					{"meanings": [{"language": "en", "text": "a [i]greeting[/i] <b>said</b> &amp; written"}]},
+					{"phrase": {"language": "en", "text": "Hello"}, "meanings": [{"language": "en", "text": "an expression of surprise"}]},
+					{"phrase": {"language": "en", "text": "hi"}}
+				]
+			}`},
+			wantDefinitions: []string{"a greeting said & written", "an expression of surprise"},
+			wantSynonyms:    []string{"hi"},
+		},
+		{
+			name:      "empty",
+			transport: &stubTransport{http.StatusOK, "application/json", `{"result": "ok", "phrase": "hello", "tuc": []}`},
+			wantErr:   &source.EmptyResultError{},
+		},
+		{
+			name:      "malformed JSON",
+			transport: &stubTransport{http.StatusOK, "application/json", `{"result": "ok", "tuc": [}`},
+			wantErr:   &json.SyntaxError{},
+		},
+		{
+			name:      "HTTP error",
+			transport: &stubTransport{http.StatusInternalServerError, "text/html", "<h1>Internal Server Error</h1>"},
+			wantErr:   &source.InvalidResponseError{},
+		},
+	}
+
+	defer func() { httpTransport = nil }()
+
+	for _, data := range testData {
+		httpTransport = data.transport
+
+		src, err := (&provider{}).Provide(&config{})
+
+		if nil != err {
+			t.Fatalf("%s: Provide returned error %q", data.name, err)
+		}
+
+		result, err := src.Define("hello")
+
+		if nil != data.wantErr {
+			if nil == err || reflect.TypeOf(data.wantErr) != reflect.TypeOf(err) {
+				t.Errorf("%s: Define returned error %#v, want a %T", data.name, err, data.wantErr)
+			}
+
+			continue
+		}
+
+		if nil != err {
+			t.Errorf("%s: Define returned error %q", data.name, err)
+			continue
+		}
+
+		if "hello" != result.Headword() {
+			t.Errorf("%s: Define returned headword %q, want %q", data.name, result.Headword(), "hello")
+		}
+
+		entry := result.Entries()[0]
+
+		var definitions []string
+
+		for _, sense := range entry.Senses() {
+			definitions = append(definitions, sense.Definitions()...)
+		}
+
+		if !reflect.DeepEqual(data.wantDefinitions, definitions) {
+			t.Errorf("%s: Define returned definitions %q, want %q", data.name, definitions, data.wantDefinitions)
+		}
+
+		if synonyms := entry.(source.ThesaurusEntry).Synonyms(); !reflect.DeepEqual(data.wantSynonyms, synonyms) {
+			t.Errorf("%s: Define returned synonyms %q, want %q", data.name, synonyms, data.wantSynonyms)
+		}
+	}
+}
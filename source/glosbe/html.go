@@ -0,0 +1,113 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package glosbe
+
+import (
+	"bytes"
+	"html"
+	"strings"
+)
+
+// spacingTags are the (lowercase) names of the HTML tags that separate the
+// text on either side of them, such as line breaks and block-level elements
+var spacingTags = map[string]bool{
+	"br":  true,
+	"hr":  true,
+	"p":   true,
+	"div": true,
+	"li":  true,
+	"ul":  true,
+	"ol":  true,
+	"tr":  true,
+	"td":  true,
+}
+
+// htmlToText converts HTML markup into plain text, by removing its tags and
+// unescaping its entities. The markup is tokenized, rather than matched, so
+// that nested and unclosed tags are removed safely, while a "<" that doesn't
+// begin a complete tag (such as in "a < b") is kept as text.
+func htmlToText(markup string) string {
+	var text bytes.Buffer
+
+	for i := 0; i < len(markup); {
+		tagEnd := -1
+
+		if '<' == markup[i] {
+			tagEnd = findTagEnd(markup, i)
+		}
+
+		if -1 == tagEnd {
+			text.WriteByte(markup[i])
+			i++
+
+			continue
+		}
+
+		if spacingTags[tagName(markup[i+1:tagEnd])] {
+			text.WriteByte(' ')
+		}
+
+		i = tagEnd + 1
+	}
+
+	// Entities are only unescaped once the tags are removed, so that escaped
+	// markup (such as "&lt;b&gt;") is kept as text
+	return strings.Join(strings.Fields(html.UnescapeString(text.String())), " ")
+}
+
+// findTagEnd returns the index of the ">" that ends the tag (or comment)
+// beginning at the given index of the markup, or -1 if there's no complete
+// tag there
+func findTagEnd(markup string, start int) int {
+	rest := markup[start+1:]
+
+	if strings.HasPrefix(rest, "!--") {
+		if end := strings.Index(rest[3:], "-->"); -1 != end {
+			return start + 1 + 3 + end + 2
+		}
+
+		return -1
+	}
+
+	if "" == rest || !isTagStart(rest[0]) {
+		return -1
+	}
+
+	var quote byte
+
+	for i := 1; i < len(rest); i++ {
+		switch c := rest[i]; {
+		case 0 != quote:
+			if c == quote {
+				quote = 0
+			}
+		case '"' == c || '\'' == c:
+			quote = c
+		case '>' == c:
+			return start + 1 + i
+		case '<' == c:
+			// A tag can't contain another, so this one is unclosed
+			return -1
+		}
+	}
+
+	return -1
+}
+
+// isTagStart returns whether a character can begin the contents of a tag
+func isTagStart(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || '/' == c || '!' == c
+}
+
+// tagName returns the lowercase name of a tag, given its contents
+func tagName(contents string) string {
+	contents = strings.TrimPrefix(contents, "/")
+
+	end := strings.IndexAny(contents, " \t\r\n/")
+
+	if -1 != end {
+		contents = contents[:end]
+	}
+
+	return strings.ToLower(contents)
+}
@@ -0,0 +1,34 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package glosbe
+
+import (
+	"testing"
+)
+
+func TestHTMLToText(t *testing.T) {
+	testData := []struct {
+		markup string
+		want   string
+	}{
+		{"plain text", "plain text"},
+		{"an <i>emphasized</i> <b>word</b>", "an emphasized word"},
+		{"<b><i>nested</b></i> tags", "nested tags"},
+		{"an <i>unclosed tag", "an unclosed tag"},
+		{"salt &amp; pepper &lt;b&gt; &quot;q&quot;", "salt & pepper <b> \"q\""},
+		{"one<br>two<br/>three", "one two three"},
+		{"<p>first</p><p>second</p>", "first second"},
+		{"<a href=\"x>y\" title='>'>link</a>", "link"},
+		{"a < b and c > d", "a < b and c > d"},
+		{"an incomplete <b", "an incomplete <b"},
+		{"a <b <i>broken</i> tag", "a <b broken tag"},
+		{"a <!-- hidden <b> --> comment", "a comment"},
+		{"  extra \n whitespace  ", "extra whitespace"},
+	}
+
+	for _, data := range testData {
+		if got := htmlToText(data.markup); data.want != got {
+			t.Errorf("htmlToText(%q) returned %q, want %q", data.markup, got, data.want)
+		}
+	}
+}
@@ -3,37 +3,90 @@
 package glosbe
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 
 	flag "github.com/ogier/pflag"
 
+	"github.com/Rican7/define/internal/transport"
 	"github.com/Rican7/define/registry"
 	"github.com/Rican7/define/source"
 )
 
-type config struct{}
+type config struct {
+	KeepHTML bool
+	Headers  map[string]string
+}
 
 type provider struct{}
 
 // JSONKey defines the JSON key used for the provider
 const JSONKey = "GlosbeAPI"
 
+// httpTransport is the transport of the HTTP clients of the provided sources,
+// which tests can replace with a stub (nil uses http.DefaultTransport)
+var httpTransport http.RoundTripper
+
 func init() {
 	registry.Register(registry.RegisterFunc(register))
 }
 
-func register(*flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
-	return &provider{}, &config{}
+func register(flags *flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
+	return &provider{}, initConfig(flags)
+}
+
+func initConfig(flags *flag.FlagSet) *config {
+	conf := &config{}
+
+	// Define our flags
+	flags.BoolVar(&conf.KeepHTML, "glosbe-keep-html", false, fmt.Sprintf("To keep the HTML markup of the %s's definitions, rather than converting it to plain text", Name))
+
+	return conf
 }
 
 func (c *config) JSONKey() string {
 	return JSONKey
 }
 
+// UnmarshalJSON defines how the configuration should be JSON unmarshalled.
+func (c *config) UnmarshalJSON(data []byte) error {
+	// Alias our type so that we can unmarshal as usual
+	type alias config
+	copy := &alias{}
+
+	// Unmarshal into our copy
+	err := json.Unmarshal(data, copy)
+
+	if nil != err {
+		return err
+	}
+
+	if !c.KeepHTML {
+		c.KeepHTML = copy.KeepHTML
+	}
+
+	if nil == c.Headers {
+		c.Headers = copy.Headers
+	}
+
+	return nil
+}
+
 func (p *provider) Name() string {
 	return Name
 }
 
+func (p *provider) Metadata() registry.Metadata {
+	return registry.Metadata{
+		Description:  "A multilingual dictionary of translations and their examples",
+		Homepage:     "https://glosbe.com/",
+		Capabilities: []string{registry.CapabilityTranslations, registry.CapabilityThesaurus},
+	}
+}
+
 func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
-	return New(http.Client{}), nil
+	config := conf.(*config)
+
+	return New(http.Client{Transport: transport.WithHeaders(httpTransport, config.Headers)}, config.KeepHTML), nil
 }
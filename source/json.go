@@ -0,0 +1,204 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+import (
+	"encoding/json"
+)
+
+// jsonResult defines the JSON representation of a Result
+type jsonResult struct {
+	Headword string      `json:"headword"`
+	Language string      `json:"language,omitempty"`
+	Entries  []jsonEntry `json:"entries"`
+}
+
+// jsonEntry defines the JSON representation of an entry of a Result
+type jsonEntry struct {
+	Word           string              `json:"word,omitempty"`
+	Language       string              `json:"language,omitempty"`
+	Category       string              `json:"category,omitempty"`
+	Pronunciation  string              `json:"pronunciation,omitempty"`
+	Pronunciations []jsonPronunciation `json:"pronunciations,omitempty"`
+	Senses         []jsonSense         `json:"senses,omitempty"`
+	Etymologies    []string            `json:"etymologies,omitempty"`
+	Synonyms       []string            `json:"synonyms,omitempty"`
+	Antonyms       []string            `json:"antonyms,omitempty"`
+	Inflections    []string            `json:"inflections,omitempty"`
+}
+
+// jsonPronunciation defines the JSON representation of a RegionalPronunciation
+type jsonPronunciation struct {
+	Transcription string `json:"transcription"`
+	Region        string `json:"region,omitempty"`
+}
+
+// jsonSense defines the JSON representation of a Sense
+type jsonSense struct {
+	Definitions    []string            `json:"definitions,omitempty"`
+	Examples       []string            `json:"examples,omitempty"`
+	Notes          []string            `json:"notes,omitempty"`
+	Pronunciations []jsonPronunciation `json:"pronunciations,omitempty"`
+	Subsenses      []jsonSense         `json:"subsenses,omitempty"`
+}
+
+// MarshalResultJSON returns the JSON encoding of a Result, including the data
+// of any of the optional entry types that its entries implement.
+func MarshalResultJSON(result Result) ([]byte, error) {
+	return json.Marshal(newJSONResult(result))
+}
+
+// MarshalResultJSONIndent is like MarshalResultJSON, but applies the given
+// prefix and indentation to format the output.
+func MarshalResultJSONIndent(result Result, prefix, indent string) ([]byte, error) {
+	return json.MarshalIndent(newJSONResult(result), prefix, indent)
+}
+
+// UnmarshalResultJSON parses the JSON encoding of a Result, as encoded by
+// MarshalResultJSON, and returns the Result.
+func UnmarshalResultJSON(data []byte) (Result, error) {
+	var decoded jsonResult
+
+	if err := json.Unmarshal(data, &decoded); nil != err {
+		return nil, err
+	}
+
+	return decoded.toResult(), nil
+}
+
+// jsonEntryValue is the entry type of a Result parsed from JSON
+type jsonEntryValue struct {
+	EntryValue
+	LanguageEntryValue
+}
+
+// toResult converts the JSON representation back to a Result
+func (r jsonResult) toResult() Result {
+	result := ResultValue{
+		Head: r.Headword,
+		Lang: r.Language,
+	}
+
+	for _, entry := range r.Entries {
+		converted := jsonEntryValue{}
+
+		converted.WordVal = entry.Word
+		converted.CategoryVal = entry.Category
+		converted.PronunciationVal = entry.Pronunciation
+		converted.PronunciationVals = toRegionalPronunciations(entry.Pronunciations)
+		converted.SenseVals = toSenseValues(entry.Senses)
+		converted.EtymologyVals = entry.Etymologies
+		converted.SynonymVals = entry.Synonyms
+		converted.AntonymVals = entry.Antonyms
+		converted.InflectionVals = entry.Inflections
+		converted.LanguageVal = entry.Language
+
+		result.EntryVals = append(result.EntryVals, converted)
+	}
+
+	return result
+}
+
+// toRegionalPronunciations converts JSON pronunciation representations back to
+// RegionalPronunciations
+func toRegionalPronunciations(pronunciations []jsonPronunciation) []RegionalPronunciation {
+	var converted []RegionalPronunciation
+
+	for _, pronunciation := range pronunciations {
+		converted = append(converted, RegionalPronunciation(pronunciation))
+	}
+
+	return converted
+}
+
+// toSenseValues converts JSON sense representations back to SenseValues
+func toSenseValues(senses []jsonSense) []SenseValue {
+	var converted []SenseValue
+
+	for _, sense := range senses {
+		converted = append(converted, SenseValue{
+			DefinitionVals:    sense.Definitions,
+			ExampleVals:       sense.Examples,
+			NoteVals:          sense.Notes,
+			PronunciationVals: toRegionalPronunciations(sense.Pronunciations),
+			SubsenseVals:      toSenseValues(sense.Subsenses),
+		})
+	}
+
+	return converted
+}
+
+// newJSONResult converts a Result to its JSON representation
+func newJSONResult(result Result) jsonResult {
+	entries := make([]jsonEntry, 0)
+
+	for _, entry := range result.Entries() {
+		entries = append(entries, newJSONEntry(entry))
+	}
+
+	return jsonResult{
+		Headword: result.Headword(),
+		Language: result.Language(),
+		Entries:  entries,
+	}
+}
+
+// newJSONEntry converts a DictionaryEntry to its JSON representation
+func newJSONEntry(entry DictionaryEntry) jsonEntry {
+	converted := jsonEntry{
+		Pronunciation: entry.Pronunciation(),
+		Senses:        newJSONSenses(entry.Senses()),
+	}
+
+	if wordEntry, ok := entry.(WordEntry); ok {
+		converted.Word = wordEntry.Word()
+		converted.Category = wordEntry.Category()
+	}
+
+	if languageEntry, ok := entry.(LanguageEntry); ok {
+		converted.Language = languageEntry.Language()
+	}
+
+	if etymologyEntry, ok := entry.(EtymologyEntry); ok {
+		converted.Etymologies = etymologyEntry.Etymologies()
+	}
+
+	if thesaurusEntry, ok := entry.(ThesaurusEntry); ok {
+		converted.Synonyms = thesaurusEntry.Synonyms()
+		converted.Antonyms = thesaurusEntry.Antonyms()
+	}
+
+	if inflectionEntry, ok := entry.(InflectionEntry); ok {
+		converted.Inflections = inflectionEntry.Inflections()
+	}
+
+	if pronunciationEntry, ok := entry.(PronunciationEntry); ok {
+		for _, pronunciation := range pronunciationEntry.Pronunciations() {
+			converted.Pronunciations = append(converted.Pronunciations, jsonPronunciation(pronunciation))
+		}
+	}
+
+	return converted
+}
+
+// newJSONSenses converts a list of Senses to their JSON representations
+func newJSONSenses(senses []Sense) []jsonSense {
+	var converted []jsonSense
+
+	for _, sense := range senses {
+		convertedSense := jsonSense{
+			Definitions: sense.Definitions(),
+			Examples:    sense.Examples(),
+			Notes:       sense.Notes(),
+			Subsenses:   newJSONSenses(sense.Subsenses()),
+		}
+
+		for _, pronunciation := range SensePronunciations(sense) {
+			convertedSense.Pronunciations = append(convertedSense.Pronunciations, jsonPronunciation(pronunciation))
+		}
+
+		converted = append(converted, convertedSense)
+	}
+
+	return converted
+}
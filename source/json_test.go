@@ -0,0 +1,156 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMarshalResultJSON(t *testing.T) {
+	result := ResultValue{
+		Head: "test",
+		Lang: "en",
+		EntryVals: []interface{}{
+			EntryValue{
+				WordEntryValue: WordEntryValue{WordVal: "test", CategoryVal: "noun"},
+				DictionaryEntryValue: DictionaryEntryValue{
+					PronunciationVal: "tɛst",
+					SenseVals: []SenseValue{
+						{
+							DefinitionVals: []string{"a procedure"},
+							ExampleVals:    []string{"a test"},
+							SubsenseVals:   []SenseValue{{DefinitionVals: []string{"an exam"}}},
+						},
+					},
+				},
+				EtymologyEntryValue: EtymologyEntryValue{EtymologyVals: []string{"Latin"}},
+				ThesaurusEntryValue: ThesaurusEntryValue{SynonymVals: []string{"trial"}},
+			},
+			DictionaryEntryValue{PronunciationVal: "tɛst"},
+		},
+	}
+
+	encoded, err := MarshalResultJSON(result)
+
+	if nil != err {
+		t.Fatalf("MarshalResultJSON returned an error: %#v", err)
+	}
+
+	var got map[string]interface{}
+	var want map[string]interface{}
+
+	json.Unmarshal(encoded, &got)
+	json.Unmarshal([]byte(`{
+		"headword": "test",
+		"language": "en",
+		"entries": [
+			{
+				"word": "test",
+				"category": "noun",
+				"pronunciation": "tɛst",
+				"senses": [
+					{
+						"definitions": ["a procedure"],
+						"examples": ["a test"],
+						"subsenses": [{"definitions": ["an exam"]}]
+					}
+				],
+				"etymologies": ["Latin"],
+				"synonyms": ["trial"]
+			},
+			{
+				"pronunciation": "tɛst"
+			}
+		]
+	}`), &want)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MarshalResultJSON returned wrong value. Got %s. Want %v.", encoded, want)
+	}
+}
+
+func TestUnmarshalResultJSON(t *testing.T) {
+	encoded := []byte(`{
+		"headword": "test",
+		"language": "en",
+		"entries": [
+			{
+				"word": "test",
+				"language": "English",
+				"category": "noun",
+				"pronunciations": [{"transcription": "tɛst", "region": "British English"}],
+				"senses": [
+					{
+						"definitions": ["a procedure"],
+						"pronunciations": [{"transcription": "tɛst", "region": "US"}],
+						"subsenses": [{"definitions": ["an exam"]}]
+					}
+				],
+				"synonyms": ["trial"]
+			}
+		]
+	}`)
+
+	result, err := UnmarshalResultJSON(encoded)
+
+	if nil != err {
+		t.Fatalf("UnmarshalResultJSON returned an error: %#v", err)
+	}
+
+	if "test" != result.Headword() || "en" != result.Language() || 1 != len(result.Entries()) {
+		t.Fatalf("UnmarshalResultJSON returned %#v", result)
+	}
+
+	entry := result.Entries()[0]
+
+	if wordEntry, ok := entry.(WordEntry); !ok || "noun" != wordEntry.Category() {
+		t.Errorf("entry isn't a WordEntry with the category %q", "noun")
+	}
+
+	if languageEntry, ok := entry.(LanguageEntry); !ok || "English" != languageEntry.Language() {
+		t.Errorf("entry isn't a LanguageEntry with the language %q", "English")
+	}
+
+	if thesaurusEntry, ok := entry.(ThesaurusEntry); !ok || !reflect.DeepEqual([]string{"trial"}, thesaurusEntry.Synonyms()) {
+		t.Errorf("entry isn't a ThesaurusEntry with the synonyms %q", []string{"trial"})
+	}
+
+	wantPronunciations := []RegionalPronunciation{{Transcription: "tɛst", Region: "British English"}}
+
+	if pronunciationEntry, ok := entry.(PronunciationEntry); !ok || !reflect.DeepEqual(wantPronunciations, pronunciationEntry.Pronunciations()) {
+		t.Errorf("entry isn't a PronunciationEntry with the pronunciations %v", wantPronunciations)
+	}
+
+	if got := SensePronunciations(entry.Senses()[0]); !reflect.DeepEqual([]RegionalPronunciation{{Transcription: "tɛst", Region: "US"}}, got) {
+		t.Errorf("sense pronunciations are %v", got)
+	}
+
+	if got := entry.Senses()[0].Subsenses()[0].Definitions(); !reflect.DeepEqual([]string{"an exam"}, got) {
+		t.Errorf("subsense definitions are %q, want %q", got, []string{"an exam"})
+	}
+
+	// Re-encoding should round-trip the document
+	reencoded, err := MarshalResultJSON(result)
+
+	if nil != err {
+		t.Fatalf("MarshalResultJSON returned an error: %#v", err)
+	}
+
+	var got map[string]interface{}
+	var want map[string]interface{}
+
+	json.Unmarshal(reencoded, &got)
+	json.Unmarshal(encoded, &want)
+
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("re-encoded JSON is %s", reencoded)
+	}
+}
+
+func TestUnmarshalResultJSONInvalid(t *testing.T) {
+	if _, err := UnmarshalResultJSON([]byte(`{"headword": 1}`)); nil == err {
+		t.Errorf("UnmarshalResultJSON didn't return an error for invalid JSON")
+	}
+}
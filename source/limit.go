@@ -0,0 +1,47 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+// LimitSenses returns a copy of the given result with at most perCategory
+// senses for each lexical category (across all of the result's entries), and
+// at most total senses overall, where a limit of 0 is no limit.
+//
+// Entries whose senses were all removed by the limits are removed as well.
+func LimitSenses(result Result, perCategory uint, total uint) Result {
+	if 0 == perCategory && 0 == total {
+		return result
+	}
+
+	limited := newJSONResult(result)
+	categoryCounts := make(map[string]uint)
+	entries := make([]jsonEntry, 0, len(limited.Entries))
+
+	var count uint
+
+	for _, entry := range limited.Entries {
+		// Group the same category in different languages separately
+		category := entry.Language + "\x00" + entry.Category
+		senses := make([]jsonSense, 0, len(entry.Senses))
+
+		for _, sense := range entry.Senses {
+			if (0 < perCategory && perCategory <= categoryCounts[category]) || (0 < total && total <= count) {
+				break
+			}
+
+			senses = append(senses, sense)
+			categoryCounts[category]++
+			count++
+		}
+
+		if 0 < len(entry.Senses) && 0 == len(senses) {
+			continue
+		}
+
+		entry.Senses = senses
+		entries = append(entries, entry)
+	}
+
+	limited.Entries = entries
+
+	return limited.toResult()
+}
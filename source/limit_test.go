@@ -0,0 +1,90 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newLimitTestResult() Result {
+	entry := func(category string, definitions ...string) EntryValue {
+		var senses []SenseValue
+
+		for _, definition := range definitions {
+			senses = append(senses, SenseValue{DefinitionVals: []string{definition}})
+		}
+
+		return EntryValue{
+			WordEntryValue:       WordEntryValue{WordVal: "run", CategoryVal: category},
+			DictionaryEntryValue: DictionaryEntryValue{SenseVals: senses},
+		}
+	}
+
+	return ResultValue{
+		Head: "run",
+		EntryVals: []interface{}{
+			entry("noun", "n1", "n2", "n3", "n4"),
+			entry("verb", "v1", "v2", "v3"),
+			entry("noun", "n5"),
+		},
+	}
+}
+
+// limitedDefinitions returns the first definition of each sense of each entry
+func limitedDefinitions(result Result) [][]string {
+	var definitions [][]string
+
+	for _, entry := range result.Entries() {
+		var entryDefinitions []string
+
+		for _, sense := range entry.Senses() {
+			entryDefinitions = append(entryDefinitions, sense.Definitions()[0])
+		}
+
+		definitions = append(definitions, entryDefinitions)
+	}
+
+	return definitions
+}
+
+func TestLimitSenses(t *testing.T) {
+	testData := []struct {
+		perCategory uint
+		total       uint
+		want        [][]string
+	}{
+		{0, 0, [][]string{{"n1", "n2", "n3", "n4"}, {"v1", "v2", "v3"}, {"n5"}}},
+		{2, 0, [][]string{{"n1", "n2"}, {"v1", "v2"}}},
+		{0, 5, [][]string{{"n1", "n2", "n3", "n4"}, {"v1"}}},
+		{2, 3, [][]string{{"n1", "n2"}, {"v1"}}},
+		{5, 0, [][]string{{"n1", "n2", "n3", "n4"}, {"v1", "v2", "v3"}, {"n5"}}},
+	}
+
+	for _, data := range testData {
+		got := limitedDefinitions(LimitSenses(newLimitTestResult(), data.perCategory, data.total))
+
+		if !reflect.DeepEqual(data.want, got) {
+			t.Errorf("LimitSenses(%d, %d) returned %q, want %q", data.perCategory, data.total, got, data.want)
+		}
+	}
+}
+
+func TestLimitSensesKeepsEntriesWithoutSenses(t *testing.T) {
+	result := ResultValue{
+		Head: "run",
+		EntryVals: []interface{}{
+			EntryValue{ThesaurusEntryValue: ThesaurusEntryValue{SynonymVals: []string{"sprint"}}},
+		},
+	}
+
+	limited := LimitSenses(result, 1, 1)
+
+	if 1 != len(limited.Entries()) {
+		t.Fatalf("LimitSenses returned %d entries, want 1", len(limited.Entries()))
+	}
+
+	if got := limited.Entries()[0].(ThesaurusEntry).Synonyms(); !reflect.DeepEqual([]string{"sprint"}, got) {
+		t.Errorf("LimitSenses returned the synonyms %q, want %q", got, []string{"sprint"})
+	}
+}
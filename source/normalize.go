@@ -0,0 +1,132 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Normalization defines how a word's capitalization is normalized before it's
+// looked up (and cached), so that lookups differing only by case are shared
+type Normalization string
+
+// The available normalizations
+const (
+	// NormalizationNone looks up words exactly as they're given
+	NormalizationNone Normalization = "none"
+
+	// NormalizationLower lowercases words
+	NormalizationLower Normalization = "lower"
+
+	// NormalizationSmart lowercases ordinary words, but preserves those that
+	// are likely proper nouns or acronyms (see NormalizeSmart)
+	NormalizationSmart Normalization = "smart"
+)
+
+// normalizations is the list of valid normalizations
+var normalizations = []Normalization{NormalizationNone, NormalizationLower, NormalizationSmart}
+
+// ParseNormalization parses a normalization by its name, where an empty name
+// is the same as NormalizationSmart.
+func ParseNormalization(name string) (Normalization, error) {
+	if "" == name {
+		return NormalizationSmart, nil
+	}
+
+	for _, normalization := range normalizations {
+		if strings.EqualFold(name, string(normalization)) {
+			return normalization, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid normalization %q (must be one of %q)", name, normalizations)
+}
+
+// Apply applies the normalization to a word.
+func (n Normalization) Apply(word string) string {
+	switch n {
+	case NormalizationLower:
+		return strings.ToLower(word)
+	case NormalizationSmart:
+		return NormalizeSmart(word)
+	default:
+		return word
+	}
+}
+
+// NormalizeSmart lowercases each of the words of a phrase (separated by spaces
+// or hyphens), except for those that are likely proper nouns or acronyms:
+// words with more than one letter that are all capitals (such as "NASA" or
+// "U.S."), and words with capitals after their first letter (such as "iPhone"
+// or "McDonald"). A phrase of more than one word that are all capitalized
+// (such as "New York") is preserved entirely, while a single capitalized word
+// (such as "Apple") is lowercased, as it's most often just a sentence's first.
+func NormalizeSmart(phrase string) string {
+	words := strings.FieldsFunc(phrase, isWordSeparator)
+
+	if 1 < len(words) && allCapitalized(words) {
+		return phrase
+	}
+
+	runes := []rune(phrase)
+
+	for start := 0; start < len(runes); {
+		end := start
+
+		for end < len(runes) && !isWordSeparator(runes[end]) {
+			end++
+		}
+
+		if !isProperWord(runes[start:end]) {
+			for i := start; i < end; i++ {
+				runes[i] = unicode.ToLower(runes[i])
+			}
+		}
+
+		start = end + 1
+	}
+
+	return string(runes)
+}
+
+// isWordSeparator returns whether a character separates the words of a phrase
+func isWordSeparator(r rune) bool {
+	return unicode.IsSpace(r) || '-' == r
+}
+
+// allCapitalized returns whether each of the given words starts with a capital
+func allCapitalized(words []string) bool {
+	for _, word := range words {
+		if first := []rune(word)[0]; !unicode.IsUpper(first) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isProperWord returns whether a word is likely a proper noun or an acronym,
+// by its capitals (see NormalizeSmart)
+func isProperWord(word []rune) bool {
+	letters, capitals := 0, 0
+
+	for i, r := range word {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+
+		letters++
+
+		if unicode.IsUpper(r) {
+			capitals++
+
+			if 0 < i && unicode.IsLower(word[i-1]) {
+				return true
+			}
+		}
+	}
+
+	return 1 < letters && letters == capitals
+}
@@ -0,0 +1,57 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+import (
+	"testing"
+)
+
+func TestParseNormalization(t *testing.T) {
+	testData := map[string]Normalization{
+		"":      NormalizationSmart,
+		"none":  NormalizationNone,
+		"LOWER": NormalizationLower,
+		"Smart": NormalizationSmart,
+	}
+
+	for name, want := range testData {
+		if got, err := ParseNormalization(name); nil != err || want != got {
+			t.Errorf("ParseNormalization(%q) returned %q and error %v, want %q", name, got, err, want)
+		}
+	}
+
+	if _, err := ParseNormalization("upper"); nil == err {
+		t.Error("ParseNormalization didn't return an error for an unknown normalization")
+	}
+}
+
+func TestNormalizationApply(t *testing.T) {
+	testData := []struct {
+		normalization Normalization
+		word          string
+		want          string
+	}{
+		{NormalizationNone, "Apple", "Apple"},
+		{NormalizationLower, "NASA", "nasa"},
+		{NormalizationSmart, "Apple", "apple"},
+		{NormalizationSmart, "apple", "apple"},
+		{NormalizationSmart, "APPLE pie", "APPLE pie"},
+		{NormalizationSmart, "NASA", "NASA"},
+		{NormalizationSmart, "U.S.", "U.S."},
+		{NormalizationSmart, "iPhone", "iPhone"},
+		{NormalizationSmart, "McDonald", "McDonald"},
+		{NormalizationSmart, "New York", "New York"},
+		{NormalizationSmart, "Ice cream", "ice cream"},
+		{NormalizationSmart, "Well-Being", "Well-Being"},
+		{NormalizationSmart, "Self-esteem", "self-esteem"},
+		{NormalizationSmart, "I", "i"},
+		{NormalizationSmart, "Éclair", "éclair"},
+		{NormalizationSmart, "", ""},
+	}
+
+	for _, data := range testData {
+		if got := data.normalization.Apply(data.word); data.want != got {
+			t.Errorf("%s normalization of %q returned %q, want %q", data.normalization, data.word, got, data.want)
+		}
+	}
+}
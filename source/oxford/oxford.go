@@ -4,10 +4,10 @@
 package oxford
 
 import (
-	"encoding/json"
-	"io/ioutil"
+	"context"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
 
 	"github.com/Rican7/define/source"
@@ -22,6 +22,9 @@ const (
 
 	entriesURLString = baseURLString + "entries/"
 
+	// webSearchURLString is the URL of the Oxford web dictionary's search
+	webSearchURLString = "https://www.oed.com/search/dictionary/?q="
+
 	httpRequestAcceptHeaderName = "Accept"
 	httpRequestAppIDHeaderName  = "app_id"
 	httpRequestAppKeyHeaderName = "app_key"
@@ -76,20 +79,21 @@ type apiResult struct {
 					Type string
 				}
 				HomographNumber string
-				Notes           []struct {
+				Inflections     []struct {
+					GrammaticalFeatures []struct {
+						Text string
+						Type string
+					}
+					InflectedForm string
+				}
+				Notes []struct {
 					ID   string
 					Text string
 					Type string
 				}
-				Pronunciations []struct {
-					AudioFile        string
-					Dialects         []string
-					PhoneticNotation string
-					PhoneticSpelling string
-					Regions          []string
-				}
-				Senses       []apiSense
-				VariantForms []struct {
+				Pronunciations []apiPronunciation
+				Senses         []apiSense
+				VariantForms   []struct {
 					Regions []string
 					Text    string
 				}
@@ -105,31 +109,29 @@ type apiResult struct {
 				Text string
 				Type string
 			}
-			Pronunciations []struct {
-				AudioFile        string
-				Dialects         []string
-				PhoneticNotation string
-				PhoneticSpelling string
-				Regions          []string
-			}
-			Text         string
-			VariantForms []struct {
+			Pronunciations []apiPronunciation
+			Text           string
+			VariantForms   []struct {
 				Regions []string
 				Text    string
 			}
 		}
-		Pronunciations []struct {
-			AudioFile        string
-			Dialects         []string
-			PhoneticNotation string
-			PhoneticSpelling string
-			Regions          []string
-		}
-		Type string
-		Word string
+		Pronunciations []apiPronunciation
+		Type           string
+		Word           string
 	}
 }
 
+// apiPronunciation is a struct that defines the data structure for Oxford API
+// pronunciations
+type apiPronunciation struct {
+	AudioFile        string
+	Dialects         []string
+	PhoneticNotation string
+	PhoneticSpelling string
+	Regions          []string
+}
+
 // apiSense is a struct that defines the data structure for Oxford API senses
 type apiSense struct {
 	CrossReferenceMarkers []string
@@ -175,17 +177,11 @@ type apiSense struct {
 		Text string
 		Type string
 	}
-	Pronunciations []struct {
-		AudioFile        string
-		Dialects         []string
-		PhoneticNotation string
-		PhoneticSpelling string
-		Regions          []string
-	}
-	Regions      []string
-	Registers    []string
-	Subsenses    []apiSense
-	Translations []struct {
+	Pronunciations []apiPronunciation
+	Regions        []string
+	Registers      []string
+	Subsenses      []apiSense
+	Translations   []struct {
 		Domains             []string
 		GrammaticalFeatures []struct {
 			Text string
@@ -212,6 +208,8 @@ type oxfordEntry struct {
 	source.WordEntryValue
 	source.DictionaryEntryValue
 	source.EtymologyEntryValue
+	source.PronunciationEntryValue
+	source.InflectionEntryValue
 }
 
 // Initialize the package
@@ -235,8 +233,13 @@ func (g *api) Name() string {
 	return Name
 }
 
-// Define takes a word string and returns a dictionary source.Result
-func (g *api) Define(word string) (source.Result, error) {
+// WebURL returns the URL of the web page for the given word
+func (g *api) WebURL(word string) string {
+	return webSearchURLString + url.QueryEscape(word)
+}
+
+// Request returns the HTTP request used to define the given word
+func (g *api) Request(word string) (*http.Request, error) {
 	// Prepare our URL
 	requestURL, err := url.Parse(entriesURLString + "en/" + word)
 
@@ -254,7 +257,24 @@ func (g *api) Define(word string) (source.Result, error) {
 	httpRequest.Header.Set(httpRequestAppIDHeaderName, g.appID)
 	httpRequest.Header.Set(httpRequestAppKeyHeaderName, g.appKey)
 
-	httpResponse, err := g.httpClient.Do(httpRequest)
+	return httpRequest, nil
+}
+
+// Define takes a word string and returns a dictionary source.Result
+func (g *api) Define(word string) (source.Result, error) {
+	return g.DefineContext(context.Background(), word)
+}
+
+// DefineContext takes a word string and returns a dictionary source.Result,
+// cancelling its request if the context is done before it finishes
+func (g *api) DefineContext(ctx context.Context, word string) (source.Result, error) {
+	httpRequest, err := g.Request(word)
+
+	if nil != err {
+		return nil, err
+	}
+
+	httpResponse, err := g.httpClient.Do(httpRequest.WithContext(ctx))
 
 	if nil != err {
 		return nil, err
@@ -274,15 +294,9 @@ func (g *api) Define(word string) (source.Result, error) {
 		return nil, err
 	}
 
-	body, err := ioutil.ReadAll(httpResponse.Body)
-
-	if nil != err {
-		return nil, err
-	}
-
 	var result apiResult
 
-	if err = json.Unmarshal(body, &result); nil != err {
+	if err = source.ReadJSONResponse(Name, httpResponse, &result); nil != err {
 		return nil, err
 	}
 
@@ -302,30 +316,51 @@ func (r apiResult) toResult() source.Result {
 	for i, lexicalEntry := range mainResult.LexicalEntries {
 		entry := oxfordEntry{}
 
-		for _, pronunciation := range lexicalEntry.Pronunciations {
-			if strings.EqualFold(phoneticNotationIPAIdentifier, pronunciation.PhoneticNotation) {
-				entry.PronunciationVal = pronunciation.PhoneticSpelling
-			}
-		}
-
+		entry.PronunciationVals = toRegionalPronunciations(lexicalEntry.Pronunciations)
 		entry.WordVal = lexicalEntry.Text
 		entry.CategoryVal = lexicalEntry.LexicalCategory
 
-		for _, subEntry := range lexicalEntry.Entries {
+		subEntryPronunciations := make([][]source.RegionalPronunciation, len(lexicalEntry.Entries))
+
+		for j, subEntry := range lexicalEntry.Entries {
+			subEntryPronunciations[j] = toRegionalPronunciations(subEntry.Pronunciations)
+		}
+
+		// Sub-entries that are all pronounced the same are pronounced as the
+		// entry, rather than by each of their senses
+		if len(entry.PronunciationVals) < 1 && haveSamePronunciations(subEntryPronunciations) {
+			entry.PronunciationVals = subEntryPronunciations[0]
+		}
+
+		for j, subEntry := range lexicalEntry.Entries {
 			entry.EtymologyVals = append(entry.EtymologyVals, subEntry.Etymologies...)
 
+			// Sub-entries may share forms, which are only listed once, and
+			// the word itself isn't an inflection of it
+			for _, inflection := range subEntry.Inflections {
+				if form := inflection.InflectedForm; "" != form && form != entry.WordVal && !containsString(entry.InflectionVals, form) {
+					entry.InflectionVals = append(entry.InflectionVals, form)
+				}
+			}
+
 			for _, sense := range subEntry.Senses {
-				senseVal := sense.toSenseValue()
+				senseValue := sense.toSenseValue()
 
-				// Only go one level deep of sub-senses
-				for _, subSense := range sense.Subsenses {
-					senseVal.SubsenseVals = append(senseVal.SubsenseVals, subSense.toSenseValue())
+				// Link the sub-entry's pronunciations (such as of the present
+				// or past tense of "read") to its senses, unless they're the
+				// entry's
+				if len(senseValue.PronunciationVals) < 1 && !reflect.DeepEqual(subEntryPronunciations[j], entry.PronunciationVals) {
+					senseValue.PronunciationVals = subEntryPronunciations[j]
 				}
 
-				entry.SenseVals = append(entry.SenseVals, senseVal)
+				entry.SenseVals = append(entry.SenseVals, senseValue)
 			}
 		}
 
+		if 0 < len(entry.PronunciationVals) {
+			entry.PronunciationVal = entry.PronunciationVals[len(entry.PronunciationVals)-1].Transcription
+		}
+
 		entries[i] = entry
 	}
 
@@ -336,10 +371,12 @@ func (r apiResult) toResult() source.Result {
 	}
 }
 
-// toSenseValue converts the proprietary API sense to a source.SenseValue
+// toSenseValue converts the proprietary API sense, and its nested sub-senses,
+// to a source.SenseValue
 func (s apiSense) toSenseValue() source.SenseValue {
 	examples := make([]string, len(s.Examples))
 	notes := make([]string, len(s.Notes))
+	subsenses := make([]source.SenseValue, len(s.Subsenses))
 
 	for i, example := range s.Examples {
 		examples[i] = example.Text
@@ -349,9 +386,68 @@ func (s apiSense) toSenseValue() source.SenseValue {
 		notes[i] = note.Text
 	}
 
+	for i, subsense := range s.Subsenses {
+		subsenses[i] = subsense.toSenseValue()
+	}
+
 	return source.SenseValue{
-		DefinitionVals: s.Definitions,
-		ExampleVals:    examples,
-		NoteVals:       notes,
+		DefinitionVals:    s.Definitions,
+		ExampleVals:       examples,
+		NoteVals:          notes,
+		PronunciationVals: toRegionalPronunciations(s.Pronunciations),
+		SubsenseVals:      subsenses,
 	}
 }
+
+// toRegionalPronunciations converts the IPA pronunciations of the proprietary
+// API pronunciations to source.RegionalPronunciations, labeled by their
+// dialects (or else their regions)
+func toRegionalPronunciations(pronunciations []apiPronunciation) []source.RegionalPronunciation {
+	var converted []source.RegionalPronunciation
+
+	for _, pronunciation := range pronunciations {
+		if !strings.EqualFold(phoneticNotationIPAIdentifier, pronunciation.PhoneticNotation) {
+			continue
+		}
+
+		region := strings.Join(pronunciation.Dialects, ", ")
+
+		if "" == region {
+			region = strings.Join(pronunciation.Regions, ", ")
+		}
+
+		converted = append(converted, source.RegionalPronunciation{
+			Transcription: pronunciation.PhoneticSpelling,
+			Region:        region,
+		})
+	}
+
+	return converted
+}
+
+// haveSamePronunciations returns whether each of the given lists of
+// pronunciations are the same (and there's at least one list)
+func haveSamePronunciations(pronunciationLists [][]source.RegionalPronunciation) bool {
+	if len(pronunciationLists) < 1 {
+		return false
+	}
+
+	for _, pronunciations := range pronunciationLists[1:] {
+		if !reflect.DeepEqual(pronunciationLists[0], pronunciations) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// containsString returns whether a list of strings contains the given string
+func containsString(list []string, str string) bool {
+	for _, item := range list {
+		if str == item {
+			return true
+		}
+	}
+
+	return false
+}
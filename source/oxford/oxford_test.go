@@ -0,0 +1,307 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package oxford
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rican7/define/source"
+)
+
+// stubTransport is an http.RoundTripper that responds to every request with
+// the same canned response, recording the last request
+type stubTransport struct {
+	statusCode  int
+	contentType string
+	body        string
+
+	request *http.Request
+}
+
+func (t *stubTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	t.request = request
+
+	return &http.Response{
+		StatusCode:    t.statusCode,
+		Header:        http.Header{"Content-Type": []string{t.contentType}},
+		Body:          ioutil.NopCloser(strings.NewReader(t.body)),
+		ContentLength: int64(len(t.body)),
+		Request:       request,
+	}, nil
+}
+
+// blockingTransport is an http.RoundTripper that never responds, failing
+// once its request is cancelled
+type blockingTransport struct {
+	cancelled chan struct{}
+}
+
+func (t *blockingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	<-request.Context().Done()
+	close(t.cancelled)
+
+	return nil, request.Context().Err()
+}
+
+func TestDefine(t *testing.T) {
+	testData := []struct {
+		name               string
+		transport          *stubTransport
+		wantCategory       string
+		wantPronunciation  string
+		wantDefinitions    []string
+		wantSubdefinitions []string
+		wantErr            interface{}
+	}{
+		{
+			name: "success",
+			transport: &stubTransport{statusCode: http.StatusOK, contentType: "application/json", body: `{
+				"results": [{
+					"word": "hello",
+					"language": "en",
+					"lexicalEntries": [{
+						"text": "hello",
+						"lexicalCategory": "Noun",
+						"pronunciations": [
+							{"phoneticNotation": "IPA", "phoneticSpelling": "həˈləʊ", "dialects": ["British English"]},
+							{"phoneticNotation": "respell", "phoneticSpelling": "heh-loh"}
+						],
+						"entries": [{
+							"senses": [{
+								"definitions": ["an utterance of \"hello\"; a greeting"],
+								"examples": [{"text": "she was getting polite nods and hellos"}],
+								"subsenses": [{"definitions": ["a call to attract attention"]}]
+							}]
+						}]
+					}]
+				}]
+			}`},
+			wantCategory:       "Noun",
+			wantPronunciation:  "həˈləʊ",
+			wantDefinitions:    []string{"an utterance of \"hello\"; a greeting"},
+			wantSubdefinitions: []string{"a call to attract attention"},
+		},
+		{
+			name:      "empty",
+			transport: &stubTransport{statusCode: http.StatusOK, contentType: "application/json", body: `{"results": []}`},
+			wantErr:   &source.EmptyResultError{},
+		},
+		{
+			name:      "not found",
+			transport: &stubTransport{statusCode: http.StatusNotFound, contentType: "text/html", body: "<h1>Not Found</h1>"},
+			wantErr:   &source.EmptyResultError{},
+		},
+		{
+			name:      "malformed JSON",
+			transport: &stubTransport{statusCode: http.StatusOK, contentType: "application/json", body: `{"results": [}`},
+			wantErr:   &json.SyntaxError{},
+		},
+		{
+			name:      "unauthenticated",
+			transport: &stubTransport{statusCode: http.StatusForbidden, contentType: "application/json", body: `{}`},
+			wantErr:   &source.AuthenticationError{},
+		},
+		{
+			name:      "HTTP error",
+			transport: &stubTransport{statusCode: http.StatusInternalServerError, contentType: "text/html", body: "<h1>Internal Server Error</h1>"},
+			wantErr:   &source.InvalidResponseError{},
+		},
+	}
+
+	defer func() { httpTransport = nil }()
+
+	for _, data := range testData {
+		httpTransport = data.transport
+
+		src, err := (&provider{}).Provide(&config{AppID: "id", AppKey: "key"})
+
+		if nil != err {
+			t.Fatalf("%s: Provide returned error %q", data.name, err)
+		}
+
+		result, err := src.Define("hello")
+
+		if appID := data.transport.request.Header.Get(httpRequestAppIDHeaderName); "id" != appID {
+			t.Errorf("%s: Define sent app ID %q, want %q", data.name, appID, "id")
+		}
+
+		if nil != data.wantErr {
+			if nil == err || reflect.TypeOf(data.wantErr) != reflect.TypeOf(err) {
+				t.Errorf("%s: Define returned error %#v, want a %T", data.name, err, data.wantErr)
+			}
+
+			continue
+		}
+
+		if nil != err {
+			t.Errorf("%s: Define returned error %q", data.name, err)
+			continue
+		}
+
+		entry := result.Entries()[0]
+
+		if category := entry.(source.WordEntry).Category(); data.wantCategory != category {
+			t.Errorf("%s: Define returned category %q, want %q", data.name, category, data.wantCategory)
+		}
+
+		if data.wantPronunciation != entry.Pronunciation() {
+			t.Errorf("%s: Define returned pronunciation %q, want %q", data.name, entry.Pronunciation(), data.wantPronunciation)
+		}
+
+		sense := entry.Senses()[0]
+
+		if !reflect.DeepEqual(data.wantDefinitions, sense.Definitions()) {
+			t.Errorf("%s: Define returned definitions %q, want %q", data.name, sense.Definitions(), data.wantDefinitions)
+		}
+
+		if subdefinitions := sense.Subsenses()[0].Definitions(); !reflect.DeepEqual(data.wantSubdefinitions, subdefinitions) {
+			t.Errorf("%s: Define returned sub-sense definitions %q, want %q", data.name, subdefinitions, data.wantSubdefinitions)
+		}
+	}
+}
+
+func TestDefineContextCancelsRequest(t *testing.T) {
+	transport := &blockingTransport{cancelled: make(chan struct{})}
+	src := New(http.Client{Transport: transport}, "id", "key")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := source.DefineContext(ctx, src, "hello"); context.DeadlineExceeded != err {
+		t.Errorf("DefineContext returned wrong error. Got %v. Want %v.", err, context.DeadlineExceeded)
+	}
+
+	select {
+	case <-transport.cancelled:
+	default:
+		t.Error("the request wasn't cancelled when the lookup's deadline was exceeded")
+	}
+}
+
+func TestDefineContextCancelledAbortsRequest(t *testing.T) {
+	transport := &blockingTransport{cancelled: make(chan struct{})}
+	src := New(http.Client{Transport: transport}, "id", "key")
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	if _, err := source.DefineContext(ctx, src, "hello"); context.Canceled != err {
+		t.Errorf("DefineContext returned wrong error. Got %v. Want %v.", err, context.Canceled)
+	}
+
+	select {
+	case <-transport.cancelled:
+	default:
+		t.Error("the request wasn't cancelled when the lookup was cancelled")
+	}
+}
+
+func TestToResultSensePronunciations(t *testing.T) {
+	var result apiResult
+
+	err := json.Unmarshal([]byte(`{
+		"results": [{
+			"word": "read",
+			"lexicalEntries": [{
+				"text": "read",
+				"lexicalCategory": "Verb",
+				"entries": [
+					{
+						"pronunciations": [{"phoneticNotation": "IPA", "phoneticSpelling": "riːd"}],
+						"senses": [
+							{"definitions": ["look at and comprehend"]},
+							{"definitions": ["a mark"], "pronunciations": [{"phoneticNotation": "IPA", "phoneticSpelling": "rɛd", "regions": ["US"]}]}
+						]
+					},
+					{
+						"pronunciations": [{"phoneticNotation": "IPA", "phoneticSpelling": "rɛd"}],
+						"senses": [{"definitions": ["past tense of read"]}]
+					}
+				]
+			}, {
+				"text": "read",
+				"lexicalCategory": "Noun",
+				"entries": [
+					{"pronunciations": [{"phoneticNotation": "IPA", "phoneticSpelling": "riːd"}], "senses": [{"definitions": ["a period of reading"]}]}
+				]
+			}]
+		}]
+	}`), &result)
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	entries := result.toResult().Entries()
+
+	testData := []struct {
+		pronunciation string
+		senses        [][]source.RegionalPronunciation
+	}{
+		{"", [][]source.RegionalPronunciation{
+			{{Transcription: "riːd"}},
+			{{Transcription: "rɛd", Region: "US"}},
+			{{Transcription: "rɛd"}},
+		}},
+		// The sub-entries are all pronounced the same, so the entry is too
+		{"riːd", [][]source.RegionalPronunciation{nil}},
+	}
+
+	for i, data := range testData {
+		if pronunciation := entries[i].Pronunciation(); data.pronunciation != pronunciation {
+			t.Errorf("entry %d has the pronunciation %q, want %q", i, pronunciation, data.pronunciation)
+		}
+
+		for j, sense := range entries[i].Senses() {
+			if got := source.SensePronunciations(sense); !reflect.DeepEqual(data.senses[j], got) {
+				t.Errorf("sense %d of entry %d has the pronunciations %v, want %v", j, i, got, data.senses[j])
+			}
+		}
+	}
+}
+
+func TestToResultInflections(t *testing.T) {
+	var result apiResult
+
+	err := json.Unmarshal([]byte(`{
+		"results": [{
+			"word": "run",
+			"lexicalEntries": [{
+				"text": "run",
+				"lexicalCategory": "Verb",
+				"entries": [
+					{"inflections": [{"inflectedForm": "runs"}, {"inflectedForm": "ran"}, {"inflectedForm": "run"}], "senses": [{"definitions": ["move swiftly"]}]},
+					{"inflections": [{"inflectedForm": "ran"}, {"inflectedForm": "running"}], "senses": [{"definitions": ["manage"]}]}
+				]
+			}, {
+				"text": "run",
+				"lexicalCategory": "Noun",
+				"entries": [{"senses": [{"definitions": ["an act of running"]}]}]
+			}]
+		}]
+	}`), &result)
+
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	entries := result.toResult().Entries()
+	want := [][]string{{"runs", "ran", "running"}, nil}
+
+	for i, entry := range entries {
+		if got := entry.(source.InflectionEntry).Inflections(); !reflect.DeepEqual(want[i], got) {
+			t.Errorf("entry %d has the inflections %q, want %q", i, got, want[i])
+		}
+	}
+}
@@ -6,10 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
 
 	flag "github.com/ogier/pflag"
 
+	"github.com/Rican7/define/internal/keyring"
+	"github.com/Rican7/define/internal/transport"
 	"github.com/Rican7/define/registry"
 	"github.com/Rican7/define/source"
 )
@@ -21,8 +22,13 @@ type RequiredConfigError struct {
 }
 
 type config struct {
-	AppID  string
-	AppKey string
+	AppID   string
+	AppKey  string
+	Headers map[string]string
+
+	// Whether values were loaded from the keyring
+	appIDInKeyring  bool
+	appKeyInKeyring bool
 }
 
 type provider struct{}
@@ -30,6 +36,23 @@ type provider struct{}
 // JSONKey defines the JSON key used for the provider
 const JSONKey = "OxfordDictionary"
 
+// httpTransport is the transport of the HTTP clients of the provided sources,
+// which tests can replace with a stub (nil uses http.DefaultTransport)
+var httpTransport http.RoundTripper
+
+// Flag names, which also serve as the names of secrets in the keyring
+const (
+	appIDFlagName  = "oxford-dictionary-app-id"
+	appKeyFlagName = "oxford-dictionary-app-key"
+)
+
+// envVars is the list of environment variables that the configuration reads
+// its values from
+var envVars = []registry.EnvVar{
+	{Name: "DEFINE_OXFORD_APP_ID", Key: "AppID", Aliases: []string{"OXFORD_DICTIONARY_APP_ID"}},
+	{Name: "DEFINE_OXFORD_APP_KEY", Key: "AppKey", Aliases: []string{"OXFORD_DICTIONARY_APP_KEY"}},
+}
+
 func init() {
 	registry.Register(registry.RegisterFunc(register))
 }
@@ -42,8 +65,8 @@ func initConfig(flags *flag.FlagSet) *config {
 	conf := &config{}
 
 	// Define our flags
-	flags.StringVar(&conf.AppID, "oxford-dictionary-app-id", "", fmt.Sprintf("The app ID for the %s", Name))
-	flags.StringVar(&conf.AppKey, "oxford-dictionary-app-key", "", fmt.Sprintf("The app key for the %s", Name))
+	flags.StringVar(&conf.AppID, appIDFlagName, "", fmt.Sprintf("The app ID for the %s", Name))
+	flags.StringVar(&conf.AppKey, appKeyFlagName, "", fmt.Sprintf("The app key for the %s", Name))
 
 	return conf
 }
@@ -56,6 +79,24 @@ func (c *config) JSONKey() string {
 	return JSONKey
 }
 
+// MarshalJSON defines how the configuration should be JSON marshalled.
+func (c *config) MarshalJSON() ([]byte, error) {
+	// Alias our type so that we can marshal as usual
+	type alias config
+	copy := alias(*c)
+
+	// Never output secrets that are stored in the keyring
+	if c.appIDInKeyring {
+		copy.AppID = keyring.Placeholder
+	}
+
+	if c.appKeyInKeyring {
+		copy.AppKey = keyring.Placeholder
+	}
+
+	return json.Marshal(copy)
+}
+
 // UnmarshalJSON defines how the configuration should be JSON unmarshalled.
 func (c *config) UnmarshalJSON(data []byte) error {
 	// Alias our type so that we can unmarshal as usual
@@ -69,24 +110,28 @@ func (c *config) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	if "" == c.AppID {
+	if "" == c.AppID && keyring.Placeholder != copy.AppID {
 		c.AppID = copy.AppID
 	}
 
-	if "" == c.AppKey {
+	if "" == c.AppKey && keyring.Placeholder != copy.AppKey {
 		c.AppKey = copy.AppKey
 	}
 
+	if nil == c.Headers {
+		c.Headers = copy.Headers
+	}
+
 	return nil
 }
 
 func (c *config) Finalize() {
 	if "" == c.AppID {
-		c.AppID = os.Getenv("OXFORD_DICTIONARY_APP_ID")
+		c.AppID, c.appIDInKeyring = keyring.Lookup(appIDFlagName)
 	}
 
 	if "" == c.AppKey {
-		c.AppKey = os.Getenv("OXFORD_DICTIONARY_APP_KEY")
+		c.AppKey, c.appKeyInKeyring = keyring.Lookup(appKeyFlagName)
 	}
 }
 
@@ -94,6 +139,20 @@ func (p *provider) Name() string {
 	return Name
 }
 
+func (p *provider) Metadata() registry.Metadata {
+	return registry.Metadata{
+		Description: "The English dictionaries of Oxford University Press",
+		Homepage:    "https://developer.oxforddictionaries.com/",
+		SignupURL:   "https://developer.oxforddictionaries.com/?tag=#plans",
+		RequiredKeys: []registry.RequiredKey{
+			{Name: "AppID", FlagName: appIDFlagName},
+			{Name: "AppKey", FlagName: appKeyFlagName},
+		},
+		Capabilities: []string{registry.CapabilityPronunciations, registry.CapabilityExamples, registry.CapabilityEtymologies, registry.CapabilityInflections},
+		EnvVars:      envVars,
+	}
+}
+
 func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
 	config := conf.(*config)
 
@@ -105,5 +164,5 @@ func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
 		return nil, &RequiredConfigError{Key: "AppKey"}
 	}
 
-	return New(http.Client{}, config.AppID, config.AppKey), nil
+	return New(http.Client{Transport: transport.WithHeaders(httpTransport, config.Headers)}, config.AppID, config.AppKey), nil
 }
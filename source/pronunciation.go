@@ -0,0 +1,74 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+// EntryPronunciations returns the pronunciations of an entry: its regional
+// pronunciations, if it has any, or otherwise its single (unlabeled)
+// pronunciation, if it has one
+func EntryPronunciations(entry DictionaryEntry) []RegionalPronunciation {
+	if pronunciationEntry, ok := entry.(PronunciationEntry); ok && 0 < len(pronunciationEntry.Pronunciations()) {
+		return pronunciationEntry.Pronunciations()
+	}
+
+	if "" == entry.Pronunciation() {
+		return nil
+	}
+
+	return []RegionalPronunciation{{Transcription: entry.Pronunciation()}}
+}
+
+// SensePronunciations returns the pronunciations specific to a sense, if it
+// has any
+func SensePronunciations(sense Sense) []RegionalPronunciation {
+	if pronouncedSense, ok := sense.(PronouncedSense); ok {
+		return pronouncedSense.Pronunciations()
+	}
+
+	return nil
+}
+
+// ResultPronunciations returns the distinct pronunciations of all of the
+// entries of a result (and of their senses), in the order that they first
+// appear. An unlabeled pronunciation is omitted if an earlier one has the same
+// transcription.
+func ResultPronunciations(result Result) []RegionalPronunciation {
+	var pronunciations []RegionalPronunciation
+
+	seen := make(map[RegionalPronunciation]bool)
+	seenTranscriptions := make(map[string]bool)
+
+	for _, entry := range result.Entries() {
+		// Copied, so that the entry's own pronunciations aren't appended to
+		candidates := append([]RegionalPronunciation(nil), EntryPronunciations(entry)...)
+		candidates = append(candidates, sensesPronunciations(entry.Senses())...)
+
+		for _, pronunciation := range candidates {
+			if "" == pronunciation.Transcription || seen[pronunciation] {
+				continue
+			}
+
+			if "" == pronunciation.Region && seenTranscriptions[pronunciation.Transcription] {
+				continue
+			}
+
+			seen[pronunciation] = true
+			seenTranscriptions[pronunciation.Transcription] = true
+			pronunciations = append(pronunciations, pronunciation)
+		}
+	}
+
+	return pronunciations
+}
+
+// sensesPronunciations returns the pronunciations specific to each of the
+// given senses, and to their subsenses
+func sensesPronunciations(senses []Sense) []RegionalPronunciation {
+	var pronunciations []RegionalPronunciation
+
+	for _, sense := range senses {
+		pronunciations = append(pronunciations, SensePronunciations(sense)...)
+		pronunciations = append(pronunciations, sensesPronunciations(sense.Subsenses())...)
+	}
+
+	return pronunciations
+}
@@ -0,0 +1,67 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEntryPronunciations(t *testing.T) {
+	regional := []RegionalPronunciation{{Transcription: "ˈtɒməːtəʊ", Region: "British English"}}
+
+	testData := []struct {
+		entry DictionaryEntry
+		want  []RegionalPronunciation
+	}{
+		{DictionaryEntryValue{}, nil},
+		{DictionaryEntryValue{PronunciationVal: "təˈmeɪtoʊ"}, []RegionalPronunciation{{Transcription: "təˈmeɪtoʊ"}}},
+		{EntryValue{PronunciationEntryValue: PronunciationEntryValue{PronunciationVals: regional}}, regional},
+		{
+			EntryValue{
+				DictionaryEntryValue:    DictionaryEntryValue{PronunciationVal: "təˈmeɪtoʊ"},
+				PronunciationEntryValue: PronunciationEntryValue{PronunciationVals: regional},
+			},
+			regional,
+		},
+	}
+
+	for _, data := range testData {
+		if got := EntryPronunciations(data.entry); !reflect.DeepEqual(data.want, got) {
+			t.Errorf("EntryPronunciations(%#v) returned %v, want %v", data.entry, got, data.want)
+		}
+	}
+}
+
+func TestResultPronunciations(t *testing.T) {
+	result := ResultValue{
+		Head: "tomato",
+		EntryVals: []interface{}{
+			EntryValue{
+				PronunciationEntryValue: PronunciationEntryValue{PronunciationVals: []RegionalPronunciation{
+					{Transcription: "təˈmɑːtəʊ", Region: "British English"},
+					{Transcription: "təˈmeɪtoʊ", Region: "American English"},
+				}},
+			},
+			EntryValue{DictionaryEntryValue: DictionaryEntryValue{PronunciationVal: "təˈmeɪtoʊ"}},
+			EntryValue{
+				PronunciationEntryValue: PronunciationEntryValue{PronunciationVals: []RegionalPronunciation{
+					{Transcription: "təˈmeɪtoʊ", Region: "American English"},
+				}},
+			},
+			EntryValue{DictionaryEntryValue: DictionaryEntryValue{SenseVals: []SenseValue{
+				NewSense("a plant").WithSubsenses(NewSense("a fruit").WithPronunciations(RegionalPronunciation{Transcription: "təˈmatoʊ"})),
+			}}},
+		},
+	}
+
+	want := []RegionalPronunciation{
+		{Transcription: "təˈmɑːtəʊ", Region: "British English"},
+		{Transcription: "təˈmeɪtoʊ", Region: "American English"},
+		{Transcription: "təˈmatoʊ"},
+	}
+
+	if got := ResultPronunciations(result); !reflect.DeepEqual(want, got) {
+		t.Errorf("ResultPronunciations returned %v, want %v", got, want)
+	}
+}
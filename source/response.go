@@ -0,0 +1,84 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+const (
+	// truncatedJSONErrorMessage is the message of the JSON syntax error for
+	// input that ends before the JSON is complete
+	truncatedJSONErrorMessage = "unexpected end of JSON input"
+
+	// truncatedXMLErrorMessage is the message of the XML syntax error for input
+	// that ends before the XML is complete
+	truncatedXMLErrorMessage = "unexpected EOF"
+)
+
+// ReadResponse reads the body of an HTTP response of the named source. A body
+// that ends before its declared length (such as when the connection dropped
+// mid-response) results in an IncompleteResponseError.
+func ReadResponse(sourceName string, httpResponse *http.Response) ([]byte, error) {
+	body, err := ioutil.ReadAll(httpResponse.Body)
+
+	if io.ErrUnexpectedEOF == err {
+		return nil, &IncompleteResponseError{Source: sourceName, Err: err}
+	}
+
+	if nil != err {
+		return nil, err
+	}
+
+	if 0 <= httpResponse.ContentLength && int64(len(body)) < httpResponse.ContentLength {
+		return nil, &IncompleteResponseError{Source: sourceName, Err: io.ErrUnexpectedEOF}
+	}
+
+	return body, nil
+}
+
+// ReadJSONResponse reads the body of an HTTP response of the named source and
+// decodes it as JSON into the given value. A body that ends before the JSON is
+// complete results in an IncompleteResponseError, rather than a syntax error.
+func ReadJSONResponse(sourceName string, httpResponse *http.Response, v interface{}) error {
+	body, err := ReadResponse(sourceName, httpResponse)
+
+	if nil != err {
+		return err
+	}
+
+	if err = json.Unmarshal(body, v); nil != err {
+		if syntaxErr, ok := err.(*json.SyntaxError); ok && truncatedJSONErrorMessage == syntaxErr.Error() {
+			return &IncompleteResponseError{Source: sourceName, Err: err}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// ReadXMLResponse reads the body of an HTTP response of the named source and
+// decodes it as XML into the given value. A body that ends before the XML is
+// complete results in an IncompleteResponseError, rather than a syntax error.
+func ReadXMLResponse(sourceName string, httpResponse *http.Response, v interface{}) error {
+	body, err := ReadResponse(sourceName, httpResponse)
+
+	if nil != err {
+		return err
+	}
+
+	if err = xml.Unmarshal(body, v); nil != err {
+		if syntaxErr, ok := err.(*xml.SyntaxError); ok && truncatedXMLErrorMessage == syntaxErr.Msg {
+			return &IncompleteResponseError{Source: sourceName, Err: err}
+		}
+
+		return err
+	}
+
+	return nil
+}
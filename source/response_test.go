@@ -0,0 +1,118 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Enforce interface contracts
+var (
+	_ error = (*IncompleteResponseError)(nil)
+)
+
+// newTruncatingServer returns a test server that responds with the given
+// status line, headers, and body, and then closes the connection
+func newTruncatingServer(t *testing.T, response string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, buf, err := w.(http.Hijacker).Hijack()
+
+		if nil != err {
+			t.Fatalf("hijacking the connection failed: %s", err)
+		}
+
+		buf.WriteString(response)
+		buf.Flush()
+		conn.Close()
+	}))
+}
+
+func TestReadJSONResponse(t *testing.T) {
+	testData := []struct {
+		name           string
+		response       string
+		wantIncomplete bool
+		wantErr        bool
+	}{
+		{
+			name:     "complete",
+			response: "HTTP/1.1 200 OK\r\nContent-Length: 13\r\n\r\n{\"word\":\"ok\"}",
+		},
+		{
+			name:           "shorter than its content length",
+			response:       "HTTP/1.1 200 OK\r\nContent-Length: 100\r\n\r\n{\"word\":",
+			wantIncomplete: true,
+			wantErr:        true,
+		},
+		{
+			name:           "closed mid-document",
+			response:       "HTTP/1.1 200 OK\r\nConnection: close\r\n\r\n{\"word\":",
+			wantIncomplete: true,
+			wantErr:        true,
+		},
+		{
+			name:     "invalid",
+			response: "HTTP/1.1 200 OK\r\nContent-Length: 8\r\n\r\n{\"word\"}",
+			wantErr:  true,
+		},
+	}
+
+	for _, data := range testData {
+		server := newTruncatingServer(t, data.response)
+
+		httpResponse, err := http.Get(server.URL)
+
+		if nil != err {
+			server.Close()
+			t.Fatalf("%s: request failed: %s", data.name, err)
+		}
+
+		var decoded struct{ Word string }
+
+		err = ReadJSONResponse("test", httpResponse, &decoded)
+
+		httpResponse.Body.Close()
+		server.Close()
+
+		if _, ok := err.(*IncompleteResponseError); data.wantIncomplete != ok {
+			t.Errorf("%s: ReadJSONResponse returned %#v, want an IncompleteResponseError: %t", data.name, err, data.wantIncomplete)
+		}
+
+		if data.wantErr != (nil != err) {
+			t.Errorf("%s: ReadJSONResponse returned %#v, want an error: %t", data.name, err, data.wantErr)
+		}
+
+		if !data.wantErr && "ok" != decoded.Word {
+			t.Errorf("%s: ReadJSONResponse decoded %#v", data.name, decoded)
+		}
+	}
+}
+
+func TestReadXMLResponseIncomplete(t *testing.T) {
+	server := newTruncatingServer(t, "HTTP/1.1 200 OK\r\nConnection: close\r\n\r\n<entries><entry>")
+	defer server.Close()
+
+	httpResponse, err := http.Get(server.URL)
+
+	if nil != err {
+		t.Fatalf("request failed: %s", err)
+	}
+
+	defer httpResponse.Body.Close()
+
+	var decoded struct{}
+
+	if err = ReadXMLResponse("test", httpResponse, &decoded); nil == err {
+		t.Fatalf("ReadXMLResponse returned no error")
+	}
+
+	if _, ok := err.(*IncompleteResponseError); !ok {
+		t.Errorf("ReadXMLResponse returned %#v, want an IncompleteResponseError", err)
+	}
+
+	if want := `incomplete response from "test", please retry`; want != err.Error() {
+		t.Errorf("Error() returned %q, want %q", err.Error(), want)
+	}
+}
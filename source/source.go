@@ -4,26 +4,132 @@
 // common structures and operations for those implementations to use.
 package source
 
+import "net/http"
+
 // Source defines an interface for interacting with different dictionaries
 type Source interface {
+	// Name returns the source's human-readable name, used for attribution
 	Name() string
 
+	// Define looks up the given word, returning its result
 	Define(word string) (Result, error)
 }
 
+// LabeledSource defines an interface for sources with a label that should be
+// shown alongside their attribution, such as a note about their content
+type LabeledSource interface {
+	Source
+
+	Label() string
+}
+
+// RequestSource defines an interface for sources that define words over HTTP,
+// allowing the request for a word to be built without sending it
+type RequestSource interface {
+	Source
+
+	Request(word string) (*http.Request, error)
+}
+
+// WebSource defines an interface for sources with a human-facing web page for
+// the words that they define
+type WebSource interface {
+	Source
+
+	// WebURL returns the URL of the web page for the given word, or an empty
+	// string if the source has no web page for it
+	WebURL(word string) string
+}
+
+// OptionedSource defines an interface for sources whose results depend on
+// their options, and not only on the word, such as the language that they
+// define words in
+type OptionedSource interface {
+	Source
+
+	// Options returns the options that the source's results depend on
+	Options() Options
+}
+
+// Options defines the options of a source that its results depend on
+type Options struct {
+	// Language is the language (or language pair) that words are defined in
+	Language string
+
+	// Region is the region or dialect that words are defined for
+	Region string
+
+	// Mode is the mode that results are built in, such as "rich"
+	Mode string
+}
+
+// Translator defines an interface for sources that translate text between
+// languages (by ISO 639-1 code, such as "fr")
+type Translator interface {
+	Name() string
+
+	Translate(text string, from string, to string) (string, error)
+}
+
+// Matcher defines an interface for sources (such as local dictionaries) that
+// can search all of their headwords by a regular expression pattern
+type Matcher interface {
+	Match(pattern string) ([]Result, error)
+}
+
+// Rhymer defines an interface for sources that can find the words that rhyme
+// with a word
+type Rhymer interface {
+	// Rhymes returns the words rhyming with the given word (or that nearly
+	// rhyme, if near is true), up to the given limit (0 for the source's
+	// default limit)
+	Rhymes(word string, near bool, limit uint) ([]Rhyme, error)
+}
+
+// Associator defines an interface for sources that can find the words that
+// are related to a word, such as by being commonly used with it
+type Associator interface {
+	Name() string
+
+	// Related returns the words related to the given word, up to the given
+	// limit (0 for the source's default limit)
+	Related(word string, limit uint) ([]string, error)
+}
+
+// Rhyme defines a word that rhymes with another, and the score of how well it
+// rhymes (relative to the other rhymes of the same word)
+type Rhyme struct {
+	Word  string `json:"word"`
+	Score int    `json:"score"`
+}
+
 // Result defines an interface for the results of a dictionary lookup
+//
+// Results can be built with NewResult, and their entries can be inspected for
+// more detail by asserting them to the other entry interfaces (such as
+// WordEntry or ThesaurusEntry).
 type Result interface {
+	// Headword returns the word that was looked up, as the source knows it
 	Headword() string
+
+	// Language returns the language of the result (by ISO 639-1 code, such as
+	// "en"), or an empty string if it's unknown
 	Language() string
+
+	// Entries returns the result's entries, such as one for each lexical
+	// category of the word
 	Entries() []DictionaryEntry
 }
 
 // Entry defines a composite interface for the complete account of a word
+//
+// Entries can be built with NewEntry.
 type Entry interface {
 	WordEntry
 	DictionaryEntry
 	EtymologyEntry
 	ThesaurusEntry
+	InflectionEntry
 }
 
 // ComprehensiveDictionaryEntry defines a composite interface for a
@@ -40,34 +146,86 @@ type VersatileDictionaryEntry interface {
 	ThesaurusEntry
 }
 
+// InflectionEntry defines an interface for an entry of a word's inflected
+// forms (such as plurals or past tenses)
+type InflectionEntry interface {
+	// Inflections returns the word's inflected forms
+	Inflections() []string
+}
+
+// PronunciationEntry defines an interface for an entry of a word's
+// pronunciations, labeled by the region or dialect that they're used in
+type PronunciationEntry interface {
+	// Pronunciations returns the word's pronunciations, by region or dialect
+	Pronunciations() []RegionalPronunciation
+}
+
+// LanguageEntry defines an interface for an entry of a word in a specific
+// language, for results that span multiple languages
+type LanguageEntry interface {
+	// Language returns the language of the entry (by ISO 639-1 code, such as
+	// "en")
+	Language() string
+}
+
 // WordEntry defines an interface for an entry of a specific word
 type WordEntry interface {
+	// Word returns the entry's word
 	Word() string
+
+	// Category returns the entry's lexical category (such as "noun")
 	Category() string
 }
 
 // DictionaryEntry defines an interface for a dictionary entry of a word
 type DictionaryEntry interface {
+	// Pronunciation returns the word's pronunciation (typically in IPA), or an
+	// empty string if it's unknown
 	Pronunciation() string
+
+	// Senses returns the word's different meanings
 	Senses() []Sense
 }
 
 // EtymologyEntry defines an interface for an etymological entry of a word
 type EtymologyEntry interface {
+	// Etymologies returns the word's origins
 	Etymologies() []string
 }
 
 // ThesaurusEntry defines an interface for a thesaurus entry of a word
 type ThesaurusEntry interface {
+	// Synonyms returns the words with the same or similar meanings
 	Synonyms() []string
+
+	// Antonyms returns the words with opposite meanings
 	Antonyms() []string
 }
 
 // Sense defines an interface for the different meanings of a word
+//
+// Senses can be built with NewSense.
 type Sense interface {
+	// Definitions returns the sense's definitions
 	Definitions() []string
+
+	// Examples returns the examples of the word used in the sense
 	Examples() []string
+
+	// Notes returns the sense's usage notes (such as "informal")
 	Notes() []string
 
+	// Subsenses returns the more specific meanings within the sense
 	Subsenses() []Sense
 }
+
+// PronouncedSense defines an interface for senses with their own
+// pronunciations, such as of a word that's pronounced differently depending on
+// its meaning (like "read" or "bow")
+type PronouncedSense interface {
+	Sense
+
+	// Pronunciations returns the pronunciations of the word in the sense, by
+	// region or dialect
+	Pronunciations() []RegionalPronunciation
+}
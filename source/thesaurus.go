@@ -0,0 +1,52 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+import "strings"
+
+// MergeThesaurus returns a copy of the given result with the synonyms and
+// antonyms of the given thesaurus result (such as of another source) added to
+// each of its entries of the same lexical category, after any that the entry
+// already has. The thesaurus result's entries of other categories are left out.
+func MergeThesaurus(result Result, thesaurus Result) Result {
+	merged := newJSONResult(result)
+	thesaurusEntries := newJSONResult(thesaurus).Entries
+
+	for i, entry := range merged.Entries {
+		for _, thesaurusEntry := range thesaurusEntries {
+			if !strings.EqualFold(entry.Category, thesaurusEntry.Category) {
+				continue
+			}
+
+			entry.Synonyms = appendUnique(entry.Synonyms, thesaurusEntry.Synonyms...)
+			entry.Antonyms = appendUnique(entry.Antonyms, thesaurusEntry.Antonyms...)
+		}
+
+		merged.Entries[i] = entry
+	}
+
+	return merged.toResult()
+}
+
+// appendUnique returns a copy of a list with the given words appended,
+// skipping those that are already in it (case-insensitively)
+func appendUnique(list []string, words ...string) []string {
+	list = append([]string(nil), list...)
+
+	for _, word := range words {
+		exists := false
+
+		for _, existing := range list {
+			if strings.EqualFold(existing, word) {
+				exists = true
+				break
+			}
+		}
+
+		if !exists {
+			list = append(list, word)
+		}
+	}
+
+	return list
+}
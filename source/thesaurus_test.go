@@ -0,0 +1,54 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package source
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeThesaurus(t *testing.T) {
+	result := NewResult("run", "en",
+		NewEntry("run", "verb", NewSense("to move swiftly")).WithSynonyms("sprint"),
+		NewEntry("run", "noun", NewSense("an act of running")),
+		NewEntry("run", "adjective", NewSense("melted")),
+	)
+
+	thesaurus := NewResult("run", "en",
+		NewEntry("run", "Verb").WithSynonyms("Sprint", "dash").WithAntonyms("walk"),
+		NewEntry("run", "noun").WithSynonyms("jog"),
+		NewEntry("run", "adverb").WithSynonyms("quickly"),
+	)
+
+	merged := MergeThesaurus(result, thesaurus)
+
+	want := []struct {
+		synonyms []string
+		antonyms []string
+	}{
+		{[]string{"sprint", "dash"}, []string{"walk"}},
+		{[]string{"jog"}, nil},
+		{nil, nil},
+	}
+
+	if len(want) != len(merged.Entries()) {
+		t.Fatalf("MergeThesaurus returned %d entries, want %d", len(merged.Entries()), len(want))
+	}
+
+	for i, entry := range merged.Entries() {
+		thesaurusEntry := entry.(ThesaurusEntry)
+
+		if !reflect.DeepEqual(want[i].synonyms, thesaurusEntry.Synonyms()) || !reflect.DeepEqual(want[i].antonyms, thesaurusEntry.Antonyms()) {
+			t.Errorf("MergeThesaurus merged %q and %q into entry %d, want %q and %q", thesaurusEntry.Synonyms(), thesaurusEntry.Antonyms(), i, want[i].synonyms, want[i].antonyms)
+		}
+
+		if 1 != len(entry.Senses()) {
+			t.Errorf("MergeThesaurus changed the senses of entry %d", i)
+		}
+	}
+
+	// The given result isn't modified
+	if synonyms := result.Entries()[0].(ThesaurusEntry).Synonyms(); 1 != len(synonyms) {
+		t.Errorf("MergeThesaurus modified the given result's synonyms to %q", synonyms)
+	}
+}
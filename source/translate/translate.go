@@ -0,0 +1,130 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package translate provides a translation source via the MyMemory
+// translation API
+package translate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Rican7/define/internal/version"
+	"github.com/Rican7/define/source"
+)
+
+// Name defines the name of the source
+const Name = "MyMemory Translation API"
+
+const (
+	// baseURLString is the base URL for all MyMemory API interactions
+	baseURLString = "https://api.mymemory.translated.net/get"
+
+	// textParameter defines the HTTP parameter for the text to translate
+	textParameter = "q"
+
+	// languagePairParameter defines the HTTP parameter for the language pair
+	// to translate between, such as "en|fr"
+	languagePairParameter = "langpair"
+
+	httpRequestAcceptHeaderName    = "Accept"
+	httpRequestUserAgentHeaderName = "User-Agent"
+
+	jsonMIMEType = "application/json"
+
+	// successStatus is the status the API reports for successful translations
+	successStatus = "200"
+)
+
+// validMIMETypes is the list of valid response MIME types
+var validMIMETypes = []string{jsonMIMEType}
+
+// api is a struct containing a configured HTTP client for MyMemory operations
+type api struct {
+	httpClient *http.Client
+}
+
+// apiResult is a struct that defines the data structure for MyMemory API
+// results
+type apiResult struct {
+	ResponseData struct {
+		TranslatedText string
+	}
+
+	// The status is inconsistently typed (a number or a string)
+	ResponseStatus  interface{}
+	ResponseDetails string
+}
+
+// New returns a new MyMemory translation source
+func New(httpClient http.Client) source.Translator {
+	return &api{&httpClient}
+}
+
+// Name returns the name of the source
+func (g *api) Name() string {
+	return Name
+}
+
+// Translate takes a text string and translates it from the given language to
+// the given target language (by ISO 639-1 code, such as "fr")
+func (g *api) Translate(text string, from string, to string) (string, error) {
+	return g.TranslateContext(context.Background(), text, from, to)
+}
+
+// TranslateContext is like Translate, but cancels its request if the context
+// is done before it finishes
+func (g *api) TranslateContext(ctx context.Context, text string, from string, to string) (string, error) {
+	// Prepare our URL
+	requestURL, err := url.Parse(baseURLString)
+
+	if nil != err {
+		return "", err
+	}
+
+	queryParams := requestURL.Query()
+	queryParams.Set(textParameter, text)
+	queryParams.Set(languagePairParameter, from+"|"+to)
+	requestURL.RawQuery = queryParams.Encode()
+
+	httpRequest, err := http.NewRequest(http.MethodGet, requestURL.String(), nil)
+
+	if nil != err {
+		return "", err
+	}
+
+	httpRequest.Header.Set(httpRequestAcceptHeaderName, jsonMIMEType)
+	httpRequest.Header.Set(httpRequestUserAgentHeaderName, version.AppName+"/"+version.Name())
+
+	httpResponse, err := g.httpClient.Do(httpRequest.WithContext(ctx))
+
+	if nil != err {
+		return "", err
+	}
+
+	defer httpResponse.Body.Close()
+
+	if err = source.ValidateHTTPResponse(httpResponse, validMIMETypes, nil); nil != err {
+		return "", err
+	}
+
+	var result apiResult
+
+	if err = source.ReadJSONResponse(Name, httpResponse, &result); nil != err {
+		return "", err
+	}
+
+	if successStatus != fmt.Sprint(result.ResponseStatus) {
+		return "", fmt.Errorf("translation failed with error: %s", result.ResponseDetails)
+	}
+
+	translation := strings.TrimSpace(result.ResponseData.TranslatedText)
+
+	if "" == translation {
+		return "", fmt.Errorf("no translation of %q into %q was found", text, to)
+	}
+
+	return translation, nil
+}
@@ -0,0 +1,101 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package wdlexeme
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	flag "github.com/ogier/pflag"
+
+	"github.com/Rican7/define/internal/transport"
+	"github.com/Rican7/define/registry"
+	"github.com/Rican7/define/source"
+)
+
+type config struct {
+	Language string
+	Headers  map[string]string
+}
+
+type provider struct{}
+
+// JSONKey defines the JSON key used for the provider
+const JSONKey = "WikidataLexemes"
+
+// httpTransport is the transport of the HTTP clients of the provided sources,
+// which tests can replace with a stub (nil uses http.DefaultTransport)
+var httpTransport http.RoundTripper
+
+// defaultLanguage is the default language to query lexemes in
+const defaultLanguage = "en"
+
+func init() {
+	registry.Register(registry.RegisterFunc(register))
+}
+
+func register(flags *flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
+	return &provider{}, initConfig(flags)
+}
+
+func initConfig(flags *flag.FlagSet) *config {
+	conf := &config{}
+
+	// Define our flags
+	flags.StringVar(&conf.Language, "wikidata-lexeme-language", "", fmt.Sprintf("The language code (ISO 639-1) to query the %s in", Name))
+
+	return conf
+}
+
+func (c *config) JSONKey() string {
+	return JSONKey
+}
+
+// UnmarshalJSON defines how the configuration should be JSON unmarshalled.
+func (c *config) UnmarshalJSON(data []byte) error {
+	// Alias our type so that we can unmarshal as usual
+	type alias config
+	copy := &alias{}
+
+	// Unmarshal into our copy
+	err := json.Unmarshal(data, copy)
+
+	if nil != err {
+		return err
+	}
+
+	if "" == c.Language {
+		c.Language = copy.Language
+	}
+
+	if nil == c.Headers {
+		c.Headers = copy.Headers
+	}
+
+	return nil
+}
+
+func (c *config) Finalize() {
+	if "" == c.Language {
+		c.Language = defaultLanguage
+	}
+}
+
+func (p *provider) Name() string {
+	return Name
+}
+
+func (p *provider) Metadata() registry.Metadata {
+	return registry.Metadata{
+		Description:  "The lexicographical data of Wikidata, with the inflected forms of words",
+		Homepage:     "https://www.wikidata.org/wiki/Wikidata:Lexicographical_data",
+		Capabilities: []string{registry.CapabilityInflections},
+	}
+}
+
+func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
+	config := conf.(*config)
+
+	return New(http.Client{Transport: transport.WithHeaders(httpTransport, config.Headers)}, config.Language), nil
+}
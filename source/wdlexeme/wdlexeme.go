@@ -0,0 +1,240 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package wdlexeme provides a dictionary source via the Wikidata
+// Lexicographical Data, queried through the Wikidata SPARQL endpoint
+package wdlexeme
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Rican7/define/internal/version"
+	"github.com/Rican7/define/source"
+)
+
+// Name defines the name of the source
+const Name = "Wikidata Lexicographical Data"
+
+const (
+	// baseURLString is the base URL for all Wikidata SPARQL interactions
+	baseURLString = "https://query.wikidata.org/sparql"
+
+	// queryParameter defines the HTTP parameter for the SPARQL query
+	queryParameter = "query"
+
+	httpRequestAcceptHeaderName    = "Accept"
+	httpRequestUserAgentHeaderName = "User-Agent"
+
+	sparqlJSONMIMEType = "application/sparql-results+json"
+	jsonMIMEType       = "application/json"
+
+	// lexemeQueryFormat is the SPARQL query format used to find lexemes by
+	// their lemma in a given language (by ISO 639-1 code).
+	//
+	// Wikidata maps a lexeme's senses via "ontolex:sense" with glosses as
+	// "skos:definition", and its forms via "ontolex:lexicalForm" with written
+	// representations as "ontolex:representation".
+	lexemeQueryFormat = `SELECT ?lexeme ?lemma ?categoryLabel ?gloss ?formRepresentation WHERE {
+  ?language wdt:P218 "%[2]s" .
+  ?lexeme dct:language ?language ;
+          wikibase:lemma ?lemma ;
+          wikibase:lexicalCategory ?category .
+  FILTER(STR(?lemma) = "%[1]s")
+  OPTIONAL {
+    ?lexeme ontolex:sense ?sense .
+    ?sense skos:definition ?gloss .
+    FILTER(LANG(?gloss) = "%[2]s")
+  }
+  OPTIONAL {
+    ?lexeme ontolex:lexicalForm ?form .
+    ?form ontolex:representation ?formRepresentation .
+  }
+  SERVICE wikibase:label { bd:serviceParam wikibase:language "%[2]s,en" . }
+}`
+)
+
+// validMIMETypes is the list of valid response MIME types
+var validMIMETypes = []string{sparqlJSONMIMEType, jsonMIMEType}
+
+// sparqlStringEscaper escapes strings for use in SPARQL string literals
+var sparqlStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`)
+
+// api is a struct containing a configured HTTP client for Wikidata operations
+type api struct {
+	httpClient *http.Client
+	language   string
+}
+
+// apiResult is a struct that defines the data structure for SPARQL results
+type apiResult struct {
+	Results struct {
+		Bindings []map[string]struct {
+			Type  string
+			Value string
+		}
+	}
+}
+
+// lexeme is a struct that collects the data of a lexeme across result rows
+type lexeme struct {
+	lemma    string
+	category string
+	glosses  []string
+	forms    []string
+}
+
+// wdlexemeEntry is a struct that contains the entry types for this API
+type wdlexemeEntry struct {
+	source.WordEntryValue
+	source.DictionaryEntryValue
+	source.InflectionEntryValue
+}
+
+// New returns a new Wikidata lexeme dictionary source for the given language
+// (by ISO 639-1 code, such as "en")
+func New(httpClient http.Client, language string) source.Source {
+	return &api{&httpClient, language}
+}
+
+// Name returns the name of the source
+func (g *api) Name() string {
+	return Name
+}
+
+// Options returns the options that the source's results depend on
+func (g *api) Options() source.Options {
+	return source.Options{Language: g.language}
+}
+
+// Request returns the HTTP request used to define the given word
+func (g *api) Request(word string) (*http.Request, error) {
+	// Prepare our URL
+	requestURL, err := url.Parse(baseURLString)
+
+	if nil != err {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(lexemeQueryFormat, sparqlStringEscaper.Replace(word), sparqlStringEscaper.Replace(g.language))
+
+	queryParams := requestURL.Query()
+	queryParams.Set(queryParameter, query)
+	requestURL.RawQuery = queryParams.Encode()
+
+	httpRequest, err := http.NewRequest(http.MethodGet, requestURL.String(), nil)
+
+	if nil != err {
+		return nil, err
+	}
+
+	httpRequest.Header.Set(httpRequestAcceptHeaderName, sparqlJSONMIMEType)
+	httpRequest.Header.Set(httpRequestUserAgentHeaderName, version.AppName+"/"+version.Name())
+
+	return httpRequest, nil
+}
+
+// Define takes a word string and returns a dictionary source.Result
+func (g *api) Define(word string) (source.Result, error) {
+	return g.DefineContext(context.Background(), word)
+}
+
+// DefineContext takes a word string and returns a dictionary source.Result,
+// cancelling its request if the context is done before it finishes
+func (g *api) DefineContext(ctx context.Context, word string) (source.Result, error) {
+	httpRequest, err := g.Request(word)
+
+	if nil != err {
+		return nil, err
+	}
+
+	httpResponse, err := g.httpClient.Do(httpRequest.WithContext(ctx))
+
+	if nil != err {
+		return nil, err
+	}
+
+	defer httpResponse.Body.Close()
+
+	if err = source.ValidateHTTPResponse(httpResponse, validMIMETypes, nil); nil != err {
+		return nil, err
+	}
+
+	var result apiResult
+
+	if err = source.ReadJSONResponse(Name, httpResponse, &result); nil != err {
+		return nil, err
+	}
+
+	if len(result.Results.Bindings) < 1 {
+		return nil, &source.EmptyResultError{Word: word}
+	}
+
+	return source.ValidateAndReturnResult(result.toResult(g.language))
+}
+
+// toResult converts the SPARQL result to a generic source.Result
+func (r apiResult) toResult(language string) source.Result {
+	var lexemeIDs []string
+	lexemes := make(map[string]*lexeme)
+
+	// Each row is a combination of a lexeme's senses and forms, so collect
+	// the unique values of each lexeme across all of the rows
+	for _, binding := range r.Results.Bindings {
+		id := binding["lexeme"].Value
+
+		lex, exists := lexemes[id]
+
+		if !exists {
+			lex = &lexeme{lemma: binding["lemma"].Value, category: binding["categoryLabel"].Value}
+			lexemes[id] = lex
+			lexemeIDs = append(lexemeIDs, id)
+		}
+
+		lex.glosses = appendUnique(lex.glosses, binding["gloss"].Value)
+
+		if form := binding["formRepresentation"].Value; form != lex.lemma {
+			lex.forms = appendUnique(lex.forms, form)
+		}
+	}
+
+	entries := make([]interface{}, 0, len(lexemeIDs))
+
+	for _, id := range lexemeIDs {
+		lex := lexemes[id]
+		entry := wdlexemeEntry{}
+
+		entry.WordVal = lex.lemma
+		entry.CategoryVal = lex.category
+		entry.InflectionVals = lex.forms
+
+		for _, gloss := range lex.glosses {
+			entry.SenseVals = append(entry.SenseVals, source.SenseValue{DefinitionVals: []string{gloss}})
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return source.ResultValue{
+		Head:      lexemes[lexemeIDs[0]].lemma,
+		Lang:      language,
+		EntryVals: entries,
+	}
+}
+
+// appendUnique appends a non-empty value to a list, if not already present
+func appendUnique(list []string, value string) []string {
+	if "" == value {
+		return list
+	}
+
+	for _, existing := range list {
+		if existing == value {
+			return list
+		}
+	}
+
+	return append(list, value)
+}
@@ -0,0 +1,152 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package wdlexeme
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+// sparqlFixture is a SPARQL JSON response of two lexemes, with a row for each
+// combination of their senses and forms
+const sparqlFixture = `{
+	"head": {"vars": ["lexeme", "lemma", "categoryLabel", "gloss", "formRepresentation"]},
+	"results": {"bindings": [
+		{
+			"lexeme": {"type": "uri", "value": "http://www.wikidata.org/entity/L3257"},
+			"lemma": {"type": "literal", "xml:lang": "en", "value": "run"},
+			"categoryLabel": {"type": "literal", "xml:lang": "en", "value": "verb"},
+			"gloss": {"type": "literal", "xml:lang": "en", "value": "to move swiftly on foot"},
+			"formRepresentation": {"type": "literal", "xml:lang": "en", "value": "run"}
+		},
+		{
+			"lexeme": {"type": "uri", "value": "http://www.wikidata.org/entity/L3257"},
+			"lemma": {"type": "literal", "xml:lang": "en", "value": "run"},
+			"categoryLabel": {"type": "literal", "xml:lang": "en", "value": "verb"},
+			"gloss": {"type": "literal", "xml:lang": "en", "value": "to move swiftly on foot"},
+			"formRepresentation": {"type": "literal", "xml:lang": "en", "value": "ran"}
+		},
+		{
+			"lexeme": {"type": "uri", "value": "http://www.wikidata.org/entity/L3257"},
+			"lemma": {"type": "literal", "xml:lang": "en", "value": "run"},
+			"categoryLabel": {"type": "literal", "xml:lang": "en", "value": "verb"},
+			"gloss": {"type": "literal", "xml:lang": "en", "value": "to operate a machine"},
+			"formRepresentation": {"type": "literal", "xml:lang": "en", "value": "running"}
+		},
+		{
+			"lexeme": {"type": "uri", "value": "http://www.wikidata.org/entity/L3258"},
+			"lemma": {"type": "literal", "xml:lang": "en", "value": "run"},
+			"categoryLabel": {"type": "literal", "xml:lang": "en", "value": "noun"},
+			"gloss": {"type": "literal", "xml:lang": "en", "value": "an act of running"}
+		}
+	]}
+}`
+
+// serverTransport is an http.RoundTripper that sends every request to a test
+// server instead of its own host, recording the last query that it sent
+type serverTransport struct {
+	server *httptest.Server
+	query  url.Values
+}
+
+func (t *serverTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	serverURL, _ := url.Parse(t.server.URL)
+
+	redirected := request.Clone(request.Context())
+	redirected.URL.Scheme = serverURL.Scheme
+	redirected.URL.Host = serverURL.Host
+	redirected.Host = serverURL.Host
+
+	t.query = redirected.URL.Query()
+
+	return t.server.Client().Transport.RoundTrip(redirected)
+}
+
+// newSPARQLServer starts a server that responds to every request with the
+// given status code and body
+func newSPARQLServer(statusCode int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", sparqlJSONMIMEType)
+		w.WriteHeader(statusCode)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestDefine(t *testing.T) {
+	server := newSPARQLServer(http.StatusOK, sparqlFixture)
+	defer server.Close()
+
+	transport := &serverTransport{server: server}
+	src := New(http.Client{Transport: transport}, "en")
+
+	result, err := src.Define("run")
+
+	if nil != err {
+		t.Fatalf("Define returned error %q", err)
+	}
+
+	if query := transport.query.Get(queryParameter); !strings.Contains(query, `FILTER(STR(?lemma) = "run")`) || !strings.Contains(query, `wdt:P218 "en"`) {
+		t.Errorf("Define sent the query %q", query)
+	}
+
+	if "run" != result.Headword() || "en" != result.Language() || 2 != len(result.Entries()) {
+		t.Fatalf("Define returned the headword %q, language %q, and %d entries", result.Headword(), result.Language(), len(result.Entries()))
+	}
+
+	testData := []struct {
+		category        string
+		wantDefinitions []string
+		wantInflections []string
+	}{
+		{"verb", []string{"to move swiftly on foot", "to operate a machine"}, []string{"ran", "running"}},
+		{"noun", []string{"an act of running"}, nil},
+	}
+
+	for i, data := range testData {
+		entry := result.Entries()[i]
+
+		if "run" != entry.(source.WordEntry).Word() || data.category != entry.(source.WordEntry).Category() {
+			t.Errorf("Define returned the entry %d of the word %q and category %q", i, entry.(source.WordEntry).Word(), entry.(source.WordEntry).Category())
+		}
+
+		var definitions []string
+
+		for _, sense := range entry.Senses() {
+			definitions = append(definitions, sense.Definitions()...)
+		}
+
+		if !reflect.DeepEqual(data.wantDefinitions, definitions) {
+			t.Errorf("Define returned the %s definitions %q, want %q", data.category, definitions, data.wantDefinitions)
+		}
+
+		if inflections := entry.(source.InflectionEntry).Inflections(); !reflect.DeepEqual(data.wantInflections, inflections) {
+			t.Errorf("Define returned the %s inflections %q, want %q", data.category, inflections, data.wantInflections)
+		}
+	}
+}
+
+func TestDefineEmpty(t *testing.T) {
+	server := newSPARQLServer(http.StatusOK, `{"head": {"vars": []}, "results": {"bindings": []}}`)
+	defer server.Close()
+
+	_, err := New(http.Client{Transport: &serverTransport{server: server}}, "en").Define("run")
+
+	if !reflect.DeepEqual(&source.EmptyResultError{Word: "run"}, err) {
+		t.Errorf("Define returned error %v, want a *source.EmptyResultError", err)
+	}
+}
+
+func TestDefineHTTPError(t *testing.T) {
+	server := newSPARQLServer(http.StatusInternalServerError, `{}`)
+	defer server.Close()
+
+	if _, err := New(http.Client{Transport: &serverTransport{server: server}}, "en").Define("run"); nil == err {
+		t.Error("Define didn't return an error for an HTTP error")
+	}
+}
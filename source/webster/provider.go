@@ -6,10 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
 
 	flag "github.com/ogier/pflag"
 
+	"github.com/Rican7/define/internal/keyring"
+	"github.com/Rican7/define/internal/transport"
 	"github.com/Rican7/define/registry"
 	"github.com/Rican7/define/source"
 )
@@ -21,7 +22,11 @@ type RequiredConfigError struct {
 }
 
 type config struct {
-	AppKey string
+	AppKey  string
+	Headers map[string]string
+
+	// Whether values were loaded from the keyring
+	appKeyInKeyring bool
 }
 
 type provider struct{}
@@ -29,6 +34,21 @@ type provider struct{}
 // JSONKey defines the JSON key used for the provider
 const JSONKey = "MerriamWebsterDictionary"
 
+// httpTransport is the transport of the HTTP clients of the provided sources,
+// which tests can replace with a stub (nil uses http.DefaultTransport)
+var httpTransport http.RoundTripper
+
+// Flag names, which also serve as the names of secrets in the keyring
+const (
+	appKeyFlagName = "merriam-webster-dictionary-app-key"
+)
+
+// envVars is the list of environment variables that the configuration reads
+// its values from
+var envVars = []registry.EnvVar{
+	{Name: "DEFINE_WEBSTER_APP_KEY", Key: "AppKey", Aliases: []string{"MERRIAM_WEBSTER_DICTIONARY_APP_KEY"}},
+}
+
 func init() {
 	registry.Register(registry.RegisterFunc(register))
 }
@@ -41,7 +61,7 @@ func initConfig(flags *flag.FlagSet) *config {
 	conf := &config{}
 
 	// Define our flags
-	flags.StringVar(&conf.AppKey, "merriam-webster-dictionary-app-key", "", fmt.Sprintf("The app key for the %s", Name))
+	flags.StringVar(&conf.AppKey, appKeyFlagName, "", fmt.Sprintf("The app key for the %s", Name))
 
 	return conf
 }
@@ -54,6 +74,20 @@ func (c *config) JSONKey() string {
 	return JSONKey
 }
 
+// MarshalJSON defines how the configuration should be JSON marshalled.
+func (c *config) MarshalJSON() ([]byte, error) {
+	// Alias our type so that we can marshal as usual
+	type alias config
+	copy := alias(*c)
+
+	// Never output secrets that are stored in the keyring
+	if c.appKeyInKeyring {
+		copy.AppKey = keyring.Placeholder
+	}
+
+	return json.Marshal(copy)
+}
+
 // UnmarshalJSON defines how the configuration should be JSON unmarshalled.
 func (c *config) UnmarshalJSON(data []byte) error {
 	// Alias our type so that we can unmarshal as usual
@@ -67,16 +101,20 @@ func (c *config) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	if "" == c.AppKey {
+	if "" == c.AppKey && keyring.Placeholder != copy.AppKey {
 		c.AppKey = copy.AppKey
 	}
 
+	if nil == c.Headers {
+		c.Headers = copy.Headers
+	}
+
 	return nil
 }
 
 func (c *config) Finalize() {
 	if "" == c.AppKey {
-		c.AppKey = os.Getenv("MERRIAM_WEBSTER_DICTIONARY_APP_KEY")
+		c.AppKey, c.appKeyInKeyring = keyring.Lookup(appKeyFlagName)
 	}
 }
 
@@ -84,6 +122,19 @@ func (p *provider) Name() string {
 	return Name
 }
 
+func (p *provider) Metadata() registry.Metadata {
+	return registry.Metadata{
+		Description: "Merriam-Webster's Collegiate Dictionary",
+		Homepage:    "https://www.dictionaryapi.com/",
+		SignupURL:   "https://www.dictionaryapi.com/register/index.htm",
+		RequiredKeys: []registry.RequiredKey{
+			{Name: "AppKey", FlagName: appKeyFlagName},
+		},
+		Capabilities: []string{registry.CapabilityPronunciations, registry.CapabilityExamples, registry.CapabilityEtymologies, registry.CapabilitySuggestions},
+		EnvVars:      envVars,
+	}
+}
+
 func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
 	config := conf.(*config)
 
@@ -91,5 +142,5 @@ func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
 		return nil, &RequiredConfigError{Key: "AppKey"}
 	}
 
-	return New(http.Client{}, config.AppKey), nil
+	return New(http.Client{Transport: transport.WithHeaders(httpTransport, config.Headers)}, config.AppKey), nil
 }
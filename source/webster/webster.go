@@ -4,9 +4,10 @@
 package webster
 
 import (
+	"context"
 	"encoding/xml"
+	"fmt"
 	"html"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -24,7 +25,16 @@ const (
 	// baseURLString is the base URL for all Webster API interactions
 	baseURLString = "http://www.dictionaryapi.com/api/v1/"
 
-	entriesURLString = baseURLString + "references/collegiate/xml/"
+	// entriesURLFormat is the format of the URL of a reference's entries
+	entriesURLFormat = baseURLString + "references/%s/xml/"
+
+	// collegiateReference is the reference of Merriam-Webster's Collegiate
+	// Dictionary
+	collegiateReference = "collegiate"
+
+	// collegiateWebURLString is the base URL of the web pages of the entries
+	// of Merriam-Webster's Collegiate Dictionary
+	collegiateWebURLString = "https://www.merriam-webster.com/dictionary/"
 
 	httpRequestAcceptHeaderName     = "Accept"
 	httpRequestAppKeyQueryParamName = "key"
@@ -62,6 +72,8 @@ var etymologyMetaStripperRegex = regexp.MustCompile("<ma>.*?</ma>")
 type api struct {
 	httpClient *http.Client
 	appKey     string
+	reference  string
+	name       string
 }
 
 // apiResult defines the data structure for Webster API results
@@ -74,6 +86,7 @@ type apiResult struct {
 		Etymologies          []cleanableString        `xml:"et"`
 		DefinitionContainers []apiDefinitionContainer `xml:"def"`
 	} `xml:"entry"`
+	Suggestions []string `xml:"suggestion"`
 }
 
 // apiDefinitionContainer defines the data structure for Oxford API definitions
@@ -153,18 +166,36 @@ func init() {
 
 // New returns a new Webster API dictionary source
 func New(httpClient http.Client, appKey string) source.Source {
-	return &api{&httpClient, appKey}
+	return NewReference(httpClient, appKey, collegiateReference, Name)
+}
+
+// NewReference returns a new Webster API dictionary source for the given
+// reference (such as "collegiate" or "sd2"), named by the given name.
+//
+// Each of the Webster API's references requires an app key registered for it.
+func NewReference(httpClient http.Client, appKey string, reference string, name string) source.Source {
+	return &api{&httpClient, appKey, reference, name}
 }
 
 // Name returns the name of the source
 func (g *api) Name() string {
-	return Name
+	return g.name
 }
 
-// Define takes a word string and returns a dictionary source.Result
-func (g *api) Define(word string) (source.Result, error) {
+// WebURL returns the URL of the web page for the given word, if the source's
+// reference has web pages
+func (g *api) WebURL(word string) string {
+	if collegiateReference != g.reference {
+		return ""
+	}
+
+	return collegiateWebURLString + url.PathEscape(word)
+}
+
+// Request returns the HTTP request used to define the given word
+func (g *api) Request(word string) (*http.Request, error) {
 	// Prepare our URL
-	requestURL, err := url.Parse(entriesURLString + word)
+	requestURL, err := url.Parse(fmt.Sprintf(entriesURLFormat, g.reference) + word)
 	queryParams := apiURL.Query()
 	queryParams.Set(httpRequestAppKeyQueryParamName, g.appKey)
 	requestURL.RawQuery = queryParams.Encode()
@@ -183,32 +214,43 @@ func (g *api) Define(word string) (source.Result, error) {
 	httpRequest.Header.Add(httpRequestAcceptHeaderName, xmlTextMIMEType)
 	httpRequest.Header.Add(httpRequestAcceptHeaderName, xmlBaseMIMEType)
 
-	httpResponse, err := g.httpClient.Do(httpRequest)
+	return httpRequest, nil
+}
+
+// Define takes a word string and returns a dictionary source.Result
+func (g *api) Define(word string) (source.Result, error) {
+	return g.DefineContext(context.Background(), word)
+}
+
+// DefineContext takes a word string and returns a dictionary source.Result,
+// cancelling its request if the context is done before it finishes
+func (g *api) DefineContext(ctx context.Context, word string) (source.Result, error) {
+	httpRequest, err := g.Request(word)
 
 	if nil != err {
 		return nil, err
 	}
 
-	defer httpResponse.Body.Close()
+	httpResponse, err := g.httpClient.Do(httpRequest.WithContext(ctx))
 
-	if err = source.ValidateHTTPResponse(httpResponse, validMIMETypes, nil); nil != err {
+	if nil != err {
 		return nil, err
 	}
 
-	body, err := ioutil.ReadAll(httpResponse.Body)
+	defer httpResponse.Body.Close()
 
-	if nil != err {
+	if err = source.ValidateHTTPResponse(httpResponse, validMIMETypes, nil); nil != err {
 		return nil, err
 	}
 
 	var result apiResult
 
-	if err = xml.Unmarshal(body, &result); nil != err {
+	if err = source.ReadXMLResponse(g.name, httpResponse, &result); nil != err {
 		return nil, err
 	}
 
 	if len(result.Entries) < 1 {
-		return nil, &source.EmptyResultError{Word: word}
+		return nil, &source.EmptyResultError{Word: word, Suggestions: result.Suggestions}
 	}
 
 	return source.ValidateAndReturnResult(result.toResult())
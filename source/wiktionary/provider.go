@@ -0,0 +1,119 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package wiktionary
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	flag "github.com/ogier/pflag"
+
+	"github.com/Rican7/define/internal/transport"
+	"github.com/Rican7/define/registry"
+	"github.com/Rican7/define/source"
+)
+
+type config struct {
+	Language string
+	Rich     bool
+	Headers  map[string]string
+}
+
+type provider struct{}
+
+// JSONKey defines the JSON key used for the provider
+const JSONKey = "Wiktionary"
+
+// httpTransport is the transport of the HTTP clients of the provided sources,
+// which tests can replace with a stub (nil uses http.DefaultTransport)
+var httpTransport http.RoundTripper
+
+// defaultLanguage is the default language section to define words in
+const defaultLanguage = "en"
+
+// envVars is the list of environment variables that the configuration reads
+// its values from
+var envVars = []registry.EnvVar{
+	{Name: "DEFINE_WIKTIONARY_LANGUAGE", Key: "Language", Aliases: []string{"WIKTIONARY_LANGUAGE"}},
+	{Name: "DEFINE_WIKTIONARY_RICH", Key: "Rich"},
+}
+
+func init() {
+	registry.Register(registry.RegisterFunc(register))
+}
+
+func register(flags *flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
+	return &provider{}, initConfig(flags)
+}
+
+func initConfig(flags *flag.FlagSet) *config {
+	conf := &config{}
+
+	// Define our flags
+	flags.StringVar(&conf.Language, "lang", "", fmt.Sprintf("The language (code or name) of the %s sections to define words in, or %q", Name, AllLanguages))
+	flags.BoolVar(&conf.Rich, "rich", false, fmt.Sprintf("To also parse the etymologies and pronunciations of %s pages from their wikitext (slower, and heuristic)", Name))
+
+	return conf
+}
+
+func (c *config) JSONKey() string {
+	return JSONKey
+}
+
+// UnmarshalJSON defines how the configuration should be JSON unmarshalled.
+func (c *config) UnmarshalJSON(data []byte) error {
+	// Alias our type so that we can unmarshal as usual
+	type alias config
+	copy := &alias{}
+
+	// Unmarshal into our copy
+	err := json.Unmarshal(data, copy)
+
+	if nil != err {
+		return err
+	}
+
+	if "" == c.Language {
+		c.Language = copy.Language
+	}
+
+	if !c.Rich {
+		c.Rich = copy.Rich
+	}
+
+	if nil == c.Headers {
+		c.Headers = copy.Headers
+	}
+
+	return nil
+}
+
+func (c *config) Finalize() {
+	if "" == c.Language {
+		c.Language = defaultLanguage
+	}
+}
+
+func (p *provider) Name() string {
+	return Name
+}
+
+func (p *provider) Metadata() registry.Metadata {
+	return registry.Metadata{
+		Description:  "The free, collaborative dictionary of words in every language",
+		Homepage:     "https://en.wiktionary.org/",
+		Capabilities: []string{registry.CapabilityExamples, registry.CapabilityMultilingual},
+		EnvVars:      envVars,
+	}
+}
+
+func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
+	config := conf.(*config)
+
+	if config.Rich {
+		return NewRich(http.Client{Transport: transport.WithHeaders(httpTransport, config.Headers)}, config.Language), nil
+	}
+
+	return New(http.Client{Transport: transport.WithHeaders(httpTransport, config.Headers)}, config.Language), nil
+}
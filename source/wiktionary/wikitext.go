@@ -0,0 +1,479 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package wiktionary
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Rican7/define/source"
+)
+
+// wikitextSection is a part of speech (or other) section of a language's
+// section of a page's wikitext, with the etymology and pronunciations that
+// apply to it
+type wikitextSection struct {
+	heading        string
+	etymology      string
+	pronunciations []source.RegionalPronunciation
+}
+
+// wikitextTemplate is a template of wikitext (such as "{{IPA|en|/ɹɛd/}}"),
+// with its positional and named arguments
+type wikitextTemplate struct {
+	name       string
+	positional []string
+	named      map[string]string
+}
+
+var (
+	// headingPattern matches a heading line of wikitext, such as "===Noun==="
+	headingPattern = regexp.MustCompile(`^(={2,6})\s*(.*?)\s*(={2,6})\s*$`)
+
+	// ignoredMarkupPattern matches the markup of wikitext that's never shown
+	// as text, such as comments and references
+	ignoredMarkupPattern = regexp.MustCompile(`(?s)<!--.*?-->|<ref[^>]*/>|<ref[^>]*>.*?</ref>`)
+
+	// tagPattern matches the HTML tags of wikitext
+	tagPattern = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+)
+
+// The names of the templates of etymologies, by how their terms are rendered
+var (
+	// derivationTemplates are given a language, a source language, and then
+	// a term
+	derivationTemplates = []string{
+		"inh", "inh+", "inherited", "der", "der+", "derived", "bor", "bor+", "borrowed",
+		"lbor", "learned borrowing", "slbor", "obor", "ubor", "uder", "cal", "calque", "sl", "psm",
+	}
+
+	// mentionTemplates are given a language and then a term
+	mentionTemplates = []string{"m", "m+", "mention", "l", "link", "ll", "cog", "cognate", "nc", "noncog", "langname-lite"}
+
+	// affixTemplates are given a language and then the parts of a term
+	affixTemplates = []string{"af", "affix", "com", "compound", "pre", "prefix", "suf", "suffix", "con", "confix", "blend", "univerbation"}
+)
+
+// parseWikitext parses the wikitext of a page into the sections of each of its
+// languages, by the languages' names. An etymology applies to the sections
+// that follow it, up until the next etymology, while pronunciations apply up
+// until the next heading of a shallower level (such as that of the next
+// etymology, when each etymology has its own pronunciations).
+func parseWikitext(wikitext string) map[string][]wikitextSection {
+	languages := make(map[string][]wikitextSection)
+
+	var language, heading, etymology string
+	var pronunciations []source.RegionalPronunciation
+	var pronunciationsLevel int
+	var lines []string
+
+	// flush applies the lines of the heading that's ending
+	flush := func() {
+		switch {
+		case isEtymologyHeading(heading):
+			etymology = parseEtymology(lines)
+		case isPronunciationHeading(heading):
+			pronunciations = parsePronunciations(lines)
+		}
+
+		lines = nil
+	}
+
+	wikitext = ignoredMarkupPattern.ReplaceAllString(wikitext, "")
+
+	for _, line := range strings.Split(wikitext, "\n") {
+		match := headingPattern.FindStringSubmatch(line)
+
+		if nil == match {
+			lines = append(lines, line)
+			continue
+		}
+
+		flush()
+
+		heading = match[2]
+		level := len(match[1])
+
+		if len(match[3]) < level {
+			level = len(match[3])
+		}
+
+		switch {
+		case 2 == level:
+			language = heading
+			etymology = ""
+			pronunciations, pronunciationsLevel = nil, 0
+		case isEtymologyHeading(heading):
+			etymology = ""
+
+			// The pronunciations of a previous etymology don't apply to this
+			// one, while those shared by every etymology do
+			if level < pronunciationsLevel {
+				pronunciations, pronunciationsLevel = nil, 0
+			}
+		case isPronunciationHeading(heading):
+			pronunciations, pronunciationsLevel = nil, level
+		case "" != language:
+			languages[language] = append(languages[language], wikitextSection{
+				heading:        heading,
+				etymology:      etymology,
+				pronunciations: pronunciations,
+			})
+		}
+	}
+
+	return languages
+}
+
+// isEtymologyHeading returns whether a heading is of an etymology, such as
+// "Etymology" or "Etymology 2"
+func isEtymologyHeading(heading string) bool {
+	return strings.HasPrefix(heading, "Etymology")
+}
+
+// isPronunciationHeading returns whether a heading is of pronunciations
+func isPronunciationHeading(heading string) bool {
+	return strings.HasPrefix(heading, "Pronunciation")
+}
+
+// parseEtymology parses the lines of an etymology section into its text,
+// skipping any lists (such as of related terms)
+func parseEtymology(lines []string) string {
+	var paragraphs []string
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+
+		if "" == line || strings.ContainsAny(line[:1], "*#:") || strings.HasPrefix(line, "{|") {
+			continue
+		}
+
+		if rendered := renderWikitext(line); "" != rendered {
+			paragraphs = append(paragraphs, rendered)
+		}
+	}
+
+	return strings.Join(paragraphs, " ")
+}
+
+// parsePronunciations parses the lines of a pronunciation section into the
+// pronunciations of its IPA templates (such as "{{IPA|en|/ɹɛd/|a=US}}"). A
+// pronunciation's region is given by the template, or else by an accent
+// template (such as "{{a|US}}") earlier in the line.
+func parsePronunciations(lines []string) []source.RegionalPronunciation {
+	var pronunciations []source.RegionalPronunciation
+
+	for _, line := range lines {
+		var lineRegion string
+
+		for _, template := range parseTemplates(line) {
+			switch template.name {
+			case "a", "accent":
+				lineRegion = renderRegion(template.positional)
+			case "IPA":
+				// The first argument is the language
+				for i, transcription := range template.positional {
+					transcription = strings.TrimSpace(transcription)
+
+					// Only the phonemic (rather than phonetic, such as
+					// "[ˈɹɛd]") transcriptions are comparable across sources
+					if 0 == i || !strings.HasPrefix(transcription, "/") {
+						continue
+					}
+
+					region := template.named["a"+strconv.Itoa(i)]
+
+					if "" == region {
+						region = template.named["a"]
+					}
+
+					if "" == region {
+						region = lineRegion
+					} else {
+						region = renderRegion(strings.Split(region, ","))
+					}
+
+					pronunciations = append(pronunciations, source.RegionalPronunciation{
+						Transcription: strings.Trim(transcription, "/"),
+						Region:        region,
+					})
+				}
+			}
+		}
+	}
+
+	return pronunciations
+}
+
+// renderRegion renders the given region labels of an accent (such as "US")
+func renderRegion(labels []string) string {
+	var rendered []string
+
+	for _, label := range labels {
+		if label = strings.TrimSpace(label); "" != label {
+			rendered = append(rendered, label)
+		}
+	}
+
+	return strings.Join(rendered, ", ")
+}
+
+// renderWikitext renders wikitext as plain text, with its templates rendered
+// heuristically (those that aren't known are removed), and its links and
+// formatting removed. Malformed markup is removed, rather than rendered.
+func renderWikitext(text string) string {
+	var rendered strings.Builder
+
+	for i := 0; i < len(text); {
+		switch {
+		case strings.HasPrefix(text[i:], "{{"):
+			end := closingIndex(text, i, "{{", "}}")
+
+			// An unclosed template hides the rest of the text
+			if end < 0 {
+				i = len(text)
+				break
+			}
+
+			rendered.WriteString(renderTemplate(newWikitextTemplate(text[i+2 : end])))
+			i = end + 2
+		case strings.HasPrefix(text[i:], "[["):
+			end := closingIndex(text, i, "[[", "]]")
+
+			if end < 0 {
+				i += 2
+				break
+			}
+
+			rendered.WriteString(renderLink(text[i+2 : end]))
+			i = end + 2
+		default:
+			rendered.WriteByte(text[i])
+			i++
+		}
+	}
+
+	plain := strings.Replace(rendered.String(), "'''", "", -1)
+	plain = strings.Replace(plain, "''", "", -1)
+	plain = tagPattern.ReplaceAllString(plain, "")
+
+	return strings.Join(strings.Fields(plain), " ")
+}
+
+// parseTemplates returns the templates at the top level of the given wikitext
+func parseTemplates(text string) []wikitextTemplate {
+	var templates []wikitextTemplate
+
+	for i := strings.Index(text, "{{"); 0 <= i; {
+		end := closingIndex(text, i, "{{", "}}")
+
+		if end < 0 {
+			break
+		}
+
+		templates = append(templates, newWikitextTemplate(text[i+2:end]))
+
+		next := strings.Index(text[end+2:], "{{")
+
+		if next < 0 {
+			break
+		}
+
+		i = end + 2 + next
+	}
+
+	return templates
+}
+
+// newWikitextTemplate returns the template of the given contents, between its
+// braces, with its arguments rendered as plain text
+func newWikitextTemplate(contents string) wikitextTemplate {
+	parts := splitArguments(contents)
+	template := wikitextTemplate{name: strings.TrimSpace(parts[0]), named: make(map[string]string)}
+
+	for _, part := range parts[1:] {
+		if equals := strings.Index(part, "="); 0 < equals && !strings.ContainsAny(part[:equals], " {[") {
+			template.named[strings.TrimSpace(part[:equals])] = renderWikitext(part[equals+1:])
+			continue
+		}
+
+		template.positional = append(template.positional, renderWikitext(part))
+	}
+
+	return template
+}
+
+// renderTemplate renders a template of an etymology as plain text, or as an
+// empty string if it isn't known
+func renderTemplate(template wikitextTemplate) string {
+	argument := func(i int) string {
+		if i < len(template.positional) {
+			return template.positional[i]
+		}
+
+		return ""
+	}
+
+	// A term is rendered by its alternative display form, if given
+	term := func(i int) string {
+		if alternative := argument(i + 1); "" != alternative {
+			return alternative
+		}
+
+		if "-" == argument(i) {
+			return ""
+		}
+
+		return argument(i)
+	}
+
+	switch {
+	case isAnyOf(template.name, derivationTemplates):
+		return term(2)
+	case isAnyOf(template.name, mentionTemplates):
+		return term(1)
+	case isAnyOf(template.name, affixTemplates):
+		if len(template.positional) < 2 {
+			return ""
+		}
+
+		return strings.Join(template.positional[1:], " + ")
+	case isAnyOf(template.name, []string{"gloss", "gl"}):
+		return "(" + argument(0) + ")"
+	case isAnyOf(template.name, []string{"q", "qual", "qualifier", "i"}):
+		return "(" + strings.Join(template.positional, ", ") + ")"
+	case isAnyOf(template.name, []string{"w", "lang"}):
+		if "lang" == template.name {
+			return argument(1)
+		}
+
+		if "" != argument(1) {
+			return argument(1)
+		}
+
+		return argument(0)
+	}
+
+	return ""
+}
+
+// renderLink renders the contents of a link, between its brackets, as its
+// displayed text (files and categories aren't displayed)
+func renderLink(contents string) string {
+	parts := splitArguments(contents)
+
+	if strings.Contains(parts[0], ":") && !strings.HasPrefix(parts[0], "w:") {
+		prefix := strings.ToLower(parts[0][:strings.Index(parts[0], ":")])
+
+		if "file" == prefix || "image" == prefix || "category" == prefix {
+			return ""
+		}
+	}
+
+	if 1 < len(parts) {
+		return renderWikitext(parts[len(parts)-1])
+	}
+
+	target := strings.TrimPrefix(parts[0], "w:")
+
+	if anchor := strings.Index(target, "#"); 0 <= anchor {
+		target = target[:anchor]
+	}
+
+	return renderWikitext(target)
+}
+
+// splitArguments splits the contents of a template or link by the pipes that
+// aren't within nested templates or links
+func splitArguments(contents string) []string {
+	var arguments []string
+	depth, start := 0, 0
+
+	for i := 0; i < len(contents); i++ {
+		switch {
+		case strings.HasPrefix(contents[i:], "{{") || strings.HasPrefix(contents[i:], "[["):
+			depth++
+			i++
+		case (strings.HasPrefix(contents[i:], "}}") || strings.HasPrefix(contents[i:], "]]")) && 0 < depth:
+			depth--
+			i++
+		case '|' == contents[i] && 0 == depth:
+			arguments = append(arguments, contents[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(arguments, contents[start:])
+}
+
+// closingIndex returns the index of the closing delimiter that matches the
+// opening one at the given index of the text, accounting for nesting, or -1 if
+// it isn't closed
+func closingIndex(text string, start int, opening string, closing string) int {
+	depth := 0
+
+	for i := start; i < len(text); {
+		switch {
+		case strings.HasPrefix(text[i:], opening):
+			depth++
+			i += len(opening)
+		case strings.HasPrefix(text[i:], closing):
+			if depth--; 0 == depth {
+				return i
+			}
+
+			i += len(closing)
+		default:
+			i++
+		}
+	}
+
+	return -1
+}
+
+// isAnyOf returns whether a name is any of the given names
+func isAnyOf(name string, names []string) bool {
+	for _, candidate := range names {
+		if name == candidate {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addWikitextDetails adds the etymologies and pronunciations of the parsed
+// wikitext sections to the result's entries. The entries of each language
+// are matched to the sections of the same part of speech, in order.
+func addWikitextDetails(result source.ResultValue, languages map[string][]wikitextSection) source.ResultValue {
+	next := make(map[string]int)
+
+	for i, value := range result.EntryVals {
+		entry := value.(wiktionaryEntry)
+		sections := languages[entry.LanguageVal]
+
+		for j := next[entry.LanguageVal]; j < len(sections); j++ {
+			if !strings.EqualFold(sections[j].heading, entry.CategoryVal) {
+				continue
+			}
+
+			next[entry.LanguageVal] = j + 1
+
+			if "" != sections[j].etymology {
+				entry.EtymologyVals = []string{sections[j].etymology}
+			}
+
+			if 0 < len(sections[j].pronunciations) {
+				entry.PronunciationVals = sections[j].pronunciations
+				entry.PronunciationVal = sections[j].pronunciations[0].Transcription
+			}
+
+			result.EntryVals[i] = entry
+
+			break
+		}
+	}
+
+	return result
+}
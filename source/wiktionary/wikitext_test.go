@@ -0,0 +1,120 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package wiktionary
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Rican7/define/source"
+)
+
+const testWikitext = `{{also|Red}}
+==English==
+{{wikipedia}}
+
+===Pronunciation===
+* {{a|UK}} {{IPA|en|/ɹɛd/|[ɹɛd]}}
+* {{IPA|en|/ɹɛd/|a=US}}
+
+===Etymology 1===
+From {{inh|en|enm|red}}, from {{inh|en|ang|rēad}}<ref>{{R:OED}}</ref>, from {{der|en|gem-pro|*raudaz}}. Compare {{cog|de|rot||red}}.
+
+====Adjective====
+{{en-adj|redder}}
+
+# Having a [[color]] like [[blood|that of blood]].
+
+====Noun====
+# The color of blood.
+
+===Etymology 2===
+{{af|en|re-|do}} (a [[w:Clipping|clipping]]) <!-- a comment -->
+
+====Pronunciation====
+* {{IPA|en|/ɹiː/|/ɹɪ/|a1=UK,US}}
+
+====Verb====
+# {{lb|en|dialectal}} To do again.
+
+==French==
+
+===Etymology===
+From {{inh|fr|la|rēte
+
+===Noun===
+# [[net]]
+`
+
+func TestParseWikitext(t *testing.T) {
+	shared := []source.RegionalPronunciation{{Transcription: "ɹɛd", Region: "UK"}, {Transcription: "ɹɛd", Region: "US"}}
+	redo := []source.RegionalPronunciation{{Transcription: "ɹiː", Region: "UK, US"}, {Transcription: "ɹɪ"}}
+	firstEtymology := "From red, from rēad, from *raudaz. Compare rot."
+
+	want := map[string][]wikitextSection{
+		"English": {
+			{heading: "Adjective", etymology: firstEtymology, pronunciations: shared},
+			{heading: "Noun", etymology: firstEtymology, pronunciations: shared},
+			{heading: "Verb", etymology: "re- + do (a clipping)", pronunciations: redo},
+		},
+		"French": {
+			// The unclosed template hides the rest of the etymology
+			{heading: "Noun", etymology: "From"},
+		},
+	}
+
+	if got := parseWikitext(testWikitext); !reflect.DeepEqual(want, got) {
+		t.Errorf("parseWikitext returned %#v, want %#v", got, want)
+	}
+}
+
+func TestRenderWikitext(t *testing.T) {
+	testData := map[string]string{
+		"'''bold''' and ''italic''":                 "bold and italic",
+		"[[Category:English]][[a|b]] [[c#English]]": "b c",
+		"{{m|en|{{l|en|nested}}}} {{unknown|x}}":    "nested",
+		"{{gloss|a meaning}} <sup>1</sup>":          "(a meaning) 1",
+		"unclosed [[link and }} {{ template":        "unclosed link and }}",
+		"{{bor|en|fr|-}}":                           "",
+		"":                                          "",
+	}
+
+	for text, want := range testData {
+		if got := renderWikitext(text); want != got {
+			t.Errorf("renderWikitext(%q) returned %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestAddWikitextDetails(t *testing.T) {
+	entry := func(category string, language string) wiktionaryEntry {
+		entry := wiktionaryEntry{}
+		entry.CategoryVal = category
+		entry.LanguageVal = language
+
+		return entry
+	}
+
+	result := source.ResultValue{EntryVals: []interface{}{
+		entry("adjective", "English"),
+		entry("verb", "English"),
+		entry("noun", "French"),
+		entry("noun", "German"),
+	}}
+
+	result = addWikitextDetails(result, parseWikitext(testWikitext))
+
+	adjective := result.EntryVals[0].(wiktionaryEntry)
+
+	if "ɹɛd" != adjective.Pronunciation() || 2 != len(adjective.Pronunciations()) || 1 != len(adjective.Etymologies()) {
+		t.Errorf("addWikitextDetails added %q, %q, and %q", adjective.Pronunciation(), adjective.Pronunciations(), adjective.Etymologies())
+	}
+
+	if verb := result.EntryVals[1].(wiktionaryEntry); "ɹiː" != verb.Pronunciation() || "re- + do (a clipping)" != verb.Etymologies()[0] {
+		t.Errorf("addWikitextDetails added %q and %q", verb.Pronunciation(), verb.Etymologies())
+	}
+
+	if german := result.EntryVals[3].(wiktionaryEntry); "" != german.Pronunciation() || 0 != len(german.Etymologies()) {
+		t.Errorf("addWikitextDetails added details to an entry of a language without any")
+	}
+}
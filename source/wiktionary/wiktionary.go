@@ -0,0 +1,324 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package wiktionary provides a dictionary source via the Wiktionary REST API
+package wiktionary
+
+import (
+	"context"
+	"html"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Rican7/define/internal/version"
+	"github.com/Rican7/define/source"
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// Name defines the name of the source
+const Name = "Wiktionary"
+
+const (
+	// baseURLString is the base URL for all Wiktionary REST API interactions
+	baseURLString = "https://en.wiktionary.org/api/rest_v1/"
+
+	// definitionURLString is the relative URL for definition lookups
+	definitionURLString = "page/definition/"
+
+	// webURLString is the base URL of Wiktionary's web pages
+	webURLString = "https://en.wiktionary.org/wiki/"
+
+	// wikitextURLString is the URL of the raw wikitext of Wiktionary's pages,
+	// by their titles
+	wikitextURLString = "https://en.wiktionary.org/w/index.php?action=raw&title="
+
+	// AllLanguages is the language selection that includes the sections of
+	// every language
+	AllLanguages = "all"
+
+	// otherLanguagesCode is the code of the section that the API groups
+	// languages without their own code into
+	otherLanguagesCode = "other"
+
+	httpRequestAcceptHeaderName    = "Accept"
+	httpRequestUserAgentHeaderName = "User-Agent"
+
+	jsonMIMEType     = "application/json"
+	wikitextMIMEType = "text/x-wiki"
+)
+
+// apiURL is the URL instance used for Wiktionary API calls
+var apiURL *url.URL
+
+// validMIMETypes is the list of valid response MIME types
+var validMIMETypes = []string{jsonMIMEType}
+
+// htmlCleaner is used to clean the strings returned from the API
+var htmlCleaner = bluemonday.StrictPolicy()
+
+// api is a struct containing a configured HTTP client for Wiktionary API
+// operations
+type api struct {
+	httpClient *http.Client
+	language   string
+	rich       bool
+}
+
+// apiResult is a struct that defines the data structure for Wiktionary API
+// results, which are a map of language codes to the entries in that language
+type apiResult map[string][]*struct {
+	PartOfSpeech string
+	Language     string
+	Definitions  []*struct {
+		Definition string
+		Examples   []string
+	}
+}
+
+// wiktionaryEntry is a struct that contains the entry types for this API
+type wiktionaryEntry struct {
+	source.WordEntryValue
+	source.DictionaryEntryValue
+	source.LanguageEntryValue
+	source.EtymologyEntryValue
+	source.PronunciationEntryValue
+}
+
+// Initialize the package
+func init() {
+	var err error
+
+	apiURL, err = url.Parse(baseURLString)
+
+	if nil != err {
+		panic(err)
+	}
+}
+
+// New returns a new Wiktionary API dictionary source for the given language,
+// selected by either its code (such as "en") or its name (such as "English"),
+// or for every language when given AllLanguages
+func New(httpClient http.Client, language string) source.Source {
+	return &api{httpClient: &httpClient, language: language}
+}
+
+// NewRich returns a new Wiktionary API dictionary source, as with New, that
+// also parses the etymologies and pronunciations of each word from the
+// wikitext of its page (which is slower, and heuristic)
+func NewRich(httpClient http.Client, language string) source.Source {
+	return &api{httpClient: &httpClient, language: language, rich: true}
+}
+
+// Name returns the name of the source
+func (g *api) Name() string {
+	return Name
+}
+
+// Options returns the options that the source's results depend on
+func (g *api) Options() source.Options {
+	options := source.Options{Language: g.language}
+
+	if g.rich {
+		options.Mode = "rich"
+	}
+
+	return options
+}
+
+// WebURL returns the URL of the web page for the given word
+func (g *api) WebURL(word string) string {
+	// Wiktionary page titles use underscores in place of spaces
+	title := strings.Replace(word, " ", "_", -1)
+
+	return webURLString + url.PathEscape(title)
+}
+
+// Request returns the HTTP request used to define the given word
+func (g *api) Request(word string) (*http.Request, error) {
+	// Wiktionary page titles use underscores in place of spaces
+	title := strings.Replace(word, " ", "_", -1)
+
+	// Prepare our URL
+	requestURL, err := url.Parse(definitionURLString + url.PathEscape(title))
+
+	if nil != err {
+		return nil, err
+	}
+
+	httpRequest, err := http.NewRequest(http.MethodGet, apiURL.ResolveReference(requestURL).String(), nil)
+
+	if nil != err {
+		return nil, err
+	}
+
+	httpRequest.Header.Set(httpRequestAcceptHeaderName, jsonMIMEType)
+	httpRequest.Header.Set(httpRequestUserAgentHeaderName, version.AppName+"/"+version.Name())
+
+	return httpRequest, nil
+}
+
+// Define takes a word string and returns a dictionary source.Result
+func (g *api) Define(word string) (source.Result, error) {
+	return g.DefineContext(context.Background(), word)
+}
+
+// DefineContext takes a word string and returns a dictionary source.Result,
+// cancelling its request if the context is done before it finishes
+func (g *api) DefineContext(ctx context.Context, word string) (source.Result, error) {
+	httpRequest, err := g.Request(word)
+
+	if nil != err {
+		return nil, err
+	}
+
+	httpResponse, err := g.httpClient.Do(httpRequest.WithContext(ctx))
+
+	if nil != err {
+		return nil, err
+	}
+
+	defer httpResponse.Body.Close()
+
+	if http.StatusNotFound == httpResponse.StatusCode {
+		return nil, &source.EmptyResultError{Word: word}
+	}
+
+	if err = source.ValidateHTTPResponse(httpResponse, validMIMETypes, nil); nil != err {
+		return nil, err
+	}
+
+	var result apiResult
+
+	if err = source.ReadJSONResponse(Name, httpResponse, &result); nil != err {
+		return nil, err
+	}
+
+	converted := result.toResult(word, g.language)
+
+	if len(converted.EntryVals) < 1 {
+		return nil, &source.EmptyResultError{Word: word}
+	}
+
+	// The details of the wikitext are optional, so the result is still
+	// returned without them if the wikitext can't be read
+	if g.rich {
+		if wikitext, err := g.wikitext(ctx, word); nil == err {
+			converted = addWikitextDetails(converted, parseWikitext(wikitext))
+		}
+	}
+
+	return source.ValidateAndReturnResult(converted)
+}
+
+// wikitext returns the raw wikitext of the page of the given word, cancelling
+// its request if the context is done before it finishes
+func (g *api) wikitext(ctx context.Context, word string) (string, error) {
+	// Wiktionary page titles use underscores in place of spaces
+	title := strings.Replace(word, " ", "_", -1)
+
+	httpRequest, err := http.NewRequest(http.MethodGet, wikitextURLString+url.QueryEscape(title), nil)
+
+	if nil != err {
+		return "", err
+	}
+
+	httpRequest.Header.Set(httpRequestUserAgentHeaderName, version.AppName+"/"+version.Name())
+
+	httpResponse, err := g.httpClient.Do(httpRequest.WithContext(ctx))
+
+	if nil != err {
+		return "", err
+	}
+
+	defer httpResponse.Body.Close()
+
+	if err = source.ValidateHTTPResponse(httpResponse, []string{wikitextMIMEType}, nil); nil != err {
+		return "", err
+	}
+
+	body, err := source.ReadResponse(Name, httpResponse)
+
+	return string(body), err
+}
+
+// toResult converts the proprietary API result to a generic source.Result,
+// including only the sections of the given language
+func (r apiResult) toResult(word string, language string) source.ResultValue {
+	result := source.ResultValue{Head: word}
+
+	for _, code := range r.languageCodes() {
+		for _, section := range r[code] {
+			if AllLanguages != language && !isLanguage(language, code, section.Language) {
+				continue
+			}
+
+			entry := wiktionaryEntry{}
+
+			entry.WordVal = word
+			entry.CategoryVal = strings.ToLower(section.PartOfSpeech)
+			entry.LanguageVal = section.Language
+
+			for _, definition := range section.Definitions {
+				sense := source.SenseValue{}
+
+				if cleaned := sanitize(definition.Definition); "" != cleaned {
+					sense.DefinitionVals = []string{cleaned}
+				} else {
+					continue
+				}
+
+				for _, example := range definition.Examples {
+					if cleaned := sanitize(example); "" != cleaned {
+						sense.ExampleVals = append(sense.ExampleVals, cleaned)
+					}
+				}
+
+				entry.SenseVals = append(entry.SenseVals, sense)
+			}
+
+			if len(entry.SenseVals) > 0 {
+				result.EntryVals = append(result.EntryVals, entry)
+			}
+		}
+	}
+
+	// Results of a single language are labeled with it, so that the entries
+	// aren't grouped under a redundant heading
+	if AllLanguages != language && len(result.EntryVals) > 0 {
+		result.Lang = result.EntryVals[0].(wiktionaryEntry).LanguageVal
+	}
+
+	return result
+}
+
+// languageCodes returns the language codes of the result's sections in a
+// deterministic order, with the catch-all section of other languages last
+func (r apiResult) languageCodes() []string {
+	codes := make([]string, 0, len(r))
+
+	for code := range r {
+		if otherLanguagesCode != code {
+			codes = append(codes, code)
+		}
+	}
+
+	sort.Strings(codes)
+
+	if _, exists := r[otherLanguagesCode]; exists {
+		codes = append(codes, otherLanguagesCode)
+	}
+
+	return codes
+}
+
+// isLanguage returns whether the given language selection matches a section's
+// language code or name
+func isLanguage(selection, code, name string) bool {
+	return strings.EqualFold(selection, code) || strings.EqualFold(selection, name)
+}
+
+// sanitize cleans a string of any markup
+func sanitize(str string) string {
+	return strings.TrimSpace(html.UnescapeString(htmlCleaner.Sanitize(str)))
+}
@@ -0,0 +1,146 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+package wordcentral
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	flag "github.com/ogier/pflag"
+
+	"github.com/Rican7/define/internal/keyring"
+	"github.com/Rican7/define/internal/transport"
+	"github.com/Rican7/define/registry"
+	"github.com/Rican7/define/source"
+)
+
+// RequiredConfigError represents an error when a required configuration key is
+// missing or invalid.
+type RequiredConfigError struct {
+	Key string
+}
+
+type config struct {
+	APIKey  string
+	Headers map[string]string
+
+	// Whether values were loaded from the keyring
+	apiKeyInKeyring bool
+}
+
+type provider struct{}
+
+// JSONKey defines the JSON key used for the provider
+const JSONKey = "MerriamWebsterWordCentral"
+
+// httpTransport is the transport of the HTTP clients of the provided sources,
+// which tests can replace with a stub (nil uses http.DefaultTransport)
+var httpTransport http.RoundTripper
+
+// Flag names, which also serve as the names of secrets in the keyring
+const (
+	apiKeyFlagName = "word-central-api-key"
+)
+
+// envVars is the list of environment variables that the configuration reads
+// its values from
+var envVars = []registry.EnvVar{
+	{Name: "DEFINE_WORDCENTRAL_API_KEY", Key: "APIKey", Aliases: []string{"MERRIAM_WEBSTER_WORD_CENTRAL_API_KEY"}},
+}
+
+func init() {
+	registry.Register(registry.RegisterFunc(register))
+}
+
+func register(flags *flag.FlagSet) (registry.SourceProvider, registry.Configuration) {
+	return &provider{}, initConfig(flags)
+}
+
+func initConfig(flags *flag.FlagSet) *config {
+	conf := &config{}
+
+	// Define our flags
+	flags.StringVar(&conf.APIKey, apiKeyFlagName, "", fmt.Sprintf("The API key (for the Elementary Dictionary) for the %s", Name))
+
+	return conf
+}
+
+func (e *RequiredConfigError) Error() string {
+	return fmt.Sprintf("required configuration key %q is missing", e.Key)
+}
+
+func (c *config) JSONKey() string {
+	return JSONKey
+}
+
+// MarshalJSON defines how the configuration should be JSON marshalled.
+func (c *config) MarshalJSON() ([]byte, error) {
+	// Alias our type so that we can marshal as usual
+	type alias config
+	copy := alias(*c)
+
+	// Never output secrets that are stored in the keyring
+	if c.apiKeyInKeyring {
+		copy.APIKey = keyring.Placeholder
+	}
+
+	return json.Marshal(copy)
+}
+
+// UnmarshalJSON defines how the configuration should be JSON unmarshalled.
+func (c *config) UnmarshalJSON(data []byte) error {
+	// Alias our type so that we can unmarshal as usual
+	type alias config
+	copy := &alias{}
+
+	// Unmarshal into our copy
+	err := json.Unmarshal(data, copy)
+
+	if nil != err {
+		return err
+	}
+
+	if "" == c.APIKey && keyring.Placeholder != copy.APIKey {
+		c.APIKey = copy.APIKey
+	}
+
+	if nil == c.Headers {
+		c.Headers = copy.Headers
+	}
+
+	return nil
+}
+
+func (c *config) Finalize() {
+	if "" == c.APIKey {
+		c.APIKey, c.apiKeyInKeyring = keyring.Lookup(apiKeyFlagName)
+	}
+}
+
+func (p *provider) Name() string {
+	return Name
+}
+
+func (p *provider) Metadata() registry.Metadata {
+	return registry.Metadata{
+		Description: "Merriam-Webster's Elementary Dictionary, for students",
+		Homepage:    "https://www.dictionaryapi.com/",
+		SignupURL:   "https://www.dictionaryapi.com/register/index.htm",
+		RequiredKeys: []registry.RequiredKey{
+			{Name: "APIKey", FlagName: apiKeyFlagName},
+		},
+		Capabilities: []string{registry.CapabilityPronunciations, registry.CapabilityExamples, registry.CapabilityEtymologies, registry.CapabilitySuggestions},
+		EnvVars:      envVars,
+	}
+}
+
+func (p *provider) Provide(conf registry.Configuration) (source.Source, error) {
+	config := conf.(*config)
+
+	if "" == config.APIKey {
+		return nil, &RequiredConfigError{Key: "APIKey"}
+	}
+
+	return New(http.Client{Transport: transport.WithHeaders(httpTransport, config.Headers)}, config.APIKey), nil
+}
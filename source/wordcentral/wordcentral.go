@@ -0,0 +1,59 @@
+// Copyright © 2018 Trevor N. Suarez (Rican7)
+
+// Package wordcentral provides a dictionary source via Merriam-Webster's Word
+// Central, a dictionary for children and early readers, through the Webster
+// Dictionaries API
+package wordcentral
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/Rican7/define/source"
+	"github.com/Rican7/define/source/webster"
+)
+
+// Name defines the name of the source
+const Name = "Merriam-Webster's Word Central"
+
+// Label defines the label shown alongside the source's attribution
+const Label = "Suitable for young readers"
+
+// reference is the Webster API reference of the Elementary Dictionary, which
+// provides Word Central's age-appropriate definitions
+const reference = "sd2"
+
+// webURLString is the URL of Word Central's dictionary search
+const webURLString = "http://wordcentral.com/cgi-bin/student"
+
+// api wraps a Webster API source to label it
+type api struct {
+	source.Source
+}
+
+// New returns a new Word Central dictionary source
+func New(httpClient http.Client, apiKey string) source.Source {
+	return &api{webster.NewReference(httpClient, apiKey, reference, Name)}
+}
+
+// Label returns the label of the source
+func (g *api) Label() string {
+	return Label
+}
+
+// WebURL returns the URL of the web page for the given word
+func (g *api) WebURL(word string) string {
+	return webURLString + "?" + url.Values{"book": {"Student"}, "va": {word}}.Encode()
+}
+
+// Request returns the HTTP request used to define the given word
+func (g *api) Request(word string) (*http.Request, error) {
+	return g.Source.(source.RequestSource).Request(word)
+}
+
+// DefineContext takes a word string and returns a dictionary source.Result,
+// cancelling its request if the context is done before it finishes
+func (g *api) DefineContext(ctx context.Context, word string) (source.Result, error) {
+	return source.DefineContext(ctx, g.Source, word)
+}